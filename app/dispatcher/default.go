@@ -4,6 +4,7 @@ package dispatcher
 
 import (
 	"context"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -173,6 +174,36 @@ func (d *DefaultDispatcher) getLink(ctx context.Context) (*transport.Link, *tran
 	return inboundLink, outboundLink
 }
 
+// recordRuleStats counts the hit of a routing rule, and the traffic of the link it routes.
+func (d *DefaultDispatcher) recordRuleStats(ruleID uint32, link *transport.Link) {
+	p := d.policy.ForSystem()
+	name := "rule>>>" + strconv.FormatUint(uint64(ruleID), 10)
+	if p.Stats.RuleHit {
+		if c, _ := stats.GetOrRegisterCounter(d.stats, name+">>>hits"); c != nil {
+			c.Add(1)
+		}
+		if c, _ := stats.GetOrRegisterCounter(d.stats, name+">>>last_hit"); c != nil {
+			c.Set(time.Now().Unix())
+		}
+	}
+	if p.Stats.RuleUplink {
+		if c, _ := stats.GetOrRegisterCounter(d.stats, name+">>>traffic>>>uplink"); c != nil {
+			link.Reader = &SizeStatReader{
+				Counter: c,
+				Reader:  link.Reader,
+			}
+		}
+	}
+	if p.Stats.RuleDownlink {
+		if c, _ := stats.GetOrRegisterCounter(d.stats, name+">>>traffic>>>downlink"); c != nil {
+			link.Writer = &SizeStatWriter{
+				Counter: c,
+				Writer:  link.Writer,
+			}
+		}
+	}
+}
+
 func shouldOverride(result SniffResult, domainOverride []string) bool {
 	if result.Domain() == "" {
 		return false
@@ -299,6 +330,9 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 	} else if d.router != nil {
 		if route, err := d.router.PickRoute(routing_session.AsRoutingContext(ctx)); err == nil {
 			tag := route.GetOutboundTag()
+			if ruleRoute, ok := route.(routing.RuleRoute); ok {
+				d.recordRuleStats(ruleRoute.GetRuleID(), link)
+			}
 			if h := d.ohm.GetHandler(tag); h != nil {
 				newError("taking detour [", tag, "] for [", destination, "]").WriteToLog(session.ExportIDToError(ctx))
 				handler = h
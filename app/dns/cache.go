@@ -0,0 +1,465 @@
+//go:build !confonly
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	core "github.com/v2fly/v2ray-core/v5"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/signal/pubsub"
+	"github.com/v2fly/v2ray-core/v5/common/task"
+	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
+)
+
+const (
+	// staleAnswerTimeout is the time to wait for a fresh answer before a stale one is served, as the client response
+	// timer suggested by RFC 8767.
+	staleAnswerTimeout = 1800 * time.Millisecond
+
+	defaultPrefetchMinHits = 2
+)
+
+// cachedServer is a name server caching its answers in an ipCache.
+type cachedServer interface {
+	Server
+	ipCache() *ipCache
+}
+
+// queryFunc sends the queries of domain to a name server. The answers are expected to be put into the cache.
+type queryFunc func(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption)
+
+// cachedIPRecord is an IPRecord with its TTL when cached.
+type cachedIPRecord struct {
+	*IPRecord
+	ttl time.Duration
+}
+
+type cacheEntry struct {
+	A    *cachedIPRecord
+	AAAA *cachedIPRecord
+
+	// hits is the number of queries answered by the entry since it was last updated.
+	hits uint32
+	// prefetching is set when a prefetch query has been sent since the entry was last updated.
+	prefetching bool
+}
+
+func (e *cacheEntry) get(reqType dnsmessage.Type) *cachedIPRecord {
+	if reqType == dnsmessage.TypeAAAA {
+		return e.AAAA
+	}
+	return e.A
+}
+
+func (e *cacheEntry) set(reqType dnsmessage.Type, rec *cachedIPRecord) {
+	if reqType == dnsmessage.TypeAAAA {
+		e.AAAA = rec
+	} else {
+		e.A = rec
+	}
+}
+
+// ipCache caches the A and AAAA records answered by a name server, and notifies the queries waiting for them.
+type ipCache struct {
+	sync.RWMutex
+	name    string
+	config  *CacheConfig
+	entries map[string]*cacheEntry
+	pub     *pubsub.Service
+	cleanup *task.Periodic
+
+	now func() time.Time
+}
+
+func newIPCache(name string) *ipCache {
+	c := &ipCache{
+		name:    name,
+		config:  &CacheConfig{},
+		entries: make(map[string]*cacheEntry),
+		pub:     pubsub.NewService(),
+		now:     time.Now,
+	}
+	c.cleanup = &task.Periodic{
+		Interval: time.Minute,
+		Execute:  c.Cleanup,
+	}
+	return c
+}
+
+// SetConfig applies config to the cache. It is expected to be called before any query.
+func (c *ipCache) SetConfig(config *CacheConfig) {
+	if config == nil {
+		config = &CacheConfig{}
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.config = config
+}
+
+func (c *ipCache) staleMaxAge() time.Duration {
+	return time.Duration(c.config.ServeStaleMaxAge) * time.Second
+}
+
+// Cleanup clears expired items from cache
+func (c *ipCache) Cleanup() error {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(c.entries) == 0 {
+		return newError(c.name, " nothing to do. stopping...")
+	}
+
+	// Records are kept after expiry as long as they may be served stale.
+	deadline := c.now().Add(-c.staleMaxAge())
+	for domain, entry := range c.entries {
+		if entry.A != nil && entry.A.Expire.Before(deadline) {
+			entry.A = nil
+		}
+		if entry.AAAA != nil && entry.AAAA.Expire.Before(deadline) {
+			entry.AAAA = nil
+		}
+
+		if entry.A == nil && entry.AAAA == nil {
+			newError(c.name, " cleanup ", domain).AtDebug().WriteToLog()
+			delete(c.entries, domain)
+		}
+	}
+
+	if len(c.entries) == 0 {
+		c.entries = make(map[string]*cacheEntry)
+	}
+
+	return nil
+}
+
+// clampTTL limits the lifetime of rec within the minimum and maximum TTL of config.
+func (c *ipCache) clampTTL(rec *IPRecord) time.Duration {
+	now := c.now()
+	ttl := rec.Expire.Sub(now)
+	if minTTL := time.Duration(c.config.MinTtl) * time.Second; minTTL > 0 && ttl < minTTL {
+		ttl = minTTL
+	}
+	if maxTTL := time.Duration(c.config.MaxTtl) * time.Second; maxTTL > 0 && ttl > maxTTL {
+		ttl = maxTTL
+	}
+	rec.Expire = now.Add(ttl)
+	return ttl
+}
+
+// isServerFailure tells whether rec reports a failure of the name server rather than an answer of the domain.
+func isServerFailure(rec *IPRecord) bool {
+	return rec.RCode != dnsmessage.RCodeSuccess && rec.RCode != dnsmessage.RCodeNameError
+}
+
+// Update caches rec answering the query of reqType for domain, and notifies the queries waiting for it.
+func (c *ipCache) Update(domain string, reqType dnsmessage.Type, rec *IPRecord) {
+	if reqType == dnsmessage.TypeAAAA {
+		addr := make([]net.Address, 0, len(rec.IP))
+		for _, ip := range rec.IP {
+			if len(ip.IP()) == net.IPv6len {
+				addr = append(addr, ip)
+			}
+		}
+		rec.IP = addr
+	}
+
+	c.Lock()
+	entry, found := c.entries[domain]
+	if !found {
+		entry = &cacheEntry{}
+		c.entries[domain] = entry
+	}
+	ttl := c.clampTTL(rec)
+	current := entry.get(reqType)
+	switch {
+	case current != nil && isServerFailure(rec) && !isServerFailure(current.IPRecord) &&
+		current.Expire.Add(c.staleMaxAge()).After(c.now()):
+		// Keep the record to be served stale while the name server is failing.
+		newError(c.name, " keeps stale record of ", domain, " ", reqType, " on ", rec.RCode).AtDebug().WriteToLog()
+	case current == nil || isNewer(current.IPRecord, rec):
+		entry.set(reqType, &cachedIPRecord{IPRecord: rec, ttl: ttl})
+		entry.hits = 0
+		entry.prefetching = false
+	}
+	switch reqType {
+	case dnsmessage.TypeA:
+		c.pub.Publish(domain+"4", nil)
+	case dnsmessage.TypeAAAA:
+		c.pub.Publish(domain+"6", nil)
+	}
+	c.Unlock()
+	common.Must(c.cleanup.Start())
+}
+
+// find returns the IPs of domain which have not expired. If stale is set, expired records within the maximum stale
+// age are returned as well.
+func (c *ipCache) find(domain string, option dns_feature.IPOption, stale bool) ([]net.IP, error) {
+	c.RLock()
+	entry, found := c.entries[domain]
+	if !found {
+		c.RUnlock()
+		return nil, errRecordNotFound
+	}
+	deadline := c.now()
+	if stale {
+		deadline = deadline.Add(-c.staleMaxAge())
+	}
+	var records []*cachedIPRecord
+	if option.IPv4Enable {
+		records = append(records, entry.A)
+	}
+	if option.IPv6Enable {
+		records = append(records, entry.AAAA)
+	}
+	c.RUnlock()
+
+	var ips []net.Address
+	var lastErr error
+	for _, rec := range records {
+		switch {
+		case rec == nil || !rec.Expire.After(deadline):
+			lastErr = errRecordNotFound
+		case rec.RCode != dnsmessage.RCodeSuccess:
+			lastErr = dns_feature.RCodeError(rec.RCode)
+		default:
+			ips = append(ips, rec.IP...)
+		}
+	}
+
+	if len(ips) > 0 {
+		return toNetIP(ips)
+	}
+
+	if lastErr != nil {
+		return nil, lastErr
+	}
+
+	return nil, dns_feature.ErrEmptyResponse
+}
+
+// hit counts a query answered by the cached records of domain, and tells whether they are to be prefetched.
+func (c *ipCache) hit(domain string, option dns_feature.IPOption) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	entry, found := c.entries[domain]
+	if !found {
+		return false
+	}
+	entry.hits++
+	if !c.config.Prefetch || entry.prefetching {
+		return false
+	}
+	minHits := c.config.PrefetchMinHits
+	if minHits == 0 {
+		minHits = defaultPrefetchMinHits
+	}
+	if entry.hits < minHits {
+		return false
+	}
+	now := c.now()
+	for _, rec := range []*cachedIPRecord{entry.A, entry.AAAA} {
+		if rec == nil || (rec == entry.A && !option.IPv4Enable) || (rec == entry.AAAA && !option.IPv6Enable) {
+			continue
+		}
+		if rec.Expire.Sub(now) < rec.ttl/10 {
+			entry.prefetching = true
+			return true
+		}
+	}
+	return false
+}
+
+// QueryIP answers the query of domain from the cache, or sends it with query and waits for the answers. An expired
+// record is served if no fresh answer arrives in time, as long as serve-stale allows.
+func (c *ipCache) QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption, disableCache bool, query queryFunc) ([]net.IP, error) {
+	fqdn := Fqdn(domain)
+
+	var staleIPs []net.IP
+	if disableCache {
+		newError("DNS cache is disabled. Querying IP for ", domain, " at ", c.name).AtDebug().WriteToLog()
+	} else {
+		ips, err := c.find(fqdn, option, false)
+		if err != errRecordNotFound {
+			newError(c.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
+			if c.hit(fqdn, option) {
+				newError(c.name, " prefetching ", domain).AtDebug().WriteToLog()
+				query(core.ToBackgroundDetachedContext(ctx), fqdn, clientIP, option)
+			}
+			return ips, err
+		}
+		if c.staleMaxAge() > 0 {
+			staleIPs, _ = c.find(fqdn, option, true)
+		}
+	}
+
+	// ipv4 and ipv6 belong to different subscription groups
+	var sub4, sub6 *pubsub.Subscriber
+	if option.IPv4Enable {
+		sub4 = c.pub.Subscribe(fqdn + "4")
+		defer sub4.Close()
+	}
+	if option.IPv6Enable {
+		sub6 = c.pub.Subscribe(fqdn + "6")
+		defer sub6.Close()
+	}
+	done := make(chan interface{})
+	go func() {
+		if sub4 != nil {
+			select {
+			case <-sub4.Wait():
+			case <-ctx.Done():
+			}
+		}
+		if sub6 != nil {
+			select {
+			case <-sub6.Wait():
+			case <-ctx.Done():
+			}
+		}
+		close(done)
+	}()
+
+	var staleTimeout <-chan time.Time
+	if len(staleIPs) > 0 {
+		// The answers are still cached for later queries, after the stale ones are served.
+		query(core.ToBackgroundDetachedContext(ctx), fqdn, clientIP, option)
+		timer := time.NewTimer(staleAnswerTimeout)
+		defer timer.Stop()
+		staleTimeout = timer.C
+	} else {
+		query(ctx, fqdn, clientIP, option)
+	}
+
+	for {
+		ips, err := c.find(fqdn, option, false)
+		if err != errRecordNotFound {
+			return ips, err
+		}
+
+		select {
+		case <-ctx.Done():
+			if len(staleIPs) > 0 {
+				return staleIPs, nil
+			}
+			return nil, ctx.Err()
+		case <-staleTimeout:
+			newError(c.name, " serving stale ", domain, " -> ", staleIPs).AtDebug().WriteToLog()
+			return staleIPs, nil
+		case <-done:
+			if len(staleIPs) > 0 {
+				// The name server has answered with a failure, and the stale record is kept.
+				if ips, err := c.find(fqdn, option, false); err != errRecordNotFound {
+					return ips, err
+				}
+				newError(c.name, " serving stale ", domain, " -> ", staleIPs).AtDebug().WriteToLog()
+				return staleIPs, nil
+			}
+			done = nil
+		}
+	}
+}
+
+// Records returns the cached records of domain, or all records if domain is empty.
+func (c *ipCache) Records(domain string) []*CacheRecord {
+	c.RLock()
+	defer c.RUnlock()
+
+	var domains []string
+	if domain != "" {
+		if _, found := c.entries[Fqdn(domain)]; found {
+			domains = append(domains, Fqdn(domain))
+		}
+	} else {
+		for domain := range c.entries {
+			domains = append(domains, domain)
+		}
+		sort.Strings(domains)
+	}
+
+	var records []*CacheRecord
+	for _, domain := range domains {
+		entry := c.entries[domain]
+		for _, reqType := range []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeAAAA} {
+			rec := entry.get(reqType)
+			if rec == nil {
+				continue
+			}
+			cacheRecord := &CacheRecord{
+				Server: c.name,
+				Domain: domain,
+				Type:   uint32(reqType),
+				Rcode:  uint32(rec.RCode),
+				Expire: rec.Expire.Unix(),
+			}
+			for _, ip := range rec.IP {
+				cacheRecord.Ip = append(cacheRecord.Ip, ip.IP())
+			}
+			records = append(records, cacheRecord)
+		}
+	}
+	return records
+}
+
+// Restore caches records, unless they have expired beyond the maximum stale age.
+func (c *ipCache) Restore(records []*CacheRecord) int {
+	deadline := c.now().Add(-c.staleMaxAge())
+	restored := 0
+
+	c.Lock()
+	for _, cacheRecord := range records {
+		reqType := dnsmessage.Type(cacheRecord.Type)
+		if reqType != dnsmessage.TypeA && reqType != dnsmessage.TypeAAAA {
+			continue
+		}
+		rec := &IPRecord{
+			RCode:  dnsmessage.RCode(cacheRecord.Rcode),
+			Expire: time.Unix(cacheRecord.Expire, 0),
+		}
+		if rec.Expire.Before(deadline) {
+			continue
+		}
+		for _, ip := range cacheRecord.Ip {
+			rec.IP = append(rec.IP, net.IPAddress(ip))
+		}
+		entry, found := c.entries[cacheRecord.Domain]
+		if !found {
+			entry = &cacheEntry{}
+			c.entries[cacheRecord.Domain] = entry
+		}
+		entry.set(reqType, &cachedIPRecord{IPRecord: rec, ttl: rec.Expire.Sub(c.now())})
+		restored++
+	}
+	c.Unlock()
+
+	if restored > 0 {
+		common.Must(c.cleanup.Start())
+	}
+	return restored
+}
+
+// Flush removes the cached records of domain, or all records if domain is empty. It returns the number of domains
+// removed.
+func (c *ipCache) Flush(domain string) int {
+	c.Lock()
+	defer c.Unlock()
+
+	if domain != "" {
+		if _, found := c.entries[Fqdn(domain)]; !found {
+			return 0
+		}
+		delete(c.entries, Fqdn(domain))
+		return 1
+	}
+	n := len(c.entries)
+	c.entries = make(map[string]*cacheEntry)
+	return n
+}
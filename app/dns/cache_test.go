@@ -0,0 +1,156 @@
+package dns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
+)
+
+func newTestIPCache(config *CacheConfig) (*ipCache, *time.Time) {
+	now := time.Unix(1600000000, 0)
+	c := newIPCache("test")
+	c.SetConfig(config)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestIPCacheServeStale(t *testing.T) {
+	c, now := newTestIPCache(&CacheConfig{ServeStaleMaxAge: 60})
+	c.Update("example.com.", dnsmessage.TypeA, &IPRecord{
+		IP:     []net.Address{net.ParseAddress("1.2.3.4")},
+		Expire: now.Add(time.Second * 10),
+	})
+	*now = now.Add(time.Second * 20)
+
+	option := dns_feature.IPOption{IPv4Enable: true}
+	queried := 0
+	failing := func(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
+		queried++
+		c.Update(domain, dnsmessage.TypeA, &IPRecord{RCode: dnsmessage.RCodeServerFailure, Expire: c.now().Add(time.Second * 600)})
+	}
+	ips, err := c.QueryIP(context.Background(), "example.com", nil, option, false, failing)
+	common.Must(err)
+	if queried != 1 || len(ips) != 1 || ips[0].String() != "1.2.3.4" {
+		t.Error("expect stale answer after a query, got ", ips, " with ", queried, " queries")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	silent := func(context.Context, string, net.IP, dns_feature.IPOption) {}
+	ips, err = c.QueryIP(ctx, "example.com", nil, option, false, silent)
+	common.Must(err)
+	if len(ips) != 1 || ips[0].String() != "1.2.3.4" {
+		t.Error("expect stale answer without response, got ", ips)
+	}
+
+	*now = now.Add(time.Second * 60)
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	if _, err := c.QueryIP(ctx, "example.com", nil, option, false, silent); err != context.DeadlineExceeded {
+		t.Error("expect no stale answer beyond max age, got ", err)
+	}
+}
+
+func TestIPCacheClampTTL(t *testing.T) {
+	c, now := newTestIPCache(&CacheConfig{MinTtl: 60, MaxTtl: 300})
+	for _, tc := range []struct {
+		ttl    time.Duration
+		expect time.Duration
+	}{
+		{ttl: time.Second * 5, expect: time.Second * 60},
+		{ttl: time.Second * 120, expect: time.Second * 120},
+		{ttl: time.Hour, expect: time.Second * 300},
+	} {
+		c.Flush("")
+		c.Update("example.com.", dnsmessage.TypeA, &IPRecord{
+			IP:     []net.Address{net.ParseAddress("1.2.3.4")},
+			Expire: now.Add(tc.ttl),
+		})
+		records := c.Records("example.com")
+		if len(records) != 1 || records[0].Expire != now.Add(tc.expect).Unix() {
+			t.Error("expect TTL ", tc.expect, " for ", tc.ttl, ", got ", records)
+		}
+	}
+}
+
+func TestIPCachePrefetch(t *testing.T) {
+	c, now := newTestIPCache(&CacheConfig{Prefetch: true})
+	c.Update("example.com.", dnsmessage.TypeA, &IPRecord{
+		IP:     []net.Address{net.ParseAddress("1.2.3.4")},
+		Expire: now.Add(time.Second * 100),
+	})
+
+	queried := 0
+	query := func(context.Context, string, net.IP, dns_feature.IPOption) { queried++ }
+	option := dns_feature.IPOption{IPv4Enable: true}
+	lookup := func() {
+		t.Helper()
+		if _, err := c.QueryIP(context.Background(), "example.com", nil, option, false, query); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lookup()
+	*now = now.Add(time.Second * 95)
+	lookup()
+	if queried != 1 {
+		t.Error("expect a prefetch near expiry, got ", queried)
+	}
+	lookup()
+	if queried != 1 {
+		t.Error("expect a single prefetch, got ", queried)
+	}
+}
+
+func TestIPCacheSnapshot(t *testing.T) {
+	c, now := newTestIPCache(&CacheConfig{})
+	c.Update("example.com.", dnsmessage.TypeA, &IPRecord{
+		IP:     []net.Address{net.ParseAddress("1.2.3.4")},
+		Expire: now.Add(time.Second * 100),
+	})
+	c.Update("example.com.", dnsmessage.TypeAAAA, &IPRecord{
+		IP:     []net.Address{net.ParseAddress("1.2.3.4"), net.ParseAddress("::1")},
+		Expire: now.Add(time.Second * 100),
+	})
+	c.Update("example.org.", dnsmessage.TypeA, &IPRecord{
+		RCode:  dnsmessage.RCodeNameError,
+		Expire: now.Add(time.Second * 100),
+	})
+
+	records := c.Records("")
+	if len(records) != 3 {
+		t.Fatal("expect 3 records, got ", records)
+	}
+	if len(records[1].Ip) != 1 || net.IP(records[1].Ip[0]).String() != "::1" {
+		t.Error("expect only IPv6 addresses in AAAA record, got ", records[1])
+	}
+
+	restored, _ := newTestIPCache(&CacheConfig{})
+	if n := restored.Restore(records); n != 3 {
+		t.Error("expect 3 records restored, got ", n)
+	}
+	ips, err := restored.find("example.com.", dns_feature.IPOption{IPv4Enable: true, IPv6Enable: true}, false)
+	common.Must(err)
+	if len(ips) != 2 {
+		t.Error("expect restored IPs, got ", ips)
+	}
+	if _, err := restored.find("example.org.", dns_feature.IPOption{IPv4Enable: true}, false); err != dns_feature.RCodeError(dnsmessage.RCodeNameError) {
+		t.Error("expect restored NXDOMAIN, got ", err)
+	}
+
+	if n := restored.Flush("example.org"); n != 1 {
+		t.Error("expect a domain flushed, got ", n)
+	}
+	if n := restored.Flush(""); n != 1 {
+		t.Error("expect a domain flushed, got ", n)
+	}
+	if records := restored.Records(""); len(records) != 0 {
+		t.Error("expect empty cache, got ", records)
+	}
+}
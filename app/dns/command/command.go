@@ -0,0 +1,76 @@
+//go:build !confonly
+// +build !confonly
+
+package command
+
+//go:generate go run github.com/v2fly/v2ray-core/v5/common/errors/errorgen
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	core "github.com/v2fly/v2ray-core/v5"
+	"github.com/v2fly/v2ray-core/v5/app/dns"
+	"github.com/v2fly/v2ray-core/v5/common"
+	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
+)
+
+// cacheManager is a DNS client whose cache can be inspected and flushed.
+type cacheManager interface {
+	ListCache(domain string) []*dns.CacheRecord
+	FlushCache(domain string) int
+}
+
+type service struct {
+	UnimplementedDNSServiceServer
+	v *core.Instance
+
+	client dns_feature.Client
+}
+
+func (s *service) getCacheManager() (cacheManager, error) {
+	manager, ok := s.client.(cacheManager)
+	if !ok {
+		return nil, newError("DNS client does not have a cache")
+	}
+	return manager, nil
+}
+
+func (s *service) ListCache(ctx context.Context, request *ListCacheRequest) (*ListCacheResponse, error) {
+	manager, err := s.getCacheManager()
+	if err != nil {
+		return nil, err
+	}
+	return &ListCacheResponse{
+		Record: manager.ListCache(request.Domain),
+	}, nil
+}
+
+func (s *service) FlushCache(ctx context.Context, request *FlushCacheRequest) (*FlushCacheResponse, error) {
+	manager, err := s.getCacheManager()
+	if err != nil {
+		return nil, err
+	}
+	return &FlushCacheResponse{
+		Count: uint32(manager.FlushCache(request.Domain)),
+	}, nil
+}
+
+func (s *service) Register(server *grpc.Server) {
+	RegisterDNSServiceServer(server, s)
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
+		s := core.MustFromContext(ctx)
+		sv := &service{v: s}
+		err := s.RequireFeatures(func(client dns_feature.Client) {
+			sv.client = client
+		})
+		if err != nil {
+			return nil, err
+		}
+		return sv, nil
+	}))
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/v2fly/v2ray-core/v5/app/dns"
+	"github.com/v2fly/v2ray-core/v5/common"
+	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
+)
+
+type cachedClient struct {
+	dns_feature.Client
+	records []*dns.CacheRecord
+}
+
+func (c *cachedClient) ListCache(domain string) []*dns.CacheRecord {
+	var records []*dns.CacheRecord
+	for _, record := range c.records {
+		if domain == "" || record.Domain == domain+"." {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+func (c *cachedClient) FlushCache(domain string) int {
+	n := len(c.records)
+	c.records = nil
+	return n
+}
+
+func TestCacheService(t *testing.T) {
+	client := &cachedClient{records: []*dns.CacheRecord{
+		{Server: "a", Domain: "example.com.", Type: 1},
+		{Server: "a", Domain: "example.org.", Type: 1},
+	}}
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterDNSServiceServer(server, &service{client: client})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	common.Must(err)
+	defer conn.Close()
+	c := NewDNSServiceClient(conn)
+
+	list, err := c.ListCache(context.Background(), &ListCacheRequest{Domain: "example.com"})
+	common.Must(err)
+	if len(list.Record) != 1 || list.Record[0].Domain != "example.com." {
+		t.Error("unexpected records: ", list.Record)
+	}
+
+	flush, err := c.FlushCache(context.Background(), &FlushCacheRequest{})
+	common.Must(err)
+	if flush.Count != 2 {
+		t.Error("expect 2 domains flushed, got ", flush.Count)
+	}
+}
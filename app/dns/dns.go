@@ -11,16 +11,26 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+
+	core "github.com/v2fly/v2ray-core/v5"
 	"github.com/v2fly/v2ray-core/v5/app/router"
 	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/environment"
+	"github.com/v2fly/v2ray-core/v5/common/environment/envctx"
 	"github.com/v2fly/v2ray-core/v5/common/errors"
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/common/platform"
 	"github.com/v2fly/v2ray-core/v5/common/session"
 	"github.com/v2fly/v2ray-core/v5/common/strmatcher"
+	"github.com/v2fly/v2ray-core/v5/common/task"
 	"github.com/v2fly/v2ray-core/v5/features"
 	"github.com/v2fly/v2ray-core/v5/features/dns"
+	"github.com/v2fly/v2ray-core/v5/features/extension/storage"
+	"github.com/v2fly/v2ray-core/v5/features/policy"
+	"github.com/v2fly/v2ray-core/v5/features/stats"
 	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon"
 	"github.com/v2fly/v2ray-core/v5/infra/conf/geodata"
 )
@@ -38,8 +48,19 @@ type DNS struct {
 	ctx                    context.Context
 	domainMatcher          strmatcher.IndexMatcher
 	matcherInfos           []DomainMatcherInfo
+	storage                storage.ScopedPersistentStorage
+	persistTask            *task.Periodic
+	queryMode              QueryMode
+	parallelConcurrency    uint32
+	records                *recordCache
+	strip                  *stripRules
 }
 
+const (
+	persistentStorageKey = "cache"
+	persistInterval      = time.Minute * 5
+)
+
 // DomainMatcherInfo contains information attached to index returned by Server.domainMatcher
 type DomainMatcherInfo struct {
 	clientIdx     uint16
@@ -90,6 +111,11 @@ func New(ctx context.Context, config *Config) (*DNS, error) {
 		return nil, newError("failed to create hosts").Base(err)
 	}
 
+	strip, err := newStripRules(config.StripRule)
+	if err != nil {
+		return nil, err
+	}
+
 	clients := []*Client{}
 	domainRuleCount := 0
 	for _, ns := range config.NameServer {
@@ -138,6 +164,46 @@ func New(ctx context.Context, config *Config) (*DNS, error) {
 		clients = append(clients, NewLocalDNSClient())
 	}
 
+	for _, client := range clients {
+		if cached, ok := client.server.(cachedServer); ok {
+			cached.ipCache().SetConfig(config.Cache)
+		}
+	}
+
+	if config.Dnssec != nil {
+		anchors, err := parseTrustAnchors(config.Dnssec.TrustAnchor)
+		if err != nil {
+			return nil, err
+		}
+		for _, client := range clients {
+			if server, ok := client.server.(validatingServer); ok {
+				client.validator = newDNSSECValidator(server, anchors, config.Dnssec.BogusPolicy)
+				server.setValidator(client.validator)
+			}
+		}
+	}
+
+	err = core.RequireFeatures(ctx, func(pm policy.Manager, sm stats.Manager) {
+		if !pm.ForSystem().Stats.DNSServer {
+			return
+		}
+		for _, client := range clients {
+			client.counters = newServerCounters(sm, client.Name())
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var persistentStorage storage.ScopedPersistentStorage
+	if config.Cache.GetPersistent() {
+		if env, ok := envctx.EnvironmentFromContext(ctx).(environment.AppEnvironment); ok {
+			persistentStorage = env.PersistentStorage()
+		} else {
+			newError("persistent storage is not available, DNS cache will not be saved").AtWarning().WriteToLog()
+		}
+	}
+
 	return &DNS{
 		tag:                    tag,
 		hosts:                  hosts,
@@ -149,6 +215,11 @@ func New(ctx context.Context, config *Config) (*DNS, error) {
 		disableCache:           config.DisableCache,
 		disableFallback:        config.DisableFallback,
 		disableFallbackIfMatch: config.DisableFallbackIfMatch,
+		storage:                persistentStorage,
+		queryMode:              config.QueryMode,
+		parallelConcurrency:    config.ParallelConcurrency,
+		records:                newRecordCache(config.Cache),
+		strip:                  strip,
 	}, nil
 }
 
@@ -159,14 +230,104 @@ func (*DNS) Type() interface{} {
 
 // Start implements common.Runnable.
 func (s *DNS) Start() error {
+	if s.storage != nil {
+		s.restoreCache()
+		s.persistTask = &task.Periodic{
+			Interval: persistInterval,
+			Execute: func() error {
+				s.persistCache()
+				return nil
+			},
+		}
+		return s.persistTask.Start()
+	}
 	return nil
 }
 
 // Close implements common.Closable.
 func (s *DNS) Close() error {
+	if s.persistTask != nil {
+		common.Close(s.persistTask)
+		s.persistCache()
+	}
 	return nil
 }
 
+// cachedServers returns the name servers with a cache.
+func (s *DNS) cachedServers() []*ipCache {
+	var caches []*ipCache
+	for _, client := range s.clients {
+		if cached, ok := client.server.(cachedServer); ok {
+			caches = append(caches, cached.ipCache())
+		}
+	}
+	return caches
+}
+
+// restoreCache loads the cache saved by the last run from the persistent storage.
+func (s *DNS) restoreCache() {
+	value, err := s.storage.Get(s.ctx, []byte(persistentStorageKey))
+	if err != nil {
+		newError("no saved DNS cache restored").Base(err).AtDebug().WriteToLog()
+		return
+	}
+	snapshot := &CacheSnapshot{}
+	if err := proto.Unmarshal(value, snapshot); err != nil {
+		newError("failed to restore saved DNS cache").Base(err).AtWarning().WriteToLog()
+		return
+	}
+	records := make(map[string][]*CacheRecord)
+	for _, record := range snapshot.Record {
+		records[record.Server] = append(records[record.Server], record)
+	}
+	for _, cache := range s.cachedServers() {
+		if n := cache.Restore(records[cache.name]); n > 0 {
+			newError("restored ", n, " DNS records of ", cache.name).AtInfo().WriteToLog()
+		}
+	}
+}
+
+// persistCache saves the cache to the persistent storage.
+func (s *DNS) persistCache() {
+	value, err := proto.Marshal(&CacheSnapshot{Record: s.ListCache("")})
+	if err != nil {
+		newError("failed to encode DNS cache").Base(err).AtWarning().WriteToLog()
+		return
+	}
+	if err := s.storage.Put(s.ctx, []byte(persistentStorageKey), value); err != nil {
+		newError("failed to save DNS cache").Base(err).AtDebug().WriteToLog()
+	}
+}
+
+// ListCache returns the records of domain cached by all name servers, or all cached records if domain is empty.
+func (s *DNS) ListCache(domain string) []*CacheRecord {
+	var records []*CacheRecord
+	for _, cache := range s.cachedServers() {
+		records = append(records, cache.Records(domain)...)
+	}
+	return records
+}
+
+// FlushCache removes the records of domain from the cache of all name servers, or all cached records if domain is
+// empty. It returns the number of domains removed, counted per name server, plus the number of cached answers of
+// other record types removed.
+func (s *DNS) FlushCache(domain string) int {
+	flushed := s.records.Flush(domain)
+	for _, cache := range s.cachedServers() {
+		flushed += cache.Flush(domain)
+	}
+	return flushed
+}
+
+// sourceFromContext returns the source IP of the client whose query is in ctx, or nil if there is none.
+func sourceFromContext(ctx context.Context) net.IP {
+	inbound := session.InboundFromContext(ctx)
+	if inbound == nil || !inbound.Source.IsValid() || !inbound.Source.Address.Family().IsIP() {
+		return nil
+	}
+	return inbound.Source.Address.IP()
+}
+
 // IsOwnLink implements proxy.dns.ownLinkVerifier
 func (s *DNS) IsOwnLink(ctx context.Context) bool {
 	inbound := session.InboundFromContext(ctx)
@@ -175,7 +336,7 @@ func (s *DNS) IsOwnLink(ctx context.Context) bool {
 
 // LookupIP implements dns.Client.
 func (s *DNS) LookupIP(domain string) ([]net.IP, error) {
-	return s.lookupIPInternal(domain, *s.ipOption)
+	return s.lookupIPInternal(domain, *s.ipOption, nil)
 }
 
 // LookupIPv4 implements dns.IPv4Lookup.
@@ -185,7 +346,7 @@ func (s *DNS) LookupIPv4(domain string) ([]net.IP, error) {
 	}
 	o := *s.ipOption
 	o.IPv6Enable = false
-	return s.lookupIPInternal(domain, o)
+	return s.lookupIPInternal(domain, o, nil)
 }
 
 // LookupIPv6 implements dns.IPv6Lookup.
@@ -195,10 +356,12 @@ func (s *DNS) LookupIPv6(domain string) ([]net.IP, error) {
 	}
 	o := *s.ipOption
 	o.IPv4Enable = false
-	return s.lookupIPInternal(domain, o)
+	return s.lookupIPInternal(domain, o, nil)
 }
 
-func (s *DNS) lookupIPInternal(domain string, option dns.IPOption) ([]net.IP, error) {
+// lookupIPInternal resolves the IPs of domain, with the name servers serving the client of source IP, which is nil for
+// lookups not from clients.
+func (s *DNS) lookupIPInternal(domain string, option dns.IPOption, source net.IP) ([]net.IP, error) {
 	if domain == "" {
 		return nil, newError("empty domain name")
 	}
@@ -206,6 +369,13 @@ func (s *DNS) lookupIPInternal(domain string, option dns.IPOption) ([]net.IP, er
 	// Normalize the FQDN form query
 	domain = strings.TrimSuffix(domain, ".")
 
+	if option.IPv6Enable && s.strip.stripAAAA(domain) {
+		option.IPv6Enable = false
+		if !option.IPv4Enable {
+			return nil, dns.ErrEmptyResponse
+		}
+	}
+
 	// Static host lookup
 	switch addrs := s.hosts.Lookup(domain, option); {
 	case addrs == nil: // Domain not recorded in static host
@@ -221,13 +391,21 @@ func (s *DNS) lookupIPInternal(domain string, option dns.IPOption) ([]net.IP, er
 	}
 
 	// Name servers lookup
-	errs := []error{}
 	ctx := session.ContextWithInbound(s.ctx, &session.Inbound{Tag: s.tag})
-	for _, client := range s.sortClients(domain) {
+	clients := make([]*Client, 0, len(s.clients))
+	for _, client := range s.sortClients(domain, source) {
 		if !option.FakeEnable && strings.EqualFold(client.Name(), "FakeDNS") {
 			newError("skip DNS resolution for domain ", domain, " at server ", client.Name()).AtDebug().WriteToLog()
 			continue
 		}
+		clients = append(clients, client)
+	}
+	if s.queryMode == QueryMode_PARALLEL {
+		return s.queryParallel(ctx, domain, clients, option)
+	}
+
+	errs := []error{}
+	for _, client := range clients {
 		ips, err := client.QueryIP(ctx, domain, option, s.disableCache)
 		if len(ips) > 0 {
 			return ips, nil
@@ -260,7 +438,7 @@ func (s *DNS) SetFakeDNSOption(isFakeEnable bool) {
 	s.ipOption.FakeEnable = isFakeEnable
 }
 
-func (s *DNS) sortClients(domain string) []*Client {
+func (s *DNS) sortClients(domain string, source net.IP) []*Client {
 	clients := make([]*Client, 0, len(s.clients))
 	clientUsed := make([]bool, len(s.clients))
 	clientNames := make([]string, 0, len(s.clients))
@@ -273,7 +451,7 @@ func (s *DNS) sortClients(domain string) []*Client {
 		client := s.clients[info.clientIdx]
 		domainRule := client.domains[info.domainRuleIdx]
 		domainRules = append(domainRules, fmt.Sprintf("%s(DNS idx:%d)", domainRule, info.clientIdx))
-		if clientUsed[info.clientIdx] {
+		if clientUsed[info.clientIdx] || !client.MatchSource(source) {
 			continue
 		}
 		clientUsed[info.clientIdx] = true
@@ -285,7 +463,7 @@ func (s *DNS) sortClients(domain string) []*Client {
 	if !(s.disableFallback || s.disableFallbackIfMatch && hasMatch) {
 		// Default round-robin query
 		for idx, client := range s.clients {
-			if clientUsed[idx] || client.skipFallback {
+			if clientUsed[idx] || client.skipFallback || !client.MatchSource(source) {
 				continue
 			}
 			clientUsed[idx] = true
@@ -302,9 +480,14 @@ func (s *DNS) sortClients(domain string) []*Client {
 	}
 
 	if len(clients) == 0 {
-		clients = append(clients, s.clients[0])
-		clientNames = append(clientNames, s.clients[0].Name())
-		newError("domain ", domain, " will use the first DNS: ", clientNames).AtDebug().WriteToLog()
+		for _, client := range s.clients {
+			if client.MatchSource(source) {
+				clients = append(clients, client)
+				clientNames = append(clientNames, client.Name())
+				newError("domain ", domain, " will use the first DNS: ", clientNames).AtDebug().WriteToLog()
+				break
+			}
+		}
 	}
 
 	return clients
@@ -358,6 +541,7 @@ func init() {
 				ClientIp:     net.ParseIP(v.ClientIp),
 				SkipFallback: v.SkipFallback,
 				Geoip:        v.Geoip,
+				SourceGeoip:  v.SourceGeoip,
 			}
 			for _, prioritizedDomain := range v.PrioritizedDomain {
 				nameserver.PrioritizedDomain = append(nameserver.PrioritizedDomain, &NameServer_PriorityDomain{
@@ -369,13 +553,18 @@ func init() {
 		}
 
 		fullConfig := &Config{
-			NameServer:      nameservers,
-			ClientIp:        net.ParseIP(simplifiedConfig.ClientIp),
-			StaticHosts:     simplifiedConfig.StaticHosts,
-			Tag:             simplifiedConfig.Tag,
-			DisableCache:    simplifiedConfig.DisableCache,
-			QueryStrategy:   simplifiedConfig.QueryStrategy,
-			DisableFallback: simplifiedConfig.DisableFallback,
+			NameServer:          nameservers,
+			ClientIp:            net.ParseIP(simplifiedConfig.ClientIp),
+			StaticHosts:         simplifiedConfig.StaticHosts,
+			Tag:                 simplifiedConfig.Tag,
+			DisableCache:        simplifiedConfig.DisableCache,
+			QueryStrategy:       simplifiedConfig.QueryStrategy,
+			DisableFallback:     simplifiedConfig.DisableFallback,
+			Cache:               simplifiedConfig.Cache,
+			QueryMode:           simplifiedConfig.QueryMode,
+			ParallelConcurrency: simplifiedConfig.ParallelConcurrency,
+			Dnssec:              simplifiedConfig.Dnssec,
+			StripRule:           simplifiedConfig.StripRule,
 		}
 		return common.CreateObject(ctx, fullConfig)
 	}))
@@ -1,6 +1,7 @@
 package dns_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -15,10 +16,13 @@ import (
 	"github.com/v2fly/v2ray-core/v5/app/proxyman"
 	_ "github.com/v2fly/v2ray-core/v5/app/proxyman/outbound"
 	"github.com/v2fly/v2ray-core/v5/app/router/routercommon"
+	"github.com/v2fly/v2ray-core/v5/app/stats"
 	"github.com/v2fly/v2ray-core/v5/common"
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/common/serial"
+	"github.com/v2fly/v2ray-core/v5/common/session"
 	feature_dns "github.com/v2fly/v2ray-core/v5/features/dns"
+	feature_stats "github.com/v2fly/v2ray-core/v5/features/stats"
 	"github.com/v2fly/v2ray-core/v5/proxy/freedom"
 	"github.com/v2fly/v2ray-core/v5/testing/servers/udp"
 )
@@ -101,6 +105,15 @@ func (*staticHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 			rr, _ := dns.NewRR("localhost-b. IN A 127.0.0.4")
 			ans.Answer = append(ans.Answer, rr)
 
+		case q.Name == "google.com." && q.Qtype == dns.TypeMX:
+			rr, _ := dns.NewRR("google.com. IN MX 10 smtp.google.com.")
+			ans.Answer = append(ans.Answer, rr)
+
+		case q.Name == "ipv6.google.com." && q.Qtype == dns.TypeHTTPS:
+			rr, err := dns.NewRR("ipv6.google.com. IN HTTPS 1 . alpn=h2 ipv4hint=8.8.8.7 ipv6hint=2001:4860:4860::8888")
+			common.Must(err)
+			ans.Answer = append(ans.Answer, rr)
+
 		case q.Name == "Mijia\\ Cloud." && q.Qtype == dns.TypeA:
 			rr, _ := dns.NewRR("Mijia\\ Cloud. IN A 127.0.0.1")
 			ans.Answer = append(ans.Answer, rr)
@@ -969,3 +982,320 @@ func TestMultiMatchPrioritizedDomain(t *testing.T) {
 		t.Error("DNS query doesn't finish in 2 seconds.")
 	}
 }
+
+func TestParallelQuery(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+	defer dnsServer.Shutdown()
+
+	// A name server never answering
+	silent, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IP{127, 0, 0, 1}})
+	common.Must(err)
+	defer silent.Close()
+
+	nameServer := func(port net.Port) *NameServer {
+		return &NameServer{
+			Address: &net.Endpoint{
+				Network: net.Network_UDP,
+				Address: &net.IPOrDomain{
+					Address: &net.IPOrDomain_Ip{
+						Ip: []byte{127, 0, 0, 1},
+					},
+				},
+				Port: uint32(port),
+			},
+		}
+	}
+
+	config := &core.Config{
+		App: []*anypb.Any{
+			serial.ToTypedMessage(&Config{
+				NameServer: []*NameServer{
+					nameServer(net.Port(silent.LocalAddr().(*net.UDPAddr).Port)),
+					nameServer(port),
+				},
+				QueryMode: QueryMode_PARALLEL,
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{
+				System: &policy.SystemPolicy{
+					Stats: &policy.SystemPolicy_Stats{DnsServer: true},
+				},
+			}),
+			serial.ToTypedMessage(&stats.Config{}),
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+
+	client := v.GetFeature(feature_dns.ClientType()).(feature_dns.Client)
+
+	start := time.Now()
+	ips, err := client.LookupIP("google.com")
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	if r := cmp.Diff(ips, []net.IP{{8, 8, 8, 8}}); r != "" {
+		t.Fatal(r)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second*2 {
+		t.Error("expect the answer not to wait for the silent server, took ", elapsed)
+	}
+
+	statsManager := v.GetFeature(feature_stats.ManagerType()).(feature_stats.Manager)
+	counter := statsManager.GetCounter("dns>>>UDP:127.0.0.1:" + port.String() + ">>>query")
+	if counter == nil || counter.Value() != 1 {
+		t.Error("expect a query counted, got ", counter)
+	}
+}
+
+func TestLookupRecord(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+	defer dnsServer.Shutdown()
+
+	config := &core.Config{
+		App: []*anypb.Any{
+			serial.ToTypedMessage(&Config{
+				NameServer: []*NameServer{
+					{
+						Address: &net.Endpoint{
+							Network: net.Network_UDP,
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Ip{
+									Ip: []byte{127, 0, 0, 1},
+								},
+							},
+							Port: uint32(port),
+						},
+					},
+				},
+				StaticHosts: []*HostMapping{
+					{
+						Type:   DomainMatchingType_Full,
+						Domain: "mail.example.com",
+						Record: []string{"MX 10 mx.example.com.", "TXT \"v=spf1 -all\""},
+					},
+					{
+						Type:   DomainMatchingType_Full,
+						Domain: "www.example.com",
+						Cname:  "google.com",
+					},
+					{
+						Type:   DomainMatchingType_Full,
+						Domain: "alias.example.com",
+						Cname:  "www.example.com",
+					},
+				},
+				StripRule: []*StripRule{
+					{
+						Domain: []*NameServer_PriorityDomain{
+							{Type: DomainMatchingType_Full, Domain: "ipv6.google.com"},
+						},
+						Aaaa: true,
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+
+	client := v.GetFeature(feature_dns.ClientType()).(feature_dns.RecordLookup)
+	lookup := func(name string, qType uint16) *dns.Msg {
+		t.Helper()
+		req := new(dns.Msg)
+		req.SetQuestion(name, qType)
+		query, err := req.Pack()
+		common.Must(err)
+		b, err := client.LookupRecord(context.Background(), query)
+		if err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+		resp := new(dns.Msg)
+		common.Must(resp.Unpack(b))
+		if resp.Id != req.Id || len(resp.Question) != 1 || resp.Question[0].Name != name {
+			t.Fatal("mismatched response: ", resp)
+		}
+		return resp
+	}
+	answer := func(resp *dns.Msg) []string {
+		var records []string
+		for _, rr := range resp.Answer {
+			rr.Header().Ttl = 0
+			records = append(records, rr.String())
+		}
+		return records
+	}
+
+	for _, tc := range []struct {
+		name   string
+		qType  uint16
+		answer []string
+	}{
+		{
+			name:   "google.com.",
+			qType:  dns.TypeMX,
+			answer: []string{"google.com.\t0\tIN\tMX\t10 smtp.google.com."},
+		},
+		{
+			name:   "mail.example.com.",
+			qType:  dns.TypeTXT,
+			answer: []string{"mail.example.com.\t0\tIN\tTXT\t\"v=spf1 -all\""},
+		},
+		{
+			name:  "www.example.com.",
+			qType: dns.TypeA,
+			answer: []string{
+				"www.example.com.\t0\tIN\tCNAME\tgoogle.com.",
+				"google.com.\t0\tIN\tA\t8.8.8.8",
+			},
+		},
+		{
+			name:  "alias.example.com.",
+			qType: dns.TypeMX,
+			answer: []string{
+				"alias.example.com.\t0\tIN\tCNAME\twww.example.com.",
+				"www.example.com.\t0\tIN\tCNAME\tgoogle.com.",
+				"google.com.\t0\tIN\tMX\t10 smtp.google.com.",
+			},
+		},
+		{
+			name:  "ipv6.google.com.",
+			qType: dns.TypeAAAA,
+		},
+		{
+			name:   "ipv6.google.com.",
+			qType:  dns.TypeHTTPS,
+			answer: []string{"ipv6.google.com.\t0\tIN\tHTTPS\t1 . alpn=\"h2\" ipv4hint=\"8.8.8.7\""},
+		},
+	} {
+		resp := lookup(tc.name, tc.qType)
+		if resp.Rcode != dns.RcodeSuccess {
+			t.Error("unexpected rcode for ", tc.name, " ", dns.TypeToString[tc.qType], ": ", resp.Rcode)
+		}
+		if r := cmp.Diff(answer(resp), tc.answer); r != "" {
+			t.Error(tc.name, " ", dns.TypeToString[tc.qType], ": ", r)
+		}
+	}
+
+	ips, err := v.GetFeature(feature_dns.ClientType()).(feature_dns.Client).LookupIP("ipv6.google.com")
+	common.Must(err)
+	if r := cmp.Diff(ips, []net.IP{{8, 8, 8, 7}}); r != "" {
+		t.Error("expect IPv6 addresses stripped: ", r)
+	}
+}
+
+func TestLookupRecordSource(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+	defer dnsServer.Shutdown()
+
+	config := &core.Config{
+		App: []*anypb.Any{
+			serial.ToTypedMessage(&Config{
+				NameServer: []*NameServer{
+					{
+						Address: &net.Endpoint{
+							Network: net.Network_UDP,
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Ip{
+									Ip: []byte{127, 0, 0, 1},
+								},
+							},
+							Port: uint32(port),
+						},
+						SourceGeoip: []*routercommon.GeoIP{
+							{
+								Cidr: []*routercommon.CIDR{
+									{Ip: []byte{10, 0, 0, 0}, Prefix: 8},
+								},
+							},
+						},
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+
+	client := v.GetFeature(feature_dns.ClientType()).(feature_dns.Client)
+	lookup := func(source string) *dns.Msg {
+		t.Helper()
+		ctx := session.ContextWithInbound(context.Background(), &session.Inbound{
+			Source: net.UDPDestination(net.ParseAddress(source), 53),
+		})
+		req := new(dns.Msg)
+		req.SetQuestion("google.com.", dns.TypeA)
+		query, err := req.Pack()
+		common.Must(err)
+		b, err := client.(feature_dns.RecordLookup).LookupRecord(ctx, query)
+		common.Must(err)
+		resp := new(dns.Msg)
+		common.Must(resp.Unpack(b))
+		return resp
+	}
+
+	if resp := lookup("10.1.2.3"); resp.Rcode != dns.RcodeSuccess || len(resp.Answer) != 1 {
+		t.Error("expect answer for client in source IPs, got ", resp)
+	}
+	if resp := lookup("192.168.1.2"); resp.Rcode != dns.RcodeServerFailure {
+		t.Error("expect server failure for client not in source IPs, got ", resp)
+	}
+	if _, err := client.LookupIP("google.com"); err == nil {
+		t.Error("expect error for lookup not from clients")
+	}
+}
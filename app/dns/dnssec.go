@@ -0,0 +1,832 @@
+//go:build !confonly
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/v2fly/v2ray-core/v5/common/net"
+)
+
+// defaultTrustAnchors are the DS records of the root key signing keys, KSK-2017 and KSK-2024.
+var defaultTrustAnchors = []string{
+	". IN DS 20326 8 2 E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D",
+	". IN DS 38696 8 2 683D2D0ACB8C9B712A1948B27F741219298D0A450D612C483AF444A4C0FB2B16",
+}
+
+const (
+	// bogusRecordTTL is how long a bogus answer is cached as a server failure.
+	bogusRecordTTL = time.Minute
+	// maxZoneKeysTTL caps how long the validated keys of a zone are cached.
+	maxZoneKeysTTL = time.Hour
+)
+
+var (
+	errInsecureZone = newError("zone is provably unsigned")
+	errNotZoneCut   = newError("not a zone cut")
+)
+
+// validatingServer is a name server whose answers can be validated with DNSSEC.
+type validatingServer interface {
+	exchangingServer
+	setValidator(v *dnssecValidator)
+}
+
+// withMessageID returns a copy of the packed DNS message b with its ID set to id.
+func withMessageID(b []byte, id uint16) []byte {
+	b = append([]byte(nil), b...)
+	if len(b) >= 2 {
+		binary.BigEndian.PutUint16(b, id)
+	}
+	return b
+}
+
+// parseTrustAnchors parses the DS and DNSKEY records of the root zone in presentation format.
+func parseTrustAnchors(anchors []string) ([]*dns.DS, error) {
+	if len(anchors) == 0 {
+		anchors = defaultTrustAnchors
+	}
+	var dsSet []*dns.DS
+	for _, anchor := range anchors {
+		rr, err := dns.NewRR(anchor)
+		if err != nil {
+			return nil, newError("failed to parse trust anchor ", anchor).Base(err)
+		}
+		if rr == nil || rr.Header().Name != "." {
+			return nil, newError("trust anchor is not of the root zone: ", anchor)
+		}
+		switch rr := rr.(type) {
+		case *dns.DS:
+			dsSet = append(dsSet, rr)
+		case *dns.DNSKEY:
+			dsSet = append(dsSet, rr.ToDS(dns.SHA256))
+		default:
+			return nil, newError("trust anchor is neither DS nor DNSKEY: ", anchor)
+		}
+	}
+	return dsSet, nil
+}
+
+type zoneKeys struct {
+	keys     []*dns.DNSKEY
+	insecure bool
+	expire   time.Time
+}
+
+// dnssecValidator validates the answers of a name server from the root trust anchors, fetching the DNSKEY and DS
+// records of the zones on the way through the same name server.
+type dnssecValidator struct {
+	name     string
+	anchors  []*dns.DS
+	policy   DNSSECConfig_BogusPolicy
+	exchange func(ctx context.Context, b []byte) ([]byte, error)
+
+	access sync.Mutex
+	zones  map[string]*zoneKeys
+}
+
+func newDNSSECValidator(server validatingServer, anchors []*dns.DS, policy DNSSECConfig_BogusPolicy) *dnssecValidator {
+	return &dnssecValidator{
+		name:     server.Name(),
+		anchors:  anchors,
+		policy:   policy,
+		exchange: server.exchangeMessage,
+		zones:    make(map[string]*zoneKeys),
+	}
+}
+
+// ednsOptions returns the EDNS0 options of queries, with the DO bit set when the validator is enabled.
+func (v *dnssecValidator) ednsOptions(clientIP net.IP) *dnsmessage.Resource {
+	opt := genEDNS0Options(clientIP)
+	if v == nil || opt != nil {
+		// The DO bit is always set with client subnet.
+		return opt
+	}
+	opt = new(dnsmessage.Resource)
+	if err := opt.Header.SetEDNS0(1350, 0xfe00, true); err != nil {
+		return nil
+	}
+	opt.Body = &dnsmessage.OPTResource{}
+	return opt
+}
+
+// verify validates resp, and returns an error if it is bogus and to be dropped according to the policy.
+func (v *dnssecValidator) verify(ctx context.Context, resp []byte) error {
+	if v == nil {
+		return nil
+	}
+	err := v.validate(ctx, resp)
+	if err == nil {
+		return nil
+	}
+	if v.policy == DNSSECConfig_FLAG {
+		newError(v.name, " got bogus answer").Base(err).AtWarning().WriteToLog()
+		return nil
+	}
+	newError(v.name, " dropped bogus answer").Base(err).AtWarning().WriteToLog()
+	return err
+}
+
+// check validates resp, parsed as rec. A bogus answer is turned into a server failure, or only logged, according to
+// the policy. Only the addresses of the name queried, or of the names it is an alias of, are kept, as the others are
+// not validated.
+func (v *dnssecValidator) check(ctx context.Context, resp []byte, rec *IPRecord) *IPRecord {
+	if v == nil {
+		return rec
+	}
+	if v.verify(ctx, resp) != nil {
+		return &IPRecord{
+			ReqID:  rec.ReqID,
+			RCode:  dnsmessage.RCodeServerFailure,
+			Expire: time.Now().Add(bogusRecordTTL),
+		}
+	}
+	return chainRecord(resp, rec)
+}
+
+// maxCNAMEChain caps the number of CNAME records followed from the name queried.
+const maxCNAMEChain = 16
+
+// answerChain returns the name of q followed by the names it is an alias of, along the CNAME records in rrsets.
+func answerChain(q dns.Question, rrsets map[rrsetKey][]dns.RR) []string {
+	chain := []string{dns.CanonicalName(q.Name)}
+	if q.Qtype == dns.TypeCNAME || q.Qtype == dns.TypeANY {
+		return chain
+	}
+	for len(chain) <= maxCNAMEChain {
+		rrset, found := rrsets[rrsetKey{name: chain[len(chain)-1], rrtype: dns.TypeCNAME}]
+		if !found {
+			break
+		}
+		target := dns.CanonicalName(rrset[0].(*dns.CNAME).Target)
+		for _, name := range chain {
+			if name == target {
+				return chain
+			}
+		}
+		chain = append(chain, target)
+	}
+	return chain
+}
+
+// chainRecord returns rec with only the addresses in resp of the name queried, or of the names it is an alias of.
+func chainRecord(resp []byte, rec *IPRecord) *IPRecord {
+	filtered := &IPRecord{
+		ReqID:  rec.ReqID,
+		RCode:  rec.RCode,
+		Expire: rec.Expire,
+	}
+	msg := new(dns.Msg)
+	if err := msg.Unpack(resp); err != nil || len(msg.Question) == 0 {
+		return filtered
+	}
+	rrsets, _ := splitRRsets(msg.Answer)
+	for _, name := range answerChain(msg.Question[0], rrsets) {
+		for _, rr := range rrsets[rrsetKey{name: name, rrtype: dns.TypeA}] {
+			filtered.IP = append(filtered.IP, net.IPAddress(rr.(*dns.A).A))
+		}
+		for _, rr := range rrsets[rrsetKey{name: name, rrtype: dns.TypeAAAA}] {
+			filtered.IP = append(filtered.IP, net.IPAddress(rr.(*dns.AAAA).AAAA))
+		}
+	}
+	return filtered
+}
+
+type rrsetKey struct {
+	name   string
+	rrtype uint16
+}
+
+// splitRRsets groups records into RRsets, and their signatures by the RRsets covered.
+func splitRRsets(records []dns.RR) (map[rrsetKey][]dns.RR, map[rrsetKey][]*dns.RRSIG) {
+	rrsets := make(map[rrsetKey][]dns.RR)
+	sigs := make(map[rrsetKey][]*dns.RRSIG)
+	for _, rr := range records {
+		name := dns.CanonicalName(rr.Header().Name)
+		if sig, ok := rr.(*dns.RRSIG); ok {
+			key := rrsetKey{name: name, rrtype: sig.TypeCovered}
+			sigs[key] = append(sigs[key], sig)
+			continue
+		}
+		if rr.Header().Rrtype == dns.TypeOPT {
+			continue
+		}
+		key := rrsetKey{name: name, rrtype: rr.Header().Rrtype}
+		rrsets[key] = append(rrsets[key], rr)
+	}
+	return rrsets, sigs
+}
+
+// validate tells whether the answers in resp are secure, or provably unsigned. Only the records of the name queried,
+// or of the names it is an alias of, are validated, as the others are not taken as answers.
+func (v *dnssecValidator) validate(ctx context.Context, resp []byte) error {
+	msg := new(dns.Msg)
+	if err := msg.Unpack(resp); err != nil {
+		return newError("failed to parse response").Base(err)
+	}
+	if len(msg.Question) == 0 {
+		return newError("response without question")
+	}
+	qtype := msg.Question[0].Qtype
+
+	rrsets, sigs := splitRRsets(msg.Answer)
+	chain := answerChain(msg.Question[0], rrsets)
+	onChain := make(map[string]bool)
+	for _, name := range chain {
+		onChain[name] = true
+	}
+
+	// DNAME records are validated first, as the CNAME records synthesized from them are not signed.
+	var dnames []*dns.DNAME
+	for key, rrset := range rrsets {
+		if key.rrtype != dns.TypeDNAME || !isParentOf(key.name, chain) {
+			continue
+		}
+		err := v.verifyRRset(ctx, rrset, sigs[key])
+		switch {
+		case err == nil:
+			dnames = append(dnames, rrset[0].(*dns.DNAME))
+		case err != errInsecureZone:
+			return newError("failed to validate ", key.name, " DNAME").Base(err)
+		}
+	}
+
+	answered := false
+	for key, rrset := range rrsets {
+		if !onChain[key.name] {
+			continue
+		}
+		switch {
+		case key.rrtype == dns.TypeCNAME:
+			if len(sigs[key]) == 0 && isSynthesized(rrset[0].(*dns.CNAME), dnames) {
+				continue
+			}
+		case key.rrtype == qtype || qtype == dns.TypeANY:
+			answered = true
+		default:
+			continue
+		}
+		if err := v.verifyRRset(ctx, rrset, sigs[key]); err != nil && err != errInsecureZone {
+			return newError("failed to validate ", key.name, " ", dns.TypeToString[key.rrtype]).Base(err)
+		}
+	}
+	if answered {
+		return nil
+	}
+
+	// The last name of the chain does not exist, or has no record of the type queried.
+	last := chain[len(chain)-1]
+	switch msg.Rcode {
+	case dns.RcodeNameError:
+		return v.verifyDenial(ctx, msg, last, qtype, true)
+	case dns.RcodeSuccess:
+		return v.verifyDenial(ctx, msg, last, qtype, false)
+	default:
+		return v.proveInsecure(ctx, last)
+	}
+}
+
+// isParentOf tells whether zone is a parent of any of names.
+func isParentOf(zone string, names []string) bool {
+	for _, name := range names {
+		if name != zone && dns.IsSubDomain(zone, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSynthesized tells whether cname is synthesized from one of dnames.
+func isSynthesized(cname *dns.CNAME, dnames []*dns.DNAME) bool {
+	owner := dns.CanonicalName(cname.Hdr.Name)
+	for _, dname := range dnames {
+		zone := dns.CanonicalName(dname.Hdr.Name)
+		if owner == zone || !dns.IsSubDomain(zone, owner) {
+			continue
+		}
+		prefix := strings.TrimSuffix(owner, zone)
+		if dns.CanonicalName(cname.Target) == dns.CanonicalName(prefix+dns.CanonicalName(dname.Target)) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyDenial verifies the NSEC or NSEC3 records in the authority section of msg, which prove that name does not
+// exist if nxdomain is set, or that it has no record of qtype otherwise. Without any signed proof, name must be in a
+// provably unsigned zone.
+func (v *dnssecValidator) verifyDenial(ctx context.Context, msg *dns.Msg, name string, qtype uint16, nxdomain bool) error {
+	rrsets, sigs := splitRRsets(msg.Ns)
+	var nsecs []*dns.NSEC
+	var nsec3s []*dns.NSEC3
+	for key, rrset := range rrsets {
+		if key.rrtype != dns.TypeNSEC && key.rrtype != dns.TypeNSEC3 {
+			continue
+		}
+		err := v.verifyRRset(ctx, rrset, sigs[key])
+		if err == errInsecureZone {
+			continue
+		}
+		if err != nil {
+			return newError("failed to validate ", key.name, " ", dns.TypeToString[key.rrtype]).Base(err)
+		}
+		for _, rr := range rrset {
+			switch rr := rr.(type) {
+			case *dns.NSEC:
+				nsecs = append(nsecs, rr)
+			case *dns.NSEC3:
+				nsec3s = append(nsec3s, rr)
+			}
+		}
+	}
+	if len(nsecs) == 0 && len(nsec3s) == 0 {
+		if err := v.proveInsecure(ctx, name); err != nil {
+			return newError("no proof of denial of ", name).Base(err)
+		}
+		return nil
+	}
+
+	if nxdomain {
+		if nsecDenyName(nsecs, name) || nsec3DenyName(nsec3s, name) {
+			return nil
+		}
+		return newError("no proof of nonexistence of ", name)
+	}
+	if nsecDenyType(nsecs, name, qtype) || nsec3DenyType(nsec3s, name, qtype) {
+		return nil
+	}
+	return newError("no proof of missing ", dns.TypeToString[qtype], " of ", name)
+}
+
+// compareCanonical compares the names a and b in the canonical order of DNSSEC, label by label from the right.
+func compareCanonical(a, b string) int {
+	la := dns.SplitDomainName(dns.CanonicalName(a))
+	lb := dns.SplitDomainName(dns.CanonicalName(b))
+	for i, j := len(la)-1, len(lb)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if c := strings.Compare(la[i], lb[j]); c != 0 {
+			return c
+		}
+	}
+	return len(la) - len(lb)
+}
+
+// isDelegation tells whether bitmap is of a delegation to a child zone, whose records it can't deny.
+func isDelegation(bitmap []uint16) bool {
+	return hasType(bitmap, dns.TypeNS) && !hasType(bitmap, dns.TypeSOA)
+}
+
+// deniesType tells whether bitmap of name proves there is no record of qtype at name.
+func deniesType(bitmap []uint16, qtype uint16) bool {
+	if hasType(bitmap, qtype) || hasType(bitmap, dns.TypeCNAME) {
+		return false
+	}
+	return qtype == dns.TypeDS || !isDelegation(bitmap)
+}
+
+// nsecCovers tells whether nsec proves that name, sorting between its owner and next name, does not exist.
+func nsecCovers(nsec *dns.NSEC, name string) bool {
+	owner := dns.CanonicalName(nsec.Hdr.Name)
+	next := dns.CanonicalName(nsec.NextDomain)
+	if owner != name && dns.IsSubDomain(owner, name) && (isDelegation(nsec.TypeBitMap) || hasType(nsec.TypeBitMap, dns.TypeDNAME)) {
+		return false
+	}
+	if compareCanonical(owner, name) >= 0 {
+		return false
+	}
+	if compareCanonical(next, owner) <= 0 {
+		// The last NSEC of a zone, whose next name is the apex.
+		return dns.IsSubDomain(next, name)
+	}
+	return compareCanonical(name, next) < 0
+}
+
+// nsecEncloser returns the closest existing parent of name, proven by nsec covering it.
+func nsecEncloser(nsec *dns.NSEC, name string) string {
+	n := dns.CompareDomainName(name, nsec.Hdr.Name)
+	if m := dns.CompareDomainName(name, nsec.NextDomain); m > n {
+		n = m
+	}
+	labels := dns.SplitDomainName(name)
+	return dns.Fqdn(strings.Join(labels[len(labels)-n:], "."))
+}
+
+// wildcardOf returns the wildcard name below encloser.
+func wildcardOf(encloser string) string {
+	if encloser == "." {
+		return "*."
+	}
+	return "*." + encloser
+}
+
+// nsecDenyName tells whether nsecs prove that neither name nor a wildcard matching it exists.
+func nsecDenyName(nsecs []*dns.NSEC, name string) bool {
+	for _, nsec := range nsecs {
+		if !nsecCovers(nsec, name) {
+			continue
+		}
+		wildcard := wildcardOf(nsecEncloser(nsec, name))
+		for _, other := range nsecs {
+			if nsecCovers(other, wildcard) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nsecDenyType tells whether nsecs prove that name has no record of qtype, as it exists without it, it is an empty
+// non-terminal, or the wildcard matching it exists without it.
+func nsecDenyType(nsecs []*dns.NSEC, name string, qtype uint16) bool {
+	for _, nsec := range nsecs {
+		owner := dns.CanonicalName(nsec.Hdr.Name)
+		if owner == name {
+			if deniesType(nsec.TypeBitMap, qtype) {
+				return true
+			}
+			continue
+		}
+		if !nsecCovers(nsec, name) {
+			continue
+		}
+		next := dns.CanonicalName(nsec.NextDomain)
+		if next != name && dns.IsSubDomain(name, next) {
+			return true
+		}
+		wildcard := wildcardOf(nsecEncloser(nsec, name))
+		for _, other := range nsecs {
+			if dns.CanonicalName(other.Hdr.Name) == wildcard && deniesType(other.TypeBitMap, qtype) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nsec3Encloser returns the closest existing parent of name, and the next closer name below it, proven by nsec3s.
+func nsec3Encloser(nsec3s []*dns.NSEC3, name string) (string, string, bool) {
+	labels := dns.SplitDomainName(name)
+	for i := 1; i <= len(labels); i++ {
+		encloser := dns.Fqdn(strings.Join(labels[i:], "."))
+		for _, nsec3 := range nsec3s {
+			if !nsec3.Match(encloser) {
+				continue
+			}
+			if isDelegation(nsec3.TypeBitMap) || hasType(nsec3.TypeBitMap, dns.TypeDNAME) {
+				return "", "", false
+			}
+			nextCloser := dns.Fqdn(strings.Join(labels[i-1:], "."))
+			for _, other := range nsec3s {
+				if other.Cover(nextCloser) {
+					return encloser, nextCloser, true
+				}
+			}
+			return "", "", false
+		}
+	}
+	return "", "", false
+}
+
+// nsec3DenyName tells whether nsec3s prove that neither name nor a wildcard matching it exists.
+func nsec3DenyName(nsec3s []*dns.NSEC3, name string) bool {
+	encloser, _, ok := nsec3Encloser(nsec3s, name)
+	if !ok {
+		return false
+	}
+	for _, nsec3 := range nsec3s {
+		if nsec3.Cover(wildcardOf(encloser)) {
+			return true
+		}
+	}
+	return false
+}
+
+// nsec3DenyType tells whether nsec3s prove that name has no record of qtype, as it exists without it, the wildcard
+// matching it exists without it, or it is an unsigned delegation for DS.
+func nsec3DenyType(nsec3s []*dns.NSEC3, name string, qtype uint16) bool {
+	for _, nsec3 := range nsec3s {
+		if nsec3.Match(name) {
+			return deniesType(nsec3.TypeBitMap, qtype)
+		}
+	}
+	encloser, nextCloser, ok := nsec3Encloser(nsec3s, name)
+	if !ok {
+		return false
+	}
+	for _, nsec3 := range nsec3s {
+		if nsec3.Match(wildcardOf(encloser)) && deniesType(nsec3.TypeBitMap, qtype) {
+			return true
+		}
+		if qtype == dns.TypeDS && nsec3.Flags&1 == 1 && nsec3.Cover(nextCloser) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyRRset verifies rrset with one of sigs, or proves it is unsigned if there is no signature. errInsecureZone
+// is returned if the signer zone is provably unsigned.
+func (v *dnssecValidator) verifyRRset(ctx context.Context, rrset []dns.RR, sigs []*dns.RRSIG) error {
+	owner := dns.CanonicalName(rrset[0].Header().Name)
+	if len(sigs) == 0 {
+		if err := v.proveInsecure(ctx, owner); err != nil {
+			return newError("missing signature").Base(err)
+		}
+		return errInsecureZone
+	}
+
+	var lastErr error
+	for _, sig := range sigs {
+		signer := dns.CanonicalName(sig.SignerName)
+		if !dns.IsSubDomain(signer, owner) {
+			lastErr = newError("signer ", signer, " is not a parent of ", owner)
+			continue
+		}
+		keys, err := v.zoneKeys(ctx, signer)
+		if err != nil {
+			lastErr = err
+			if err == errInsecureZone {
+				return err
+			}
+			continue
+		}
+		if lastErr = verifyWithKeys(rrset, sig, keys); lastErr == nil {
+			return nil
+		}
+	}
+	return lastErr
+}
+
+// verifyWithKeys verifies rrset with sig made by one of keys.
+func verifyWithKeys(rrset []dns.RR, sig *dns.RRSIG, keys []*dns.DNSKEY) error {
+	if !sig.ValidityPeriod(time.Now()) {
+		return newError("signature expired or not yet valid")
+	}
+	for _, key := range keys {
+		if key.KeyTag() != sig.KeyTag || key.Algorithm != sig.Algorithm {
+			continue
+		}
+		if err := sig.Verify(key, rrset); err == nil {
+			return nil
+		}
+	}
+	return newError("no key verifies the signature")
+}
+
+// query sends a query of qtype for name through the name server, with the DO bit set.
+func (v *dnssecValidator) query(ctx context.Context, name string, qtype uint16) (*dns.Msg, error) {
+	req := new(dns.Msg)
+	req.SetQuestion(name, qtype)
+	req.SetEdns0(4096, true)
+	b, err := req.Pack()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.exchange(ctx, b)
+	if err != nil {
+		return nil, newError("failed to query ", name, " ", dns.TypeToString[qtype]).Base(err)
+	}
+	msg := new(dns.Msg)
+	if err := msg.Unpack(resp); err != nil {
+		return nil, newError("failed to parse response of ", name, " ", dns.TypeToString[qtype]).Base(err)
+	}
+	if len(msg.Question) == 0 || !strings.EqualFold(msg.Question[0].Name, name) || msg.Question[0].Qtype != qtype {
+		return nil, newError("mismatched response of ", name, " ", dns.TypeToString[qtype])
+	}
+	return msg, nil
+}
+
+func (v *dnssecValidator) cachedZone(zone string) *zoneKeys {
+	v.access.Lock()
+	defer v.access.Unlock()
+	if keys, found := v.zones[zone]; found && keys.expire.After(time.Now()) {
+		return keys
+	}
+	return nil
+}
+
+func (v *dnssecValidator) cacheZone(zone string, keys *zoneKeys) {
+	v.access.Lock()
+	defer v.access.Unlock()
+	v.zones[zone] = keys
+}
+
+// zoneKeys returns the validated DNSKEY records of zone. errInsecureZone is returned if the zone is provably
+// unsigned.
+func (v *dnssecValidator) zoneKeys(ctx context.Context, zone string) ([]*dns.DNSKEY, error) {
+	if cached := v.cachedZone(zone); cached != nil {
+		if cached.insecure {
+			return nil, errInsecureZone
+		}
+		return cached.keys, nil
+	}
+
+	var dsSet []*dns.DS
+	var expire time.Time
+	if zone == "." {
+		dsSet = v.anchors
+		expire = time.Now().Add(maxZoneKeysTTL)
+	} else {
+		var err error
+		dsSet, expire, err = v.delegationSigners(ctx, zone)
+		if err == errInsecureZone {
+			v.cacheZone(zone, &zoneKeys{insecure: true, expire: expire})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msg, err := v.query(ctx, zone, dns.TypeDNSKEY)
+	if err != nil {
+		return nil, err
+	}
+	var keySet []dns.RR
+	var keys []*dns.DNSKEY
+	var sigs []*dns.RRSIG
+	for _, rr := range msg.Answer {
+		switch rr := rr.(type) {
+		case *dns.DNSKEY:
+			keySet = append(keySet, rr)
+			keys = append(keys, rr)
+		case *dns.RRSIG:
+			if rr.TypeCovered == dns.TypeDNSKEY {
+				sigs = append(sigs, rr)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return nil, newError("no DNSKEY of ", zone)
+	}
+
+	// The key set is trusted once signed by a key matching a DS record.
+	var trusted []*dns.DNSKEY
+	for _, key := range keys {
+		if key.Flags&dns.ZONE == 0 {
+			continue
+		}
+		for _, ds := range dsSet {
+			if ds.KeyTag != key.KeyTag() || ds.Algorithm != key.Algorithm {
+				continue
+			}
+			if digest := key.ToDS(ds.DigestType); digest != nil && strings.EqualFold(digest.Digest, ds.Digest) {
+				trusted = append(trusted, key)
+				break
+			}
+		}
+	}
+	verified := false
+	for _, sig := range sigs {
+		if verifyWithKeys(keySet, sig, trusted) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, newError("DNSKEY of ", zone, " does not match its DS")
+	}
+
+	if ttl := time.Now().Add(time.Duration(keySet[0].Header().Ttl) * time.Second); ttl.Before(expire) {
+		expire = ttl
+	}
+	v.cacheZone(zone, &zoneKeys{keys: keys, expire: expire})
+	return keys, nil
+}
+
+// delegationSigners returns the validated DS records of zone from its parent zone, and when they expire.
+// errInsecureZone is returned if the parent proves there is none.
+func (v *dnssecValidator) delegationSigners(ctx context.Context, zone string) ([]*dns.DS, time.Time, error) {
+	expire := time.Now().Add(maxZoneKeysTTL)
+	msg, err := v.query(ctx, zone, dns.TypeDS)
+	if err != nil {
+		return nil, expire, err
+	}
+
+	rrsets, sigs := splitRRsets(msg.Answer)
+	key := rrsetKey{name: zone, rrtype: dns.TypeDS}
+	if rrset, found := rrsets[key]; found {
+		if err := v.verifyParentRRset(ctx, zone, rrset, sigs[key]); err != nil {
+			return nil, expire, newError("failed to validate DS of ", zone).Base(err)
+		}
+		dsSet := make([]*dns.DS, 0, len(rrset))
+		for _, rr := range rrset {
+			dsSet = append(dsSet, rr.(*dns.DS))
+		}
+		if ttl := time.Now().Add(time.Duration(rrset[0].Header().Ttl) * time.Second); ttl.Before(expire) {
+			expire = ttl
+		}
+		return dsSet, expire, nil
+	}
+
+	cut, err := v.verifyNoDS(ctx, zone, msg)
+	if err != nil {
+		return nil, expire, err
+	}
+	if cut {
+		return nil, expire, errInsecureZone
+	}
+	return nil, expire, errNotZoneCut
+}
+
+// verifyParentRRset verifies rrset signed by a parent zone of name.
+func (v *dnssecValidator) verifyParentRRset(ctx context.Context, name string, rrset []dns.RR, sigs []*dns.RRSIG) error {
+	if len(sigs) == 0 {
+		return newError("missing signature")
+	}
+	var lastErr error
+	for _, sig := range sigs {
+		signer := dns.CanonicalName(sig.SignerName)
+		if signer == name || !dns.IsSubDomain(signer, name) {
+			lastErr = newError("signer ", signer, " is not a parent of ", name)
+			continue
+		}
+		keys, err := v.zoneKeys(ctx, signer)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if lastErr = verifyWithKeys(rrset, sig, keys); lastErr == nil {
+			return nil
+		}
+	}
+	return lastErr
+}
+
+// verifyNoDS verifies the denial of DS records of name in msg, and tells whether name is a delegation without DS.
+func (v *dnssecValidator) verifyNoDS(ctx context.Context, name string, msg *dns.Msg) (bool, error) {
+	if msg.Rcode == dns.RcodeNameError {
+		return false, newError(name, " does not exist")
+	}
+	if msg.Rcode != dns.RcodeSuccess {
+		return false, newError("failed to query DS of ", name, ": ", dns.RcodeToString[msg.Rcode])
+	}
+	rrsets, sigs := splitRRsets(msg.Ns)
+	for key, rrset := range rrsets {
+		if key.rrtype != dns.TypeNSEC && key.rrtype != dns.TypeNSEC3 {
+			continue
+		}
+		if err := v.verifyParentRRset(ctx, name, rrset, sigs[key]); err != nil {
+			continue
+		}
+		for _, rr := range rrset {
+			switch rr := rr.(type) {
+			case *dns.NSEC:
+				if dns.CanonicalName(rr.Header().Name) == name && !hasType(rr.TypeBitMap, dns.TypeDS) {
+					return hasType(rr.TypeBitMap, dns.TypeNS) && !hasType(rr.TypeBitMap, dns.TypeSOA), nil
+				}
+			case *dns.NSEC3:
+				if rr.Match(name) && !hasType(rr.TypeBitMap, dns.TypeDS) {
+					return hasType(rr.TypeBitMap, dns.TypeNS) && !hasType(rr.TypeBitMap, dns.TypeSOA), nil
+				}
+				if rr.Flags&1 == 1 && rr.Cover(name) {
+					// An opt-out span may hold unsigned delegations.
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, newError("no proof of missing DS of ", name)
+}
+
+func hasType(bitmap []uint16, rrtype uint16) bool {
+	for _, t := range bitmap {
+		if t == rrtype {
+			return true
+		}
+	}
+	return false
+}
+
+// proveInsecure proves that name belongs to an unsigned zone, by walking the zone cuts down from the root until
+// a delegation without DS.
+func (v *dnssecValidator) proveInsecure(ctx context.Context, name string) error {
+	labels := dns.SplitDomainName(name)
+	for i := len(labels) - 1; i >= 0; i-- {
+		zone := dns.Fqdn(strings.Join(labels[i:], "."))
+		if cached := v.cachedZone(zone); cached != nil {
+			if cached.insecure {
+				return nil
+			}
+			continue
+		}
+		_, err := v.zoneKeys(ctx, zone)
+		switch {
+		case err == nil:
+			// A signed zone
+		case err == errInsecureZone:
+			return nil
+		case err == errNotZoneCut:
+			// Not a zone of its own
+		default:
+			return err
+		}
+	}
+	return newError(name, " is in a signed zone")
+}
@@ -0,0 +1,332 @@
+package dns
+
+import (
+	"context"
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/v2fly/v2ray-core/v5/common"
+)
+
+type testZone struct {
+	name string
+	key  *dns.DNSKEY
+	priv crypto.Signer
+}
+
+func newTestZone(name string) *testZone {
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: name, Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 3600},
+		Flags:     dns.ZONE | dns.SEP,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}
+	priv, err := key.Generate(256)
+	common.Must(err)
+	return &testZone{name: name, key: key, priv: priv.(crypto.Signer)}
+}
+
+func (z *testZone) sign(rrset ...dns.RR) []dns.RR {
+	now := time.Now()
+	sig := &dns.RRSIG{
+		Hdr:         dns.RR_Header{Name: rrset[0].Header().Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: 3600},
+		TypeCovered: rrset[0].Header().Rrtype,
+		Algorithm:   z.key.Algorithm,
+		SignerName:  z.name,
+		KeyTag:      z.key.KeyTag(),
+		Inception:   uint32(now.Add(-time.Hour).Unix()),
+		Expiration:  uint32(now.Add(time.Hour).Unix()),
+	}
+	common.Must(sig.Sign(z.priv, rrset))
+	return append(rrset, sig)
+}
+
+func (z *testZone) ds() *dns.DS {
+	ds := z.key.ToDS(dns.SHA256)
+	ds.Hdr.Ttl = 3600
+	return ds
+}
+
+func newTestRR(s string) dns.RR {
+	rr, err := dns.NewRR(s)
+	common.Must(err)
+	return rr
+}
+
+// testAuthority answers the queries of a signed hierarchy of "com." and "example.com.", with "insecure.com." as an
+// unsigned delegation.
+type testAuthority struct {
+	root    *testZone
+	com     *testZone
+	example *testZone
+	answers map[rrsetKey]*dns.Msg
+}
+
+func newTestAuthority() *testAuthority {
+	a := &testAuthority{
+		root:    newTestZone("."),
+		com:     newTestZone("com."),
+		example: newTestZone("example.com."),
+		answers: make(map[rrsetKey]*dns.Msg),
+	}
+	a.answer(".", dns.TypeDNSKEY, &dns.Msg{Answer: a.root.sign(a.root.key)})
+	a.answer("com.", dns.TypeDNSKEY, &dns.Msg{Answer: a.com.sign(a.com.key)})
+	a.answer("com.", dns.TypeDS, &dns.Msg{Answer: a.root.sign(a.com.ds())})
+	a.answer("example.com.", dns.TypeDNSKEY, &dns.Msg{Answer: a.example.sign(a.example.key)})
+	a.answer("example.com.", dns.TypeDS, &dns.Msg{Answer: a.com.sign(a.example.ds())})
+	a.answer("insecure.com.", dns.TypeDS, &dns.Msg{Ns: a.com.sign(&dns.NSEC{
+		Hdr:        dns.RR_Header{Name: "insecure.com.", Rrtype: dns.TypeNSEC, Class: dns.ClassINET, Ttl: 3600},
+		NextDomain: "jnsecure.com.",
+		TypeBitMap: []uint16{dns.TypeNS, dns.TypeRRSIG, dns.TypeNSEC},
+	})})
+	return a
+}
+
+func (a *testAuthority) answer(name string, rrtype uint16, msg *dns.Msg) {
+	a.answers[rrsetKey{name: name, rrtype: rrtype}] = msg
+}
+
+func (a *testAuthority) exchange(ctx context.Context, b []byte) ([]byte, error) {
+	req := new(dns.Msg)
+	if err := req.Unpack(b); err != nil {
+		return nil, err
+	}
+	resp := new(dns.Msg)
+	if answer, found := a.answers[rrsetKey{name: dns.CanonicalName(req.Question[0].Name), rrtype: req.Question[0].Qtype}]; found {
+		resp.SetReply(req)
+		resp.Answer = answer.Answer
+		resp.Ns = answer.Ns
+	} else {
+		resp.SetRcode(req, dns.RcodeNameError)
+	}
+	return resp.Pack()
+}
+
+func (a *testAuthority) validator(policy DNSSECConfig_BogusPolicy) *dnssecValidator {
+	anchors, err := parseTrustAnchors([]string{a.root.ds().String()})
+	common.Must(err)
+	return &dnssecValidator{
+		name:     "test",
+		anchors:  anchors,
+		policy:   policy,
+		exchange: a.exchange,
+		zones:    make(map[string]*zoneKeys),
+	}
+}
+
+func packTestResponse(name string, answer ...dns.RR) []byte {
+	msg := new(dns.Msg)
+	msg.SetQuestion(name, dns.TypeA)
+	msg.Response = true
+	msg.Answer = answer
+	b, err := msg.Pack()
+	common.Must(err)
+	return b
+}
+
+func TestDNSSECValidator(t *testing.T) {
+	authority := newTestAuthority()
+
+	tampered := authority.example.sign(newTestRR("www.example.com. 300 IN A 1.2.3.4"))
+	tampered[0].(*dns.A).A[3] = 5
+
+	for _, tc := range []struct {
+		name   string
+		resp   []byte
+		secure bool
+	}{
+		{
+			name:   "secure",
+			resp:   packTestResponse("www.example.com.", authority.example.sign(newTestRR("www.example.com. 300 IN A 1.2.3.4"))...),
+			secure: true,
+		},
+		{
+			name: "tampered",
+			resp: packTestResponse("www.example.com.", tampered...),
+		},
+		{
+			name: "unsigned in signed zone",
+			resp: packTestResponse("www.example.com.", newTestRR("www.example.com. 300 IN A 1.2.3.4")),
+		},
+		{
+			name: "signed by unrelated zone",
+			resp: packTestResponse("www.example.org.", authority.example.sign(newTestRR("www.example.org. 300 IN A 1.2.3.4"))...),
+		},
+		{
+			name: "unsigned alias in signed zone",
+			resp: packTestResponse("www.example.com.",
+				newTestRR("www.example.com. 300 IN CNAME www.insecure.com."),
+				newTestRR("www.insecure.com. 300 IN A 1.2.3.4")),
+		},
+		{
+			name:   "insecure delegation",
+			resp:   packTestResponse("www.insecure.com.", newTestRR("www.insecure.com. 300 IN A 1.2.3.4")),
+			secure: true,
+		},
+	} {
+		for _, policy := range []DNSSECConfig_BogusPolicy{DNSSECConfig_FAIL, DNSSECConfig_FLAG} {
+			rec, err := parseResponse(tc.resp)
+			common.Must(err)
+			checked := authority.validator(policy).check(context.Background(), tc.resp, rec)
+			if tc.secure || policy == DNSSECConfig_FLAG {
+				if checked.RCode != rec.RCode || len(checked.IP) != 1 {
+					t.Error(tc.name, ": expect answer kept with policy ", policy, ", got ", checked)
+				}
+				continue
+			}
+			if checked.RCode != dnsmessage.RCodeServerFailure || len(checked.IP) != 0 {
+				t.Error(tc.name, ": expect server failure, got ", checked)
+			}
+		}
+	}
+}
+
+func TestDNSSECOffNameAnswer(t *testing.T) {
+	authority := newTestAuthority()
+
+	// An unsigned record of another name in an unsigned zone, injected into a secure answer.
+	answer := authority.example.sign(newTestRR("www.example.com. 300 IN A 1.2.3.4"))
+	answer = append(answer, newTestRR("www.insecure.com. 300 IN A 6.6.6.6"), newTestRR("example.com. 300 IN A 6.6.6.6"))
+	resp := packTestResponse("www.example.com.", answer...)
+	if err := authority.validator(DNSSECConfig_FAIL).validate(context.Background(), resp); err != nil {
+		t.Error("expect secure answer, got ", err)
+	}
+	rec, err := parseResponse(resp)
+	common.Must(err)
+	rec = authority.validator(DNSSECConfig_FAIL).check(context.Background(), resp, rec)
+	if len(rec.IP) != 1 || rec.IP[0].String() != "1.2.3.4" {
+		t.Error("expect only the address of www.example.com, got ", rec.IP)
+	}
+}
+
+func packTestDenial(name string, qtype uint16, rcode int, ns ...dns.RR) []byte {
+	msg := new(dns.Msg)
+	msg.SetQuestion(name, qtype)
+	msg.Response = true
+	msg.Rcode = rcode
+	msg.Ns = ns
+	b, err := msg.Pack()
+	common.Must(err)
+	return b
+}
+
+func TestDNSSECDenial(t *testing.T) {
+	authority := newTestAuthority()
+	soa := authority.example.sign(newTestRR("example.com. 300 IN SOA ns.example.com. admin.example.com. 1 7200 3600 86400 300"))
+	apex := authority.example.sign(&dns.NSEC{
+		Hdr:        dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeNSEC, Class: dns.ClassINET, Ttl: 300},
+		NextDomain: "www.example.com.",
+		TypeBitMap: []uint16{dns.TypeNS, dns.TypeSOA, dns.TypeRRSIG, dns.TypeNSEC, dns.TypeDNSKEY},
+	})
+	www := authority.example.sign(&dns.NSEC{
+		Hdr:        dns.RR_Header{Name: "www.example.com.", Rrtype: dns.TypeNSEC, Class: dns.ClassINET, Ttl: 300},
+		NextDomain: "example.com.",
+		TypeBitMap: []uint16{dns.TypeA, dns.TypeRRSIG, dns.TypeNSEC},
+	})
+	other := authority.example.sign(&dns.NSEC{
+		Hdr:        dns.RR_Header{Name: "a.example.com.", Rrtype: dns.TypeNSEC, Class: dns.ClassINET, Ttl: 300},
+		NextDomain: "b.example.com.",
+		TypeBitMap: []uint16{dns.TypeA, dns.TypeRRSIG, dns.TypeNSEC},
+	})
+	delegation := authority.com.sign(&dns.NSEC{
+		Hdr:        dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeNSEC, Class: dns.ClassINET, Ttl: 300},
+		NextDomain: "insecure.com.",
+		TypeBitMap: []uint16{dns.TypeNS, dns.TypeDS, dns.TypeRRSIG, dns.TypeNSEC},
+	})
+	// A single NSEC3 record of the apex, which covers every other name in the zone.
+	apexHash := dns.HashName("example.com.", dns.SHA1, 0, "")
+	nsec3 := authority.example.sign(&dns.NSEC3{
+		Hdr:        dns.RR_Header{Name: apexHash + ".example.com.", Rrtype: dns.TypeNSEC3, Class: dns.ClassINET, Ttl: 300},
+		Hash:       dns.SHA1,
+		SaltLength: 0,
+		Salt:       "",
+		HashLength: 20,
+		NextDomain: apexHash,
+		TypeBitMap: []uint16{dns.TypeNS, dns.TypeSOA, dns.TypeRRSIG, dns.TypeDNSKEY, dns.TypeNSEC3PARAM},
+	})
+
+	for _, tc := range []struct {
+		name   string
+		resp   []byte
+		secure bool
+	}{
+		{
+			name: "replayed SOA",
+			resp: packTestDenial("nx.example.com.", dns.TypeA, dns.RcodeNameError, soa...),
+		},
+		{
+			name: "replayed NSEC of another name",
+			resp: packTestDenial("nx.example.com.", dns.TypeA, dns.RcodeNameError, append(soa, other...)...),
+		},
+		{
+			name:   "nonexistent name",
+			resp:   packTestDenial("nx.example.com.", dns.TypeA, dns.RcodeNameError, append(soa, apex...)...),
+			secure: true,
+		},
+		{
+			name:   "missing type",
+			resp:   packTestDenial("www.example.com.", dns.TypeAAAA, dns.RcodeSuccess, append(soa, www...)...),
+			secure: true,
+		},
+		{
+			name: "existing type",
+			resp: packTestDenial("www.example.com.", dns.TypeA, dns.RcodeSuccess, append(soa, www...)...),
+		},
+		{
+			name: "missing type from delegation",
+			resp: packTestDenial("example.com.", dns.TypeA, dns.RcodeSuccess, delegation...),
+		},
+		{
+			name:   "nonexistent name with NSEC3",
+			resp:   packTestDenial("nx.example.com.", dns.TypeA, dns.RcodeNameError, append(soa, nsec3...)...),
+			secure: true,
+		},
+		{
+			name:   "missing type with NSEC3",
+			resp:   packTestDenial("example.com.", dns.TypeA, dns.RcodeSuccess, append(soa, nsec3...)...),
+			secure: true,
+		},
+		{
+			name:   "insecure delegation",
+			resp:   packTestDenial("nx.insecure.com.", dns.TypeA, dns.RcodeNameError),
+			secure: true,
+		},
+	} {
+		err := authority.validator(DNSSECConfig_FAIL).validate(context.Background(), tc.resp)
+		if tc.secure && err != nil {
+			t.Error(tc.name, ": expect secure answer, got ", err)
+		}
+		if !tc.secure && err == nil {
+			t.Error(tc.name, ": expect bogus answer")
+		}
+	}
+}
+
+func TestDNSSECUntrustedKey(t *testing.T) {
+	authority := newTestAuthority()
+	// A key of com. not matching its DS record in the root zone.
+	forged := newTestZone("com.")
+	authority.answer("com.", dns.TypeDNSKEY, &dns.Msg{Answer: forged.sign(forged.key)})
+	authority.answer("example.com.", dns.TypeDS, &dns.Msg{Answer: forged.sign(authority.example.ds())})
+
+	resp := packTestResponse("www.example.com.", authority.example.sign(newTestRR("www.example.com. 300 IN A 1.2.3.4"))...)
+	if err := authority.validator(DNSSECConfig_FAIL).validate(context.Background(), resp); err == nil {
+		t.Error("expect bogus answer with an untrusted key of com.")
+	}
+}
+
+func TestParseTrustAnchors(t *testing.T) {
+	if anchors, err := parseTrustAnchors(nil); err != nil || len(anchors) != len(defaultTrustAnchors) {
+		t.Error("expect default trust anchors, got ", anchors, err)
+	}
+	if _, err := parseTrustAnchors([]string{"com. IN DS 20326 8 2 E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D"}); err == nil {
+		t.Error("expect error for trust anchor not of the root zone")
+	}
+	if _, err := parseTrustAnchors([]string{". IN A 1.2.3.4"}); err == nil {
+		t.Error("expect error for trust anchor neither DS nor DNSKEY")
+	}
+}
@@ -11,6 +11,8 @@ import (
 // StaticHosts represents static domain-ip mapping in DNS server.
 type StaticHosts struct {
 	ips      [][]net.Address
+	cnames   []string
+	records  []hostRecords
 	matchers *strmatcher.LinearIndexMatcher
 }
 
@@ -19,6 +21,8 @@ func NewStaticHosts(hosts []*HostMapping, legacy map[string]*net.IPOrDomain) (*S
 	g := new(strmatcher.LinearIndexMatcher)
 	sh := &StaticHosts{
 		ips:      make([][]net.Address, len(hosts)+len(legacy)+16),
+		cnames:   make([]string, len(hosts)+len(legacy)+16),
+		records:  make([]hostRecords, len(hosts)+len(legacy)+16),
 		matchers: g,
 	}
 
@@ -47,6 +51,13 @@ func NewStaticHosts(hosts []*HostMapping, legacy map[string]*net.IPOrDomain) (*S
 		id := g.Add(matcher)
 		ips := make([]net.Address, 0, len(mapping.Ip)+1)
 		switch {
+		case len(mapping.Cname) > 0:
+			if len(mapping.ProxiedDomain) > 0 || len(mapping.Ip) > 0 || len(mapping.Record) > 0 {
+				return nil, newError("CNAME cannot coexist with other records for domain: ", mapping.Domain).AtWarning()
+			}
+			// IP queries follow the CNAME as a proxied domain.
+			ips = append(ips, net.DomainAddress(mapping.Cname))
+			sh.cnames[id] = mapping.Cname
 		case len(mapping.ProxiedDomain) > 0:
 			ips = append(ips, net.DomainAddress(mapping.ProxiedDomain))
 		case len(mapping.Ip) > 0:
@@ -57,11 +68,17 @@ func NewStaticHosts(hosts []*HostMapping, legacy map[string]*net.IPOrDomain) (*S
 				}
 				ips = append(ips, addr)
 			}
+		case len(mapping.Record) > 0:
 		default:
-			return nil, newError("neither IP address nor proxied domain specified for domain: ", mapping.Domain).AtWarning()
+			return nil, newError("neither IP address, proxied domain, CNAME nor record specified for domain: ", mapping.Domain).AtWarning()
 		}
 
+		records, err := parseHostRecords(mapping.Record)
+		if err != nil {
+			return nil, newError("invalid record in static hosts for domain: ", mapping.Domain).Base(err).AtWarning()
+		}
 		sh.ips[id] = ips
+		sh.records[id] = records
 	}
 
 	return sh, nil
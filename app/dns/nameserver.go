@@ -13,6 +13,7 @@ import (
 	"github.com/v2fly/v2ray-core/v5/common/strmatcher"
 	"github.com/v2fly/v2ray-core/v5/features/dns"
 	"github.com/v2fly/v2ray-core/v5/features/routing"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tls"
 )
 
 // Server is the interface for Name Server.
@@ -30,12 +31,23 @@ type Client struct {
 	skipFallback bool
 	domains      []string
 	expectIPs    []*router.GeoIPMatcher
+	sourceIPs    []*router.GeoIPMatcher
+	validator    *dnssecValidator
+
+	health   serverHealth
+	counters *serverCounters
 }
 
 var errExpectedIPNonMatch = errors.New("expectIPs not match")
 
 // NewServer creates a name server object according to the network destination url.
 func NewServer(dest net.Destination, dispatcher routing.Dispatcher) (Server, error) {
+	return newServer(dest, dispatcher, nil)
+}
+
+// newServer creates a name server object according to the network destination url, with the TLS settings for DNS
+// over TLS servers.
+func newServer(dest net.Destination, dispatcher routing.Dispatcher, tlsSettings *tls.Config) (Server, error) {
 	if address := dest.Address; address.Family().IsDomain() {
 		u, err := url.Parse(address.Domain())
 		if err != nil {
@@ -48,12 +60,22 @@ func NewServer(dest net.Destination, dispatcher routing.Dispatcher) (Server, err
 			return NewDoHNameServer(u, dispatcher)
 		case strings.EqualFold(u.Scheme, "https+local"): // DOH Local mode
 			return NewDoHLocalNameServer(u), nil
+		case strings.EqualFold(u.Scheme, "h3"): // DNS-over-HTTP/3 Remote mode
+			return NewDoH3NameServer(u, dispatcher, tlsSettings), nil
+		case strings.EqualFold(u.Scheme, "h3+local"): // DNS-over-HTTP/3 Local mode
+			return NewDoH3LocalNameServer(u, tlsSettings), nil
+		case strings.EqualFold(u.Scheme, "quic"): // DNS-over-QUIC Remote mode
+			return NewQUICRemoteNameServer(u, dispatcher, tlsSettings)
 		case strings.EqualFold(u.Scheme, "quic+local"): // DNS-over-QUIC Local mode
 			return NewQUICNameServer(u)
 		case strings.EqualFold(u.Scheme, "tcp"): // DNS-over-TCP Remote mode
 			return NewTCPNameServer(u, dispatcher)
 		case strings.EqualFold(u.Scheme, "tcp+local"): // DNS-over-TCP Local mode
 			return NewTCPLocalNameServer(u)
+		case strings.EqualFold(u.Scheme, "tls"): // DNS-over-TLS Remote mode
+			return NewTLSNameServer(u, dispatcher, tlsSettings)
+		case strings.EqualFold(u.Scheme, "tls+local"): // DNS-over-TLS Local mode
+			return NewTLSLocalNameServer(u, tlsSettings)
 		case strings.EqualFold(u.String(), "fakedns"):
 			return NewFakeDNSServer(), nil
 		}
@@ -73,7 +95,7 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 
 	err := core.RequireFeatures(ctx, func(dispatcher routing.Dispatcher) error {
 		// Create a new server for each client for now
-		server, err := NewServer(ns.Address.AsDestination(), dispatcher)
+		server, err := newServer(ns.Address.AsDestination(), dispatcher, ns.TlsSettings)
 		if err != nil {
 			return newError("failed to create nameserver").Base(err).AtWarning()
 		}
@@ -137,6 +159,16 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 			matchers = append(matchers, matcher)
 		}
 
+		// Establish source IPs of served clients
+		var sourceMatchers []*router.GeoIPMatcher
+		for _, geoip := range ns.SourceGeoip {
+			matcher, err := container.Add(geoip)
+			if err != nil {
+				return newError("failed to create source ip matcher").Base(err).AtWarning()
+			}
+			sourceMatchers = append(sourceMatchers, matcher)
+		}
+
 		if len(clientIP) > 0 {
 			switch ns.Address.Address.GetAddress().(type) {
 			case *net.IPOrDomain_Domain:
@@ -151,6 +183,7 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 		client.skipFallback = ns.SkipFallback
 		client.domains = rules
 		client.expectIPs = matchers
+		client.sourceIPs = sourceMatchers
 		return nil
 	})
 	return client, err
@@ -188,9 +221,11 @@ func (c *Client) Name() string {
 
 // QueryIP send DNS query to the name server with the client's IP.
 func (c *Client) QueryIP(ctx context.Context, domain string, option dns.IPOption, disableCache bool) ([]net.IP, error) {
+	start := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, 4*time.Second)
 	ips, err := c.server.QueryIP(ctx, domain, c.clientIP, option, disableCache)
 	cancel()
+	c.recordResult(err, time.Since(start))
 
 	if err != nil {
 		return ips, err
@@ -198,6 +233,21 @@ func (c *Client) QueryIP(ctx context.Context, domain string, option dns.IPOption
 	return c.MatchExpectedIPs(domain, ips)
 }
 
+// recordResult tracks the health of the name server, and counts the query in its stat counters.
+func (c *Client) recordResult(err error, elapsed time.Duration) {
+	if err == context.Canceled {
+		// The query is abandoned, e.g. when another name server answered first.
+		return
+	}
+	failed := isServerError(err)
+	if demotion := c.health.record(failed); demotion > 0 {
+		newError("DNS server ", c.Name(), " demoted for ", demotion, " after failures in a row").Base(err).AtWarning().WriteToLog()
+	}
+	if c.counters != nil {
+		c.counters.record(failed, elapsed)
+	}
+}
+
 // MatchExpectedIPs matches queried domain IPs with expected IPs and returns matched ones.
 func (c *Client) MatchExpectedIPs(domain string, ips []net.IP) ([]net.IP, error) {
 	if len(c.expectIPs) == 0 {
@@ -218,3 +268,19 @@ func (c *Client) MatchExpectedIPs(domain string, ips []net.IP) ([]net.IP, error)
 	newError("domain ", domain, " expectIPs ", newIps, " matched at server ", c.Name()).AtDebug().WriteToLog()
 	return newIps, nil
 }
+
+// MatchSource tells whether the name server serves the client of source IP, which is nil for queries not from clients.
+func (c *Client) MatchSource(source net.IP) bool {
+	if len(c.sourceIPs) == 0 {
+		return true
+	}
+	if source == nil {
+		return false
+	}
+	for _, matcher := range c.sourceIPs {
+		if matcher.Match(source) {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ package dns
 import (
 	"bytes"
 	"context"
+	gotls "crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,31 +15,28 @@ import (
 	"sync/atomic"
 	"time"
 
-	"golang.org/x/net/dns/dnsmessage"
+	"github.com/lucas-clemente/quic-go"
+	"github.com/lucas-clemente/quic-go/http3"
 
-	"github.com/v2fly/v2ray-core/v5/common"
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/common/protocol/dns"
 	"github.com/v2fly/v2ray-core/v5/common/session"
-	"github.com/v2fly/v2ray-core/v5/common/signal/pubsub"
-	"github.com/v2fly/v2ray-core/v5/common/task"
 	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
 	"github.com/v2fly/v2ray-core/v5/features/routing"
 	"github.com/v2fly/v2ray-core/v5/transport/internet"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tls"
 )
 
 // DoHNameServer implemented DNS over HTTPS (RFC8484) Wire Format,
 // which is compatible with traditional dns over udp(RFC1035),
 // thus most of the DOH implementation is copied from udpns.go
 type DoHNameServer struct {
-	sync.RWMutex
-	ips        map[string]record
-	pub        *pubsub.Service
-	cleanup    *task.Periodic
+	cache      *ipCache
 	reqID      uint32
 	httpClient *http.Client
 	dohURL     string
 	name       string
+	validator  *dnssecValidator
 }
 
 // NewDoHNameServer creates DOH server object for remote resolving.
@@ -110,97 +108,135 @@ func NewDoHLocalNameServer(url *url.URL) *DoHNameServer {
 	return s
 }
 
-func baseDOHNameServer(url *url.URL, prefix string) *DoHNameServer {
-	s := &DoHNameServer{
-		ips:    make(map[string]record),
-		pub:    pubsub.NewService(),
-		name:   prefix + "//" + url.Host,
-		dohURL: url.String(),
+// NewDoH3NameServer creates DNS-over-HTTP/3 client object for remote resolving, with the UDP packets carried by
+// the dispatcher.
+func NewDoH3NameServer(url *url.URL, dispatcher routing.Dispatcher, settings *tls.Config) *DoHNameServer {
+	url.Scheme = "https"
+	s := baseDOHNameServer(url, "DOH3")
+	s.httpClient = &http.Client{
+		Timeout: 60 * time.Second,
+		Transport: &http3RoundTripper{
+			tlsConfig: newQUICClientConfig(settings),
+			dial: func(ctx context.Context, addr string, tlsConfig *gotls.Config, quicConfig *quic.Config) (quic.EarlyConnection, error) {
+				dest, err := net.ParseDestination("udp:" + addr)
+				if err != nil {
+					return nil, err
+				}
+				packetConn, err := newDispatchedPacketConn(ctx, dispatcher, dest)
+				if err != nil {
+					return nil, err
+				}
+				conn, err := quic.DialEarlyContext(ctx, packetConn, packetConn.RemoteAddr(), addr, tlsConfig, quicConfig)
+				if err != nil {
+					packetConn.Close()
+					return nil, err
+				}
+				go func() {
+					<-conn.Context().Done()
+					packetConn.Close()
+				}()
+				return conn, nil
+			},
+		},
 	}
-	s.cleanup = &task.Periodic{
-		Interval: time.Minute,
-		Execute:  s.Cleanup,
+	newError("DNS: created Remote DOH3 client for ", url.String()).AtInfo().WriteToLog()
+	return s
+}
+
+// NewDoH3LocalNameServer creates DNS-over-HTTP/3 client object for local resolving
+func NewDoH3LocalNameServer(url *url.URL, settings *tls.Config) *DoHNameServer {
+	url.Scheme = "https"
+	s := baseDOHNameServer(url, "DOH3L")
+	s.httpClient = &http.Client{
+		Timeout: time.Second * 180,
+		Transport: &http3RoundTripper{
+			tlsConfig: newQUICClientConfig(settings),
+			dial:      quic.DialAddrEarlyContext,
+		},
 	}
+	newError("DNS: created Local DOH3 client for ", url.String()).AtInfo().WriteToLog()
 	return s
 }
 
-// Name implements Server.
-func (s *DoHNameServer) Name() string {
-	return s.name
+// http3RoundTripper is an http.RoundTripper over HTTP/3. It replaces the underlying round tripper once its
+// connection is closed or fails to be established, as an http3.RoundTripper never redials.
+type http3RoundTripper struct {
+	access    sync.Mutex
+	tlsConfig *gotls.Config
+	dial      func(context.Context, string, *gotls.Config, *quic.Config) (quic.EarlyConnection, error)
+	current   *http3.RoundTripper
 }
 
-// Cleanup clears expired items from cache
-func (s *DoHNameServer) Cleanup() error {
-	now := time.Now()
-	s.Lock()
-	defer s.Unlock()
+// RoundTrip implements http.RoundTripper.
+func (t *http3RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.roundTripper().RoundTrip(req)
+}
 
-	if len(s.ips) == 0 {
-		return newError("nothing to do. stopping...")
-	}
+func (t *http3RoundTripper) roundTripper() *http3.RoundTripper {
+	t.access.Lock()
+	defer t.access.Unlock()
 
-	for domain, record := range s.ips {
-		if record.A != nil && record.A.Expire.Before(now) {
-			record.A = nil
-		}
-		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
-			record.AAAA = nil
+	if t.current != nil {
+		return t.current
+	}
+	rt := &http3.RoundTripper{
+		TLSClientConfig: t.tlsConfig,
+		QuicConfig: &quic.Config{
+			HandshakeIdleTimeout: handshakeIdleTimeout,
+		},
+	}
+	rt.Dial = func(ctx context.Context, addr string, tlsConfig *gotls.Config, quicConfig *quic.Config) (quic.EarlyConnection, error) {
+		conn, err := t.dial(ctx, addr, tlsConfig, quicConfig)
+		if err != nil {
+			t.drop(rt)
+			return nil, err
 		}
+		go func() {
+			<-conn.Context().Done()
+			t.drop(rt)
+		}()
+		return conn, nil
+	}
+	t.current = rt
+	return rt
+}
 
-		if record.A == nil && record.AAAA == nil {
-			newError(s.name, " cleanup ", domain).AtDebug().WriteToLog()
-			delete(s.ips, domain)
-		} else {
-			s.ips[domain] = record
-		}
+func (t *http3RoundTripper) drop(rt *http3.RoundTripper) {
+	t.access.Lock()
+	defer t.access.Unlock()
+
+	if t.current == rt {
+		t.current = nil
 	}
+}
 
-	if len(s.ips) == 0 {
-		s.ips = make(map[string]record)
+func baseDOHNameServer(url *url.URL, prefix string) *DoHNameServer {
+	s := &DoHNameServer{
+		name:   prefix + "//" + url.Host,
+		dohURL: url.String(),
 	}
+	s.cache = newIPCache(s.name)
+	return s
+}
 
-	return nil
+// Name implements Server.
+func (s *DoHNameServer) Name() string {
+	return s.name
 }
 
-func (s *DoHNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
-	elapsed := time.Since(req.start)
+func (s *DoHNameServer) ipCache() *ipCache {
+	return s.cache
+}
 
-	s.Lock()
-	rec := s.ips[req.domain]
-	updated := false
+// Cleanup clears expired items from cache
+func (s *DoHNameServer) Cleanup() error {
+	return s.cache.Cleanup()
+}
 
-	switch req.reqType {
-	case dnsmessage.TypeA:
-		if isNewer(rec.A, ipRec) {
-			rec.A = ipRec
-			updated = true
-		}
-	case dnsmessage.TypeAAAA:
-		addr := make([]net.Address, 0)
-		for _, ip := range ipRec.IP {
-			if len(ip.IP()) == net.IPv6len {
-				addr = append(addr, ip)
-			}
-		}
-		ipRec.IP = addr
-		if isNewer(rec.AAAA, ipRec) {
-			rec.AAAA = ipRec
-			updated = true
-		}
-	}
+func (s *DoHNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
+	elapsed := time.Since(req.start)
 	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
-
-	if updated {
-		s.ips[req.domain] = rec
-	}
-	switch req.reqType {
-	case dnsmessage.TypeA:
-		s.pub.Publish(req.domain+"4", nil)
-	case dnsmessage.TypeAAAA:
-		s.pub.Publish(req.domain+"6", nil)
-	}
-	s.Unlock()
-	common.Must(s.cleanup.Start())
+	s.cache.Update(req.domain, req.reqType, ipRec)
 }
 
 func (s *DoHNameServer) newReqID() uint16 {
@@ -210,7 +246,7 @@ func (s *DoHNameServer) newReqID() uint16 {
 func (s *DoHNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
 	newError(s.name, " querying: ", domain).AtInfo().WriteToLog(session.ExportIDToError(ctx))
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP))
+	reqs := buildReqMsgs(domain, option, s.newReqID, s.validator.ednsOptions(clientIP))
 
 	var deadline time.Time
 	if d, ok := ctx.Deadline(); ok {
@@ -257,11 +293,24 @@ func (s *DoHNameServer) sendQuery(ctx context.Context, domain string, clientIP n
 				newError("failed to handle DOH response").Base(err).AtError().WriteToLog()
 				return
 			}
-			s.updateIP(r, rec)
+			s.updateIP(r, s.validator.check(dnsCtx, resp, rec))
 		}(req)
 	}
 }
 
+func (s *DoHNameServer) setValidator(v *dnssecValidator) {
+	s.validator = v
+}
+
+func (s *DoHNameServer) exchangeMessage(ctx context.Context, b []byte) ([]byte, error) {
+	ctx = session.ContextWithContent(ctx, &session.Content{
+		Protocol:       "https",
+		SkipDNSResolve: true,
+	})
+	ctx = session.ContextWithMuxPrefered(ctx, true)
+	return s.dohHTTPSContext(ctx, withMessageID(b, s.newReqID()))
+}
+
 func (s *DoHNameServer) dohHTTPSContext(ctx context.Context, b []byte) ([]byte, error) {
 	body := bytes.NewBuffer(b)
 	req, err := http.NewRequest("POST", s.dohURL, body)
@@ -286,96 +335,7 @@ func (s *DoHNameServer) dohHTTPSContext(ctx context.Context, b []byte) ([]byte,
 	return io.ReadAll(resp.Body)
 }
 
-func (s *DoHNameServer) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
-	s.RLock()
-	record, found := s.ips[domain]
-	s.RUnlock()
-
-	if !found {
-		return nil, errRecordNotFound
-	}
-
-	var ips []net.Address
-	var lastErr error
-	if option.IPv4Enable {
-		a, err := record.A.getIPs()
-		if err != nil {
-			lastErr = err
-		}
-		ips = append(ips, a...)
-	}
-
-	if option.IPv6Enable {
-		aaaa, err := record.AAAA.getIPs()
-		if err != nil {
-			lastErr = err
-		}
-		ips = append(ips, aaaa...)
-	}
-
-	if len(ips) > 0 {
-		return toNetIP(ips)
-	}
-
-	if lastErr != nil {
-		return nil, lastErr
-	}
-
-	return nil, dns_feature.ErrEmptyResponse
-}
-
 // QueryIP implements Server.
-func (s *DoHNameServer) QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption, disableCache bool) ([]net.IP, error) { // nolint: dupl
-	fqdn := Fqdn(domain)
-
-	if disableCache {
-		newError("DNS cache is disabled. Querying IP for ", domain, " at ", s.name).AtDebug().WriteToLog()
-	} else {
-		ips, err := s.findIPsForDomain(fqdn, option)
-		if err != errRecordNotFound {
-			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
-			return ips, err
-		}
-	}
-
-	// ipv4 and ipv6 belong to different subscription groups
-	var sub4, sub6 *pubsub.Subscriber
-	if option.IPv4Enable {
-		sub4 = s.pub.Subscribe(fqdn + "4")
-		defer sub4.Close()
-	}
-	if option.IPv6Enable {
-		sub6 = s.pub.Subscribe(fqdn + "6")
-		defer sub6.Close()
-	}
-	done := make(chan interface{})
-	go func() {
-		if sub4 != nil {
-			select {
-			case <-sub4.Wait():
-			case <-ctx.Done():
-			}
-		}
-		if sub6 != nil {
-			select {
-			case <-sub6.Wait():
-			case <-ctx.Done():
-			}
-		}
-		close(done)
-	}()
-	s.sendQuery(ctx, fqdn, clientIP, option)
-
-	for {
-		ips, err := s.findIPsForDomain(fqdn, option)
-		if err != errRecordNotFound {
-			return ips, err
-		}
-
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-done:
-		}
-	}
+func (s *DoHNameServer) QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption, disableCache bool) ([]net.IP, error) {
+	return s.cache.QueryIP(ctx, domain, clientIP, option, disableCache, s.sendQuery)
 }
@@ -2,16 +2,21 @@ package dns_test
 
 import (
 	"context"
+	"io"
+	"net/http"
 	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/lucas-clemente/quic-go/http3"
 
 	. "github.com/v2fly/v2ray-core/v5/app/dns"
 	"github.com/v2fly/v2ray-core/v5/common"
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tls"
 )
 
 func TestDoHLocalNameServer(t *testing.T) {
@@ -58,3 +63,40 @@ func TestDoHLocalNameServerWithCache(t *testing.T) {
 		t.Fatal(r)
 	}
 }
+
+func TestDoH3NameServer(t *testing.T) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.LocalHostIP.IP()})
+	common.Must(err)
+	server := &http3.Server{
+		TLSConfig: newTestServerTLSConfig(),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query, err := io.ReadAll(r.Body)
+			if err != nil || r.URL.Path != "/dns-query" {
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/dns-message")
+			w.Write(answerDNSQuery(query))
+		}),
+	}
+	go server.Serve(conn)
+	defer server.Close()
+
+	dispatcher := new(udpDispatcher)
+	url, err := url.Parse("h3://" + conn.LocalAddr().String() + "/dns-query")
+	common.Must(err)
+	s := NewDoH3NameServer(url, dispatcher, &tls.Config{AllowInsecure: true})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ips, err := s.QueryIP(ctx, "example.com", net.IP(nil), dns_feature.IPOption{
+		IPv4Enable: true,
+		IPv6Enable: true,
+	}, false)
+	cancel()
+	common.Must(err)
+	if len(ips) != 1 || ips[0].String() != "1.2.3.4" {
+		t.Error("unexpected answer: ", ips)
+	}
+	if n := atomic.LoadInt32(&dispatcher.dispatched); n != 1 {
+		t.Error("expect the connection to be dispatched once, got ", n)
+	}
+}
@@ -1,8 +1,8 @@
 package dns
 
 import (
-	"bytes"
 	"context"
+	gotls "crypto/tls"
 	"encoding/binary"
 	"net/url"
 	"sync"
@@ -10,17 +10,14 @@ import (
 	"time"
 
 	"github.com/lucas-clemente/quic-go"
-	"golang.org/x/net/dns/dnsmessage"
 	"golang.org/x/net/http2"
 
-	"github.com/v2fly/v2ray-core/v5/common"
 	"github.com/v2fly/v2ray-core/v5/common/buf"
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/common/protocol/dns"
 	"github.com/v2fly/v2ray-core/v5/common/session"
-	"github.com/v2fly/v2ray-core/v5/common/signal/pubsub"
-	"github.com/v2fly/v2ray-core/v5/common/task"
 	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
+	"github.com/v2fly/v2ray-core/v5/features/routing"
 	"github.com/v2fly/v2ray-core/v5/transport/internet/tls"
 )
 
@@ -33,19 +30,59 @@ const handshakeIdleTimeout = time.Second * 8
 // QUICNameServer implemented DNS over QUIC
 type QUICNameServer struct {
 	sync.RWMutex
-	ips         map[string]record
-	pub         *pubsub.Service
-	cleanup     *task.Periodic
+	cache       *ipCache
 	reqID       uint32
 	name        string
 	destination net.Destination
 	connection  quic.Connection
+	tlsConfig   *gotls.Config
+	validator   *dnssecValidator
+	dial        func(context.Context, *gotls.Config, *quic.Config) (quic.Connection, error)
 }
 
 // NewQUICNameServer creates DNS-over-QUIC client object for local resolving
 func NewQUICNameServer(url *url.URL) (*QUICNameServer, error) {
 	newError("DNS: created Local DNS-over-QUIC client for ", url.String()).AtInfo().WriteToLog()
 
+	s, err := baseQUICNameServer(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.dial = func(ctx context.Context, tlsConfig *gotls.Config, quicConfig *quic.Config) (quic.Connection, error) {
+		return quic.DialAddrContext(ctx, s.destination.NetAddr(), tlsConfig, quicConfig)
+	}
+	return s, nil
+}
+
+// NewQUICRemoteNameServer creates DNS-over-QUIC client object for remote resolving, with the UDP packets carried
+// by the dispatcher.
+func NewQUICRemoteNameServer(url *url.URL, dispatcher routing.Dispatcher, settings *tls.Config) (*QUICNameServer, error) {
+	newError("DNS: created Remote DNS-over-QUIC client for ", url.String()).AtInfo().WriteToLog()
+
+	s, err := baseQUICNameServer(url, settings)
+	if err != nil {
+		return nil, err
+	}
+	s.dial = func(ctx context.Context, tlsConfig *gotls.Config, quicConfig *quic.Config) (quic.Connection, error) {
+		packetConn, err := newDispatchedPacketConn(ctx, dispatcher, s.destination)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := quic.DialContext(ctx, packetConn, packetConn.RemoteAddr(), s.destination.Address.String(), tlsConfig, quicConfig)
+		if err != nil {
+			packetConn.Close()
+			return nil, err
+		}
+		go func() {
+			<-conn.Context().Done()
+			packetConn.Close()
+		}()
+		return conn, nil
+	}
+	return s, nil
+}
+
+func baseQUICNameServer(url *url.URL, settings *tls.Config) (*QUICNameServer, error) {
 	var err error
 	port := net.Port(853)
 	if url.Port() != "" {
@@ -57,15 +94,11 @@ func NewQUICNameServer(url *url.URL) (*QUICNameServer, error) {
 	dest := net.UDPDestination(net.ParseAddress(url.Hostname()), port)
 
 	s := &QUICNameServer{
-		ips:         make(map[string]record),
-		pub:         pubsub.NewService(),
 		name:        url.String(),
 		destination: dest,
+		tlsConfig:   newQUICClientConfig(settings, "http/1.1", http2.NextProtoTLS, NextProtoDQ),
 	}
-	s.cleanup = &task.Periodic{
-		Interval: time.Minute,
-		Execute:  s.Cleanup,
-	}
+	s.cache = newIPCache(s.name)
 
 	return s, nil
 }
@@ -75,78 +108,19 @@ func (s *QUICNameServer) Name() string {
 	return s.name
 }
 
+func (s *QUICNameServer) ipCache() *ipCache {
+	return s.cache
+}
+
 // Cleanup clears expired items from cache
 func (s *QUICNameServer) Cleanup() error {
-	now := time.Now()
-	s.Lock()
-	defer s.Unlock()
-
-	if len(s.ips) == 0 {
-		return newError("nothing to do. stopping...")
-	}
-
-	for domain, record := range s.ips {
-		if record.A != nil && record.A.Expire.Before(now) {
-			record.A = nil
-		}
-		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
-			record.AAAA = nil
-		}
-
-		if record.A == nil && record.AAAA == nil {
-			newError(s.name, " cleanup ", domain).AtDebug().WriteToLog()
-			delete(s.ips, domain)
-		} else {
-			s.ips[domain] = record
-		}
-	}
-
-	if len(s.ips) == 0 {
-		s.ips = make(map[string]record)
-	}
-
-	return nil
+	return s.cache.Cleanup()
 }
 
 func (s *QUICNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
 	elapsed := time.Since(req.start)
-
-	s.Lock()
-	rec := s.ips[req.domain]
-	updated := false
-
-	switch req.reqType {
-	case dnsmessage.TypeA:
-		if isNewer(rec.A, ipRec) {
-			rec.A = ipRec
-			updated = true
-		}
-	case dnsmessage.TypeAAAA:
-		addr := make([]net.Address, 0)
-		for _, ip := range ipRec.IP {
-			if len(ip.IP()) == net.IPv6len {
-				addr = append(addr, ip)
-			}
-		}
-		ipRec.IP = addr
-		if isNewer(rec.AAAA, ipRec) {
-			rec.AAAA = ipRec
-			updated = true
-		}
-	}
 	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
-
-	if updated {
-		s.ips[req.domain] = rec
-	}
-	switch req.reqType {
-	case dnsmessage.TypeA:
-		s.pub.Publish(req.domain+"4", nil)
-	case dnsmessage.TypeAAAA:
-		s.pub.Publish(req.domain+"6", nil)
-	}
-	s.Unlock()
-	common.Must(s.cleanup.Start())
+	s.cache.Update(req.domain, req.reqType, ipRec)
 }
 
 func (s *QUICNameServer) newReqID() uint16 {
@@ -156,7 +130,7 @@ func (s *QUICNameServer) newReqID() uint16 {
 func (s *QUICNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
 	newError(s.name, " querying: ", domain).AtInfo().WriteToLog(session.ExportIDToError(ctx))
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP))
+	reqs := buildReqMsgs(domain, option, s.newReqID, s.validator.ednsOptions(clientIP))
 
 	var deadline time.Time
 	if d, ok := ctx.Deadline(); ok {
@@ -191,147 +165,80 @@ func (s *QUICNameServer) sendQuery(ctx context.Context, domain string, clientIP
 				return
 			}
 
-			dnsReqBuf := buf.New()
-			binary.Write(dnsReqBuf, binary.BigEndian, uint16(b.Len()))
-			dnsReqBuf.Write(b.Bytes())
+			resp, err := s.exchangeStream(dnsCtx, b.Bytes())
 			b.Release()
-
-			conn, err := s.openStream(dnsCtx)
-			if err != nil {
-				newError("failed to open quic connection").Base(err).AtError().WriteToLog()
-				return
-			}
-
-			_, err = conn.Write(dnsReqBuf.Bytes())
-			if err != nil {
-				newError("failed to send query").Base(err).AtError().WriteToLog()
-				return
-			}
-
-			_ = conn.Close()
-
-			respBuf := buf.New()
-			defer respBuf.Release()
-			n, err := respBuf.ReadFullFrom(conn, 2)
-			if err != nil && n == 0 {
-				newError("failed to read response length").Base(err).AtError().WriteToLog()
-				return
-			}
-			var length int16
-			err = binary.Read(bytes.NewReader(respBuf.Bytes()), binary.BigEndian, &length)
 			if err != nil {
-				newError("failed to parse response length").Base(err).AtError().WriteToLog()
-				return
-			}
-			respBuf.Clear()
-			n, err = respBuf.ReadFullFrom(conn, int32(length))
-			if err != nil && n == 0 {
-				newError("failed to read response length").Base(err).AtError().WriteToLog()
+				newError("failed to query ", s.name).Base(err).AtError().WriteToLog()
 				return
 			}
 
-			rec, err := parseResponse(respBuf.Bytes())
+			rec, err := parseResponse(resp)
 			if err != nil {
 				newError("failed to handle response").Base(err).AtError().WriteToLog()
 				return
 			}
-			s.updateIP(r, rec)
+			s.updateIP(r, s.validator.check(dnsCtx, resp, rec))
 		}(req)
 	}
 }
 
-func (s *QUICNameServer) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
-	s.RLock()
-	record, found := s.ips[domain]
-	s.RUnlock()
+// exchangeStream sends a packed query over a new stream, and returns the response.
+func (s *QUICNameServer) exchangeStream(ctx context.Context, b []byte) ([]byte, error) {
+	dnsReqBuf := buf.New()
+	defer dnsReqBuf.Release()
+	binary.Write(dnsReqBuf, binary.BigEndian, uint16(len(b)))
+	dnsReqBuf.Write(b)
 
-	if !found {
-		return nil, errRecordNotFound
+	conn, err := s.openStream(ctx)
+	if err != nil {
+		return nil, newError("failed to open quic connection").Base(err)
 	}
 
-	var ips []net.Address
-	var lastErr error
-	if option.IPv4Enable {
-		a, err := record.A.getIPs()
-		if err != nil {
-			lastErr = err
-		}
-		ips = append(ips, a...)
+	if _, err := conn.Write(dnsReqBuf.Bytes()); err != nil {
+		return nil, newError("failed to send query").Base(err)
 	}
 
-	if option.IPv6Enable {
-		aaaa, err := record.AAAA.getIPs()
-		if err != nil {
-			lastErr = err
-		}
-		ips = append(ips, aaaa...)
-	}
+	_ = conn.Close()
 
-	if len(ips) > 0 {
-		return toNetIP(ips)
+	respBuf := buf.New()
+	defer respBuf.Release()
+	n, err := respBuf.ReadFullFrom(conn, 2)
+	if err != nil && n == 0 {
+		return nil, newError("failed to read response length").Base(err)
 	}
-
-	if lastErr != nil {
-		return nil, lastErr
+	length := binary.BigEndian.Uint16(respBuf.Bytes())
+	respBuf.Clear()
+	n, err = respBuf.ReadFullFrom(conn, int32(length))
+	if err != nil && n == 0 {
+		return nil, newError("failed to read response").Base(err)
 	}
+	return append([]byte(nil), respBuf.Bytes()...), nil
+}
 
-	return nil, dns_feature.ErrEmptyResponse
+func (s *QUICNameServer) setValidator(v *dnssecValidator) {
+	s.validator = v
+}
+
+func (s *QUICNameServer) exchangeMessage(ctx context.Context, b []byte) ([]byte, error) {
+	ctx = session.ContextWithContent(ctx, &session.Content{
+		Protocol:       "quic",
+		SkipDNSResolve: true,
+	})
+	return s.exchangeStream(ctx, withMessageID(b, s.newReqID()))
 }
 
 // QueryIP is called from dns.Server->queryIPTimeout
 func (s *QUICNameServer) QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption, disableCache bool) ([]net.IP, error) {
-	fqdn := Fqdn(domain)
-
-	if disableCache {
-		newError("DNS cache is disabled. Querying IP for ", domain, " at ", s.name).AtDebug().WriteToLog()
-	} else {
-		ips, err := s.findIPsForDomain(fqdn, option)
-		if err != errRecordNotFound {
-			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
-			return ips, err
-		}
-	}
-
-	// ipv4 and ipv6 belong to different subscription groups
-	var sub4, sub6 *pubsub.Subscriber
-	if option.IPv4Enable {
-		sub4 = s.pub.Subscribe(fqdn + "4")
-		defer sub4.Close()
-	}
-	if option.IPv6Enable {
-		sub6 = s.pub.Subscribe(fqdn + "6")
-		defer sub6.Close()
-	}
-	done := make(chan interface{})
-	go func() {
-		if sub4 != nil {
-			select {
-			case <-sub4.Wait():
-			case <-ctx.Done():
-			}
-		}
-		if sub6 != nil {
-			select {
-			case <-sub6.Wait():
-			case <-ctx.Done():
-			}
-		}
-		close(done)
-	}()
-	s.sendQuery(ctx, fqdn, clientIP, option)
-
-	for {
-		ips, err := s.findIPsForDomain(fqdn, option)
-		if err != errRecordNotFound {
-			return ips, err
-		}
+	return s.cache.QueryIP(ctx, domain, clientIP, option, disableCache, s.sendQuery)
+}
 
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-done:
-		}
+// newQUICClientConfig builds the TLS config of a QUIC based name server from settings, with nextProtos as the
+// default ALPN values.
+func newQUICClientConfig(settings *tls.Config, nextProtos ...string) *gotls.Config {
+	if settings == nil {
+		settings = &tls.Config{}
 	}
+	return settings.GetTLSConfig(tls.WithNextProto(nextProtos...))
 }
 
 func isActive(s quic.Connection) bool {
@@ -378,12 +285,11 @@ func (s *QUICNameServer) getConnection(ctx context.Context) (quic.Connection, er
 }
 
 func (s *QUICNameServer) openConnection(ctx context.Context) (quic.Connection, error) {
-	tlsConfig := tls.Config{}
 	quicConfig := &quic.Config{
 		HandshakeIdleTimeout: handshakeIdleTimeout,
 	}
 
-	conn, err := quic.DialAddrContext(ctx, s.destination.NetAddr(), tlsConfig.GetTLSConfig(tls.WithNextProto("http/1.1", http2.NextProtoTLS, NextProtoDQ)), quicConfig)
+	conn, err := s.dial(ctx, s.tlsConfig, quicConfig)
 	if err != nil {
 		return nil, err
 	}
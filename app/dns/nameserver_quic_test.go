@@ -2,18 +2,96 @@ package dns_test
 
 import (
 	"context"
+	gotls "crypto/tls"
+	"encoding/binary"
+	"io"
 	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/lucas-clemente/quic-go"
 
 	. "github.com/v2fly/v2ray-core/v5/app/dns"
 	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/buf"
 	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/protocol/tls/cert"
 	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
+	"github.com/v2fly/v2ray-core/v5/features/routing"
+	"github.com/v2fly/v2ray-core/v5/transport"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tls"
+	"github.com/v2fly/v2ray-core/v5/transport/pipe"
 )
 
+// udpDispatcher is a routing.Dispatcher sending UDP packets directly to the destination.
+type udpDispatcher struct {
+	dispatched int32
+}
+
+func (d *udpDispatcher) Dispatch(ctx context.Context, dest net.Destination) (*transport.Link, error) {
+	atomic.AddInt32(&d.dispatched, 1)
+	conn, err := net.ListenUDP("udp", nil)
+	if err != nil {
+		return nil, err
+	}
+	addr := &net.UDPAddr{IP: dest.Address.IP(), Port: int(dest.Port)}
+	uplinkReader, uplinkWriter := pipe.New()
+	downlinkReader, downlinkWriter := pipe.New()
+	go func() {
+		defer conn.Close()
+		for {
+			mb, err := uplinkReader.ReadMultiBuffer()
+			if err != nil {
+				return
+			}
+			for _, b := range mb {
+				conn.WriteTo(b.Bytes(), addr)
+			}
+			buf.ReleaseMulti(mb)
+		}
+	}()
+	go func() {
+		defer downlinkWriter.Close()
+		for {
+			b := buf.New()
+			n, _, err := conn.ReadFrom(b.Extend(buf.Size))
+			if err != nil {
+				b.Release()
+				return
+			}
+			b.Resize(0, int32(n))
+			if err := downlinkWriter.WriteMultiBuffer(buf.MultiBuffer{b}); err != nil {
+				return
+			}
+		}
+	}()
+	return &transport.Link{Reader: downlinkReader, Writer: uplinkWriter}, nil
+}
+
+func (*udpDispatcher) Start() error {
+	return nil
+}
+
+func (*udpDispatcher) Close() error {
+	return nil
+}
+
+func (*udpDispatcher) Type() interface{} {
+	return routing.DispatcherType()
+}
+
+// newTestServerTLSConfig returns the TLS config of a test server with a self signed certificate.
+func newTestServerTLSConfig(nextProtos ...string) *gotls.Config {
+	serverCert, err := gotls.X509KeyPair(cert.MustGenerate(nil, cert.DNSNames("dns.example.com")).ToPEM())
+	common.Must(err)
+	return &gotls.Config{
+		Certificates: []gotls.Certificate{serverCert},
+		NextProtos:   nextProtos,
+	}
+}
+
 func TestQUICNameServer(t *testing.T) {
 	url, err := url.Parse("quic://dns.adguard.com")
 	common.Must(err)
@@ -58,3 +136,58 @@ func TestQUICNameServerWithCache(t *testing.T) {
 		t.Fatal(r)
 	}
 }
+
+func TestQUICRemoteNameServer(t *testing.T) {
+	listener, err := quic.ListenAddr("127.0.0.1:0", newTestServerTLSConfig(NextProtoDQ), nil)
+	common.Must(err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept(context.Background())
+			if err != nil {
+				return
+			}
+			go func() {
+				for {
+					stream, err := conn.AcceptStream(context.Background())
+					if err != nil {
+						return
+					}
+					go func() {
+						defer stream.Close()
+						var length uint16
+						if err := binary.Read(stream, binary.BigEndian, &length); err != nil {
+							return
+						}
+						query := make([]byte, length)
+						if _, err := io.ReadFull(stream, query); err != nil {
+							return
+						}
+						resp := answerDNSQuery(query)
+						binary.Write(stream, binary.BigEndian, uint16(len(resp)))
+						stream.Write(resp)
+					}()
+				}
+			}()
+		}
+	}()
+
+	dispatcher := new(udpDispatcher)
+	url, err := url.Parse("quic://" + listener.Addr().String())
+	common.Must(err)
+	s, err := NewQUICRemoteNameServer(url, dispatcher, &tls.Config{AllowInsecure: true})
+	common.Must(err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ips, err := s.QueryIP(ctx, "example.com", net.IP(nil), dns_feature.IPOption{
+		IPv4Enable: true,
+		IPv6Enable: true,
+	}, false)
+	cancel()
+	common.Must(err)
+	if len(ips) != 1 || ips[0].String() != "1.2.3.4" {
+		t.Error("unexpected answer: ", ips)
+	}
+	if n := atomic.LoadInt32(&dispatcher.dispatched); n != 1 {
+		t.Error("expect the connection to be dispatched once, got ", n)
+	}
+}
@@ -4,23 +4,17 @@
 package dns
 
 import (
-	"bytes"
 	"context"
 	"encoding/binary"
+	"io"
 	"net/url"
-	"sync"
 	"sync/atomic"
 	"time"
 
-	"golang.org/x/net/dns/dnsmessage"
-
-	"github.com/v2fly/v2ray-core/v5/common"
 	"github.com/v2fly/v2ray-core/v5/common/buf"
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/common/protocol/dns"
 	"github.com/v2fly/v2ray-core/v5/common/session"
-	"github.com/v2fly/v2ray-core/v5/common/signal/pubsub"
-	"github.com/v2fly/v2ray-core/v5/common/task"
 	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
 	"github.com/v2fly/v2ray-core/v5/features/routing"
 	"github.com/v2fly/v2ray-core/v5/transport/internet"
@@ -28,19 +22,19 @@ import (
 
 // TCPNameServer implemented DNS over TCP (RFC7766).
 type TCPNameServer struct {
-	sync.RWMutex
 	name        string
 	destination net.Destination
-	ips         map[string]record
-	pub         *pubsub.Service
-	cleanup     *task.Periodic
+	cache       *ipCache
 	reqID       uint32
+	validator   *dnssecValidator
 	dial        func(context.Context) (net.Conn, error)
+	// exchange sends a packed query and returns the response.
+	exchange func(context.Context, []byte) ([]byte, error)
 }
 
 // NewTCPNameServer creates DNS over TCP server object for remote resolving.
 func NewTCPNameServer(url *url.URL, dispatcher routing.Dispatcher) (*TCPNameServer, error) {
-	s, err := baseTCPNameServer(url, "TCP")
+	s, err := baseTCPNameServer(url, "TCP", 53)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +56,7 @@ func NewTCPNameServer(url *url.URL, dispatcher routing.Dispatcher) (*TCPNameServ
 
 // NewTCPLocalNameServer creates DNS over TCP client object for local resolving
 func NewTCPLocalNameServer(url *url.URL) (*TCPNameServer, error) {
-	s, err := baseTCPNameServer(url, "TCPL")
+	s, err := baseTCPNameServer(url, "TCPL", 53)
 	if err != nil {
 		return nil, err
 	}
@@ -74,9 +68,8 @@ func NewTCPLocalNameServer(url *url.URL) (*TCPNameServer, error) {
 	return s, nil
 }
 
-func baseTCPNameServer(url *url.URL, prefix string) (*TCPNameServer, error) {
+func baseTCPNameServer(url *url.URL, prefix string, port net.Port) (*TCPNameServer, error) {
 	var err error
-	port := net.Port(53)
 	if url.Port() != "" {
 		port, err = net.PortFromString(url.Port())
 		if err != nil {
@@ -87,14 +80,10 @@ func baseTCPNameServer(url *url.URL, prefix string) (*TCPNameServer, error) {
 
 	s := &TCPNameServer{
 		destination: dest,
-		ips:         make(map[string]record),
-		pub:         pubsub.NewService(),
 		name:        prefix + "//" + dest.NetAddr(),
 	}
-	s.cleanup = &task.Periodic{
-		Interval: time.Minute,
-		Execute:  s.Cleanup,
-	}
+	s.cache = newIPCache(s.name)
+	s.exchange = s.exchangeOverNewConn
 
 	return s, nil
 }
@@ -104,88 +93,81 @@ func (s *TCPNameServer) Name() string {
 	return s.name
 }
 
+func (s *TCPNameServer) ipCache() *ipCache {
+	return s.cache
+}
+
 // Cleanup clears expired items from cache
 func (s *TCPNameServer) Cleanup() error {
-	now := time.Now()
-	s.Lock()
-	defer s.Unlock()
+	return s.cache.Cleanup()
+}
 
-	if len(s.ips) == 0 {
-		return newError("nothing to do. stopping...")
-	}
+func (s *TCPNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
+	elapsed := time.Since(req.start)
+	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
+	s.cache.Update(req.domain, req.reqType, ipRec)
+}
 
-	for domain, record := range s.ips {
-		if record.A != nil && record.A.Expire.Before(now) {
-			record.A = nil
-		}
-		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
-			record.AAAA = nil
-		}
+func (s *TCPNameServer) newReqID() uint16 {
+	return uint16(atomic.AddUint32(&s.reqID, 1))
+}
 
-		if record.A == nil && record.AAAA == nil {
-			newError(s.name, " cleanup ", domain).AtDebug().WriteToLog()
-			delete(s.ips, domain)
-		} else {
-			s.ips[domain] = record
-		}
-	}
+func (s *TCPNameServer) setValidator(v *dnssecValidator) {
+	s.validator = v
+}
 
-	if len(s.ips) == 0 {
-		s.ips = make(map[string]record)
+func (s *TCPNameServer) exchangeMessage(ctx context.Context, b []byte) ([]byte, error) {
+	ctx = session.ContextWithContent(ctx, &session.Content{
+		Protocol:       "dns",
+		SkipDNSResolve: true,
+	})
+	return s.exchange(ctx, withMessageID(b, s.newReqID()))
+}
+
+// exchangeOverNewConn dials a new connection for the query, and closes it after the response is read.
+func (s *TCPNameServer) exchangeOverNewConn(ctx context.Context, b []byte) ([]byte, error) {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return nil, newError("failed to dial namesever").Base(err)
 	}
+	defer conn.Close()
 
-	return nil
+	if err := writeTCPMessage(conn, b); err != nil {
+		return nil, err
+	}
+	return readTCPMessage(conn)
 }
 
-func (s *TCPNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
-	elapsed := time.Since(req.start)
-
-	s.Lock()
-	rec := s.ips[req.domain]
-	updated := false
+// writeTCPMessage writes the DNS message prefixed with its length.
+func writeTCPMessage(conn net.Conn, b []byte) error {
+	dnsReqBuf := buf.New()
+	defer dnsReqBuf.Release()
+	binary.Write(dnsReqBuf, binary.BigEndian, uint16(len(b)))
+	dnsReqBuf.Write(b)
 
-	switch req.reqType {
-	case dnsmessage.TypeA:
-		if isNewer(rec.A, ipRec) {
-			rec.A = ipRec
-			updated = true
-		}
-	case dnsmessage.TypeAAAA:
-		addr := make([]net.Address, 0)
-		for _, ip := range ipRec.IP {
-			if len(ip.IP()) == net.IPv6len {
-				addr = append(addr, ip)
-			}
-		}
-		ipRec.IP = addr
-		if isNewer(rec.AAAA, ipRec) {
-			rec.AAAA = ipRec
-			updated = true
-		}
+	if _, err := conn.Write(dnsReqBuf.Bytes()); err != nil {
+		return newError("failed to send query").Base(err)
 	}
-	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
+	return nil
+}
 
-	if updated {
-		s.ips[req.domain] = rec
+// readTCPMessage reads a DNS message prefixed with its length.
+func readTCPMessage(conn net.Conn) ([]byte, error) {
+	var length uint16
+	if err := binary.Read(conn, binary.BigEndian, &length); err != nil {
+		return nil, newError("failed to read response length").Base(err)
 	}
-	switch req.reqType {
-	case dnsmessage.TypeA:
-		s.pub.Publish(req.domain+"4", nil)
-	case dnsmessage.TypeAAAA:
-		s.pub.Publish(req.domain+"6", nil)
+	resp := make([]byte, length)
+	if _, err := io.ReadFull(conn, resp); err != nil {
+		return nil, newError("failed to read response").Base(err)
 	}
-	s.Unlock()
-	common.Must(s.cleanup.Start())
-}
-
-func (s *TCPNameServer) newReqID() uint16 {
-	return uint16(atomic.AddUint32(&s.reqID, 1))
+	return resp, nil
 }
 
 func (s *TCPNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
 	newError(s.name, " querying DNS for: ", domain).AtDebug().WriteToLog(session.ExportIDToError(ctx))
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP))
+	reqs := buildReqMsgs(domain, option, s.newReqID, s.validator.ednsOptions(clientIP))
 
 	var deadline time.Time
 	if d, ok := ctx.Deadline(); ok {
@@ -217,145 +199,25 @@ func (s *TCPNameServer) sendQuery(ctx context.Context, domain string, clientIP n
 				return
 			}
 
-			conn, err := s.dial(dnsCtx)
-			if err != nil {
-				newError("failed to dial namesever").Base(err).AtError().WriteToLog()
-				return
-			}
-			defer conn.Close()
-			dnsReqBuf := buf.New()
-			binary.Write(dnsReqBuf, binary.BigEndian, uint16(b.Len()))
-			dnsReqBuf.Write(b.Bytes())
+			resp, err := s.exchange(dnsCtx, b.Bytes())
 			b.Release()
-
-			_, err = conn.Write(dnsReqBuf.Bytes())
 			if err != nil {
-				newError("failed to send query").Base(err).AtError().WriteToLog()
+				newError("failed to query ", s.name).Base(err).AtError().WriteToLog()
 				return
 			}
-			dnsReqBuf.Release()
 
-			respBuf := buf.New()
-			defer respBuf.Release()
-			n, err := respBuf.ReadFullFrom(conn, 2)
-			if err != nil && n == 0 {
-				newError("failed to read response length").Base(err).AtError().WriteToLog()
-				return
-			}
-			var length int16
-			err = binary.Read(bytes.NewReader(respBuf.Bytes()), binary.BigEndian, &length)
-			if err != nil {
-				newError("failed to parse response length").Base(err).AtError().WriteToLog()
-				return
-			}
-			respBuf.Clear()
-			n, err = respBuf.ReadFullFrom(conn, int32(length))
-			if err != nil && n == 0 {
-				newError("failed to read response length").Base(err).AtError().WriteToLog()
-				return
-			}
-
-			rec, err := parseResponse(respBuf.Bytes())
+			rec, err := parseResponse(resp)
 			if err != nil {
 				newError("failed to parse DNS over TCP response").Base(err).AtError().WriteToLog()
 				return
 			}
 
-			s.updateIP(r, rec)
+			s.updateIP(r, s.validator.check(dnsCtx, resp, rec))
 		}(req)
 	}
 }
 
-func (s *TCPNameServer) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
-	s.RLock()
-	record, found := s.ips[domain]
-	s.RUnlock()
-
-	if !found {
-		return nil, errRecordNotFound
-	}
-
-	var ips []net.Address
-	var lastErr error
-	if option.IPv4Enable {
-		a, err := record.A.getIPs()
-		if err != nil {
-			lastErr = err
-		}
-		ips = append(ips, a...)
-	}
-
-	if option.IPv6Enable {
-		aaaa, err := record.AAAA.getIPs()
-		if err != nil {
-			lastErr = err
-		}
-		ips = append(ips, aaaa...)
-	}
-
-	if len(ips) > 0 {
-		return toNetIP(ips)
-	}
-
-	if lastErr != nil {
-		return nil, lastErr
-	}
-
-	return nil, dns_feature.ErrEmptyResponse
-}
-
 // QueryIP implements Server.
 func (s *TCPNameServer) QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption, disableCache bool) ([]net.IP, error) {
-	fqdn := Fqdn(domain)
-
-	if disableCache {
-		newError("DNS cache is disabled. Querying IP for ", domain, " at ", s.name).AtDebug().WriteToLog()
-	} else {
-		ips, err := s.findIPsForDomain(fqdn, option)
-		if err != errRecordNotFound {
-			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
-			return ips, err
-		}
-	}
-
-	// ipv4 and ipv6 belong to different subscription groups
-	var sub4, sub6 *pubsub.Subscriber
-	if option.IPv4Enable {
-		sub4 = s.pub.Subscribe(fqdn + "4")
-		defer sub4.Close()
-	}
-	if option.IPv6Enable {
-		sub6 = s.pub.Subscribe(fqdn + "6")
-		defer sub6.Close()
-	}
-	done := make(chan interface{})
-	go func() {
-		if sub4 != nil {
-			select {
-			case <-sub4.Wait():
-			case <-ctx.Done():
-			}
-		}
-		if sub6 != nil {
-			select {
-			case <-sub6.Wait():
-			case <-ctx.Done():
-			}
-		}
-		close(done)
-	}()
-	s.sendQuery(ctx, fqdn, clientIP, option)
-
-	for {
-		ips, err := s.findIPsForDomain(fqdn, option)
-		if err != errRecordNotFound {
-			return ips, err
-		}
-
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-done:
-		}
-	}
+	return s.cache.QueryIP(ctx, domain, clientIP, option, disableCache, s.sendQuery)
 }
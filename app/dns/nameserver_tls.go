@@ -0,0 +1,256 @@
+//go:build !confonly
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+	gotls "crypto/tls"
+	"encoding/binary"
+	"net/url"
+	"sync"
+	"time"
+
+	core "github.com/v2fly/v2ray-core/v5"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/features/routing"
+	"github.com/v2fly/v2ray-core/v5/transport/internet"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tls"
+)
+
+// tlsIdleTimeout is the time after which a connection without queries is closed.
+const tlsIdleTimeout = 30 * time.Second
+
+// TLSNameServer implemented DNS over TLS (RFC7858). Queries are pipelined over a single connection, which is
+// established on demand and reused until it breaks or idles.
+type TLSNameServer struct {
+	*TCPNameServer
+
+	tlsConfig *gotls.Config
+
+	access sync.Mutex
+	conn   *pipelinedConn
+}
+
+// NewTLSNameServer creates DNS over TLS server object for remote resolving.
+func NewTLSNameServer(url *url.URL, dispatcher routing.Dispatcher, settings *tls.Config) (*TLSNameServer, error) {
+	s, err := baseTLSNameServer(url, "DOT", settings)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dial = func(ctx context.Context) (net.Conn, error) {
+		// The connection outlives the query that establishes it.
+		link, err := dispatcher.Dispatch(core.ToBackgroundDetachedContext(ctx), s.destination)
+		if err != nil {
+			return nil, err
+		}
+
+		return net.NewConnection(
+			net.ConnectionInputMulti(link.Writer),
+			net.ConnectionOutputMulti(link.Reader),
+		), nil
+	}
+
+	return s, nil
+}
+
+// NewTLSLocalNameServer creates DNS over TLS client object for local resolving
+func NewTLSLocalNameServer(url *url.URL, settings *tls.Config) (*TLSNameServer, error) {
+	s, err := baseTLSNameServer(url, "DOTL", settings)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dial = func(ctx context.Context) (net.Conn, error) {
+		return internet.DialSystem(ctx, s.destination, nil)
+	}
+
+	return s, nil
+}
+
+func baseTLSNameServer(url *url.URL, prefix string, settings *tls.Config) (*TLSNameServer, error) {
+	tcp, err := baseTCPNameServer(url, prefix, 853)
+	if err != nil {
+		return nil, err
+	}
+	s := &TLSNameServer{
+		TCPNameServer: tcp,
+		tlsConfig:     newTLSClientConfig(url, settings),
+	}
+	s.exchange = s.exchangePipelined
+	return s, nil
+}
+
+// newTLSClientConfig builds the TLS config of a DNS over TLS server. The server name is taken, in order, from the
+// "sni" query parameter of url, the server name of settings and the host of url.
+func newTLSClientConfig(url *url.URL, settings *tls.Config) *gotls.Config {
+	if settings == nil {
+		settings = &tls.Config{}
+	}
+	config := settings.GetTLSConfig()
+	if len(settings.NextProtocol) == 0 {
+		config.NextProtos = []string{"dot"}
+	}
+	if sni := url.Query().Get("sni"); sni != "" {
+		config.ServerName = sni
+	} else if config.ServerName == "" {
+		config.ServerName = url.Hostname()
+	}
+	return config
+}
+
+// connect returns the current connection, or establishes a new one if there is none. reused tells whether the
+// connection was established by an earlier query.
+func (s *TLSNameServer) connect(ctx context.Context) (conn *pipelinedConn, reused bool, err error) {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	if s.conn != nil && !s.conn.closed() {
+		return s.conn, true, nil
+	}
+
+	rawConn, err := s.dial(ctx)
+	if err != nil {
+		return nil, false, newError("failed to dial namesever").Base(err)
+	}
+	tlsConn := gotls.Client(rawConn, s.tlsConfig)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		rawConn.Close()
+		return nil, false, newError("failed to handshake with ", s.name).Base(err)
+	}
+	newError(s.name, " connected to ", s.destination).AtDebug().WriteToLog()
+
+	s.conn = newPipelinedConn(tlsConn)
+	return s.conn, false, nil
+}
+
+func (s *TLSNameServer) exchangePipelined(ctx context.Context, b []byte) ([]byte, error) {
+	for {
+		conn, reused, err := s.connect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := conn.exchange(ctx, b)
+		// The server may have closed an idle connection before the query reached it.
+		if err == errConnectionBroken && reused {
+			continue
+		}
+		return resp, err
+	}
+}
+
+var errConnectionBroken = newError("connection broken before the query was sent")
+
+// pipelinedConn sends DNS messages over a stream connection without waiting for earlier responses, and dispatches
+// the responses by their message ID.
+type pipelinedConn struct {
+	conn net.Conn
+
+	writeAccess sync.Mutex
+
+	access  sync.Mutex
+	pending map[uint16]chan []byte
+	err     error
+	done    chan struct{}
+}
+
+func newPipelinedConn(conn net.Conn) *pipelinedConn {
+	c := &pipelinedConn{
+		conn:    conn,
+		pending: make(map[uint16]chan []byte),
+		done:    make(chan struct{}),
+	}
+	conn.SetReadDeadline(time.Now().Add(tlsIdleTimeout))
+	go c.readResponses()
+	return c
+}
+
+func (c *pipelinedConn) closed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *pipelinedConn) close(err error) {
+	c.access.Lock()
+	defer c.access.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	close(c.done)
+	c.conn.Close()
+}
+
+func (c *pipelinedConn) readResponses() {
+	for {
+		resp, err := readTCPMessage(c.conn)
+		if err != nil {
+			c.close(err)
+			return
+		}
+		if len(resp) < 2 {
+			continue
+		}
+		c.conn.SetReadDeadline(time.Now().Add(tlsIdleTimeout))
+		id := binary.BigEndian.Uint16(resp)
+		c.access.Lock()
+		if ch, found := c.pending[id]; found {
+			delete(c.pending, id)
+			ch <- resp
+		}
+		c.access.Unlock()
+	}
+}
+
+func (c *pipelinedConn) exchange(ctx context.Context, b []byte) ([]byte, error) {
+	if len(b) < 2 {
+		return nil, newError("invalid DNS message")
+	}
+	id := binary.BigEndian.Uint16(b)
+	ch := make(chan []byte, 1)
+
+	c.access.Lock()
+	if c.err != nil {
+		c.access.Unlock()
+		return nil, errConnectionBroken
+	}
+	if _, found := c.pending[id]; found {
+		c.access.Unlock()
+		return nil, newError("duplicated query ID ", id)
+	}
+	c.pending[id] = ch
+	c.access.Unlock()
+	defer func() {
+		c.access.Lock()
+		delete(c.pending, id)
+		c.access.Unlock()
+	}()
+
+	c.writeAccess.Lock()
+	c.conn.SetReadDeadline(time.Now().Add(tlsIdleTimeout))
+	err := writeTCPMessage(c.conn, b)
+	c.writeAccess.Unlock()
+	if err != nil {
+		c.close(err)
+		return nil, errConnectionBroken
+	}
+
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-c.done:
+		select {
+		case resp := <-ch:
+			return resp, nil
+		default:
+			return nil, newError("connection closed before response").Base(c.err)
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,143 @@
+package dns_test
+
+import (
+	"context"
+	gotls "crypto/tls"
+	"encoding/binary"
+	"io"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	. "github.com/v2fly/v2ray-core/v5/app/dns"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/protocol/tls/cert"
+	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tls"
+)
+
+// startTLSDNSServer starts a DNS over TLS server answering every A query with 1.2.3.4. It returns the address of
+// the server and the number of connections accepted so far.
+func startTLSDNSServer(t *testing.T, certificate *cert.Certificate) (string, *int32) {
+	serverCert, err := gotls.X509KeyPair(certificate.ToPEM())
+	common.Must(err)
+	listener, err := gotls.Listen("tcp", "127.0.0.1:0", &gotls.Config{
+		Certificates: []gotls.Certificate{serverCert},
+		NextProtos:   []string{"dot"},
+	})
+	common.Must(err)
+	t.Cleanup(func() { listener.Close() })
+
+	var accepted int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&accepted, 1)
+			go serveTLSDNS(conn)
+		}
+	}()
+	return listener.Addr().String(), &accepted
+}
+
+func serveTLSDNS(conn net.Conn) {
+	defer conn.Close()
+	var writeAccess sync.Mutex
+	for {
+		var length uint16
+		if err := binary.Read(conn, binary.BigEndian, &length); err != nil {
+			return
+		}
+		query := make([]byte, length)
+		if _, err := io.ReadFull(conn, query); err != nil {
+			return
+		}
+		// Answer concurrently, so that responses of pipelined queries may arrive out of order.
+		go func() {
+			resp := answerDNSQuery(query)
+			if resp == nil {
+				return
+			}
+			writeAccess.Lock()
+			defer writeAccess.Unlock()
+			binary.Write(conn, binary.BigEndian, uint16(len(resp)))
+			conn.Write(resp)
+		}()
+	}
+}
+
+// answerDNSQuery answers an A query with 1.2.3.4, and other queries with no records.
+func answerDNSQuery(query []byte) []byte {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(query); err != nil || len(msg.Questions) == 0 {
+		return nil
+	}
+	msg.Header.Response = true
+	if q := msg.Questions[0]; q.Type == dnsmessage.TypeA {
+		msg.Answers = append(msg.Answers, dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: 300},
+			Body:   &dnsmessage.AResource{A: [4]byte{1, 2, 3, 4}},
+		})
+	}
+	msg.Additionals = nil
+	resp, err := msg.Pack()
+	if err != nil {
+		return nil
+	}
+	return resp
+}
+
+func TestTLSLocalNameServer(t *testing.T) {
+	certificate := cert.MustGenerate(nil, cert.DNSNames("dns.example.com"))
+	addr, accepted := startTLSDNSServer(t, certificate)
+
+	u, err := url.Parse("tls+local://" + addr + "?sni=dns.example.com")
+	common.Must(err)
+	s, err := NewTLSLocalNameServer(u, &tls.Config{
+		AllowInsecure:                    true,
+		PinnedPeerCertificateChainSha256: [][]byte{tls.GenerateCertChainHash([][]byte{certificate.Certificate})},
+	})
+	common.Must(err)
+
+	for _, disableCache := range []bool{false, true} {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		ips, err := s.QueryIP(ctx, "example.com", net.IP(nil), dns_feature.IPOption{
+			IPv4Enable: true,
+			IPv6Enable: true,
+		}, disableCache)
+		cancel()
+		common.Must(err)
+		if len(ips) != 1 || ips[0].String() != "1.2.3.4" {
+			t.Error("unexpected answer: ", ips)
+		}
+	}
+	if n := atomic.LoadInt32(accepted); n != 1 {
+		t.Error("expect queries to share a connection, got ", n, " connections")
+	}
+}
+
+func TestTLSLocalNameServerPinningMismatch(t *testing.T) {
+	certificate := cert.MustGenerate(nil, cert.DNSNames("dns.example.com"))
+	addr, _ := startTLSDNSServer(t, certificate)
+
+	u, err := url.Parse("tls+local://" + addr)
+	common.Must(err)
+	s, err := NewTLSLocalNameServer(u, &tls.Config{
+		AllowInsecure:                    true,
+		PinnedPeerCertificateChainSha256: [][]byte{make([]byte, 32)},
+	})
+	common.Must(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := s.QueryIP(ctx, "example.com", net.IP(nil), dns_feature.IPOption{IPv4Enable: true}, true); err == nil {
+		t.Error("expect failure with mismatched certificate")
+	}
+}
@@ -14,11 +14,11 @@ import (
 
 	core "github.com/v2fly/v2ray-core/v5"
 	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/buf"
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/common/protocol/dns"
 	udp_proto "github.com/v2fly/v2ray-core/v5/common/protocol/udp"
 	"github.com/v2fly/v2ray-core/v5/common/session"
-	"github.com/v2fly/v2ray-core/v5/common/signal/pubsub"
 	"github.com/v2fly/v2ray-core/v5/common/task"
 	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
 	"github.com/v2fly/v2ray-core/v5/features/routing"
@@ -30,12 +30,12 @@ type ClassicNameServer struct {
 	sync.RWMutex
 	name      string
 	address   net.Destination
-	ips       map[string]record
+	cache     *ipCache
 	requests  map[uint16]dnsRequest
-	pub       *pubsub.Service
 	udpServer udp.DispatcherI
 	cleanup   *task.Periodic
 	reqID     uint32
+	validator *dnssecValidator
 }
 
 // NewClassicNameServer creates udp server object for remote resolving.
@@ -47,14 +47,13 @@ func NewClassicNameServer(address net.Destination, dispatcher routing.Dispatcher
 
 	s := &ClassicNameServer{
 		address:  address,
-		ips:      make(map[string]record),
 		requests: make(map[uint16]dnsRequest),
-		pub:      pubsub.NewService(),
 		name:     strings.ToUpper(address.String()),
 	}
+	s.cache = newIPCache(s.name)
 	s.cleanup = &task.Periodic{
 		Interval: time.Minute,
-		Execute:  s.Cleanup,
+		Execute:  s.cleanupRequests,
 	}
 	s.udpServer = udp.NewSplitDispatcher(dispatcher, s.HandleResponse)
 	newError("DNS: created UDP client initialized for ", address.NetAddr()).AtInfo().WriteToLog()
@@ -66,33 +65,27 @@ func (s *ClassicNameServer) Name() string {
 	return s.name
 }
 
+func (s *ClassicNameServer) ipCache() *ipCache {
+	return s.cache
+}
+
 // Cleanup clears expired items from cache
 func (s *ClassicNameServer) Cleanup() error {
-	now := time.Now()
-	s.Lock()
-	defer s.Unlock()
-
-	if len(s.ips) == 0 && len(s.requests) == 0 {
+	cacheErr := s.cache.Cleanup()
+	if err := s.cleanupRequests(); err != nil && cacheErr != nil {
 		return newError(s.name, " nothing to do. stopping...")
 	}
+	return nil
+}
 
-	for domain, record := range s.ips {
-		if record.A != nil && record.A.Expire.Before(now) {
-			record.A = nil
-		}
-		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
-			record.AAAA = nil
-		}
-
-		if record.A == nil && record.AAAA == nil {
-			delete(s.ips, domain)
-		} else {
-			s.ips[domain] = record
-		}
-	}
+// cleanupRequests clears expired pending requests.
+func (s *ClassicNameServer) cleanupRequests() error {
+	now := time.Now()
+	s.Lock()
+	defer s.Unlock()
 
-	if len(s.ips) == 0 {
-		s.ips = make(map[string]record)
+	if len(s.requests) == 0 {
+		return newError(s.name, " no pending request. stopping...")
 	}
 
 	for id, req := range s.requests {
@@ -128,49 +121,27 @@ func (s *ClassicNameServer) HandleResponse(ctx context.Context, packet *udp_prot
 		newError(s.name, " cannot find the pending request").AtError().WriteToLog()
 		return
 	}
-
-	var rec record
-	switch req.reqType {
-	case dnsmessage.TypeA:
-		rec.A = ipRec
-	case dnsmessage.TypeAAAA:
-		rec.AAAA = ipRec
+	if req.response != nil {
+		req.response <- append([]byte(nil), packet.Payload.Bytes()...)
+		return
 	}
 
 	elapsed := time.Since(req.start)
 	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
-	if len(req.domain) > 0 && (rec.A != nil || rec.AAAA != nil) {
-		s.updateIP(req.domain, rec)
-	}
-}
-
-func (s *ClassicNameServer) updateIP(domain string, newRec record) {
-	s.Lock()
-
-	newError(s.name, " updating IP records for domain:", domain).AtDebug().WriteToLog()
-	rec := s.ips[domain]
-
-	updated := false
-	if isNewer(rec.A, newRec.A) {
-		rec.A = newRec.A
-		updated = true
-	}
-	if isNewer(rec.AAAA, newRec.AAAA) {
-		rec.AAAA = newRec.AAAA
-		updated = true
-	}
-
-	if updated {
-		s.ips[domain] = rec
-	}
-	if newRec.A != nil {
-		s.pub.Publish(domain+"4", nil)
+	if len(req.domain) == 0 || (req.reqType != dnsmessage.TypeA && req.reqType != dnsmessage.TypeAAAA) {
+		return
 	}
-	if newRec.AAAA != nil {
-		s.pub.Publish(domain+"6", nil)
+	if s.validator == nil {
+		s.cache.Update(req.domain, req.reqType, ipRec)
+		return
 	}
-	s.Unlock()
-	common.Must(s.cleanup.Start())
+	// The validation queries are answered through this handler, so it must not wait for them.
+	resp := append([]byte(nil), packet.Payload.Bytes()...)
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+		defer cancel()
+		s.cache.Update(req.domain, req.reqType, s.validator.check(ctx, resp, ipRec))
+	}()
 }
 
 func (s *ClassicNameServer) newReqID() uint16 {
@@ -179,122 +150,65 @@ func (s *ClassicNameServer) newReqID() uint16 {
 
 func (s *ClassicNameServer) addPendingRequest(req *dnsRequest) {
 	s.Lock()
-	defer s.Unlock()
-
 	id := req.msg.ID
 	req.expire = time.Now().Add(time.Second * 8)
 	s.requests[id] = *req
+	s.Unlock()
+	common.Must(s.cleanup.Start())
 }
 
 func (s *ClassicNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
 	newError(s.name, " querying DNS for: ", domain).AtDebug().WriteToLog(session.ExportIDToError(ctx))
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP))
+	reqs := buildReqMsgs(domain, option, s.newReqID, s.validator.ednsOptions(clientIP))
 
 	for _, req := range reqs {
 		s.addPendingRequest(req)
 		b, _ := dns.PackMessage(req.msg)
-		udpCtx := core.ToBackgroundDetachedContext(ctx)
-		if inbound := session.InboundFromContext(ctx); inbound != nil {
-			udpCtx = session.ContextWithInbound(udpCtx, inbound)
-		}
-		udpCtx = session.ContextWithContent(udpCtx, &session.Content{
-			Protocol: "dns",
-		})
-		s.udpServer.Dispatch(udpCtx, s.address, b)
+		s.dispatch(ctx, b)
 	}
 }
 
-func (s *ClassicNameServer) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
-	s.RLock()
-	record, found := s.ips[domain]
-	s.RUnlock()
-
-	if !found {
-		return nil, errRecordNotFound
+func (s *ClassicNameServer) dispatch(ctx context.Context, b *buf.Buffer) {
+	udpCtx := core.ToBackgroundDetachedContext(ctx)
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		udpCtx = session.ContextWithInbound(udpCtx, inbound)
 	}
+	udpCtx = session.ContextWithContent(udpCtx, &session.Content{
+		Protocol: "dns",
+	})
+	s.udpServer.Dispatch(udpCtx, s.address, b)
+}
 
-	var ips []net.Address
-	var lastErr error
-	if option.IPv4Enable {
-		a, err := record.A.getIPs()
-		if err != nil {
-			lastErr = err
-		}
-		ips = append(ips, a...)
-	}
+func (s *ClassicNameServer) setValidator(v *dnssecValidator) {
+	s.validator = v
+}
 
-	if option.IPv6Enable {
-		aaaa, err := record.AAAA.getIPs()
-		if err != nil {
-			lastErr = err
-		}
-		ips = append(ips, aaaa...)
+func (s *ClassicNameServer) exchangeMessage(ctx context.Context, b []byte) ([]byte, error) {
+	id := s.newReqID()
+	req := &dnsRequest{
+		start:    time.Now(),
+		msg:      &dnsmessage.Message{Header: dnsmessage.Header{ID: id}},
+		response: make(chan []byte, 1),
 	}
+	s.addPendingRequest(req)
 
-	if len(ips) > 0 {
-		return toNetIP(ips)
-	}
+	payload := buf.New()
+	payload.Write(withMessageID(b, id))
+	s.dispatch(ctx, payload)
 
-	if lastErr != nil {
-		return nil, lastErr
+	select {
+	case resp := <-req.response:
+		return resp, nil
+	case <-ctx.Done():
+		s.Lock()
+		delete(s.requests, id)
+		s.Unlock()
+		return nil, ctx.Err()
 	}
-
-	return nil, dns_feature.ErrEmptyResponse
 }
 
 // QueryIP implements Server.
 func (s *ClassicNameServer) QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption, disableCache bool) ([]net.IP, error) {
-	fqdn := Fqdn(domain)
-
-	if disableCache {
-		newError("DNS cache is disabled. Querying IP for ", domain, " at ", s.name).AtDebug().WriteToLog()
-	} else {
-		ips, err := s.findIPsForDomain(fqdn, option)
-		if err != errRecordNotFound {
-			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
-			return ips, err
-		}
-	}
-
-	// ipv4 and ipv6 belong to different subscription groups
-	var sub4, sub6 *pubsub.Subscriber
-	if option.IPv4Enable {
-		sub4 = s.pub.Subscribe(fqdn + "4")
-		defer sub4.Close()
-	}
-	if option.IPv6Enable {
-		sub6 = s.pub.Subscribe(fqdn + "6")
-		defer sub6.Close()
-	}
-	done := make(chan interface{})
-	go func() {
-		if sub4 != nil {
-			select {
-			case <-sub4.Wait():
-			case <-ctx.Done():
-			}
-		}
-		if sub6 != nil {
-			select {
-			case <-sub6.Wait():
-			case <-ctx.Done():
-			}
-		}
-		close(done)
-	}()
-	s.sendQuery(ctx, fqdn, clientIP, option)
-
-	for {
-		ips, err := s.findIPsForDomain(fqdn, option)
-		if err != errRecordNotFound {
-			return ips, err
-		}
-
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-done:
-		}
-	}
+	return s.cache.QueryIP(ctx, domain, clientIP, option, disableCache, s.sendQuery)
 }
@@ -0,0 +1,129 @@
+//go:build !confonly
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	core "github.com/v2fly/v2ray-core/v5"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/buf"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/features/routing"
+	"github.com/v2fly/v2ray-core/v5/transport"
+)
+
+// dispatchedPacketConn is a net.PacketConn carrying its packets to a single destination through the dispatcher, so
+// that QUIC based name servers follow the routing like the classic UDP name server.
+type dispatchedPacketConn struct {
+	link   *transport.Link
+	local  net.Addr
+	remote net.Addr
+
+	access sync.Mutex
+	cache  buf.MultiBuffer
+}
+
+// newDispatchedPacketConn dispatches a UDP link to dest. The link outlives ctx, and is released on Close.
+func newDispatchedPacketConn(ctx context.Context, dispatcher routing.Dispatcher, dest net.Destination) (*dispatchedPacketConn, error) {
+	dest.Network = net.Network_UDP
+	link, err := dispatcher.Dispatch(core.ToBackgroundDetachedContext(ctx), dest)
+	if err != nil {
+		return nil, err
+	}
+	remote := &net.UDPAddr{Port: int(dest.Port)}
+	if dest.Address.Family().IsIP() {
+		remote.IP = dest.Address.IP()
+	}
+	return &dispatchedPacketConn{
+		link:   link,
+		local:  dispatchedAddr(atomic.AddUint32(&lastDispatchedAddr, 1)),
+		remote: remote,
+	}, nil
+}
+
+var lastDispatchedAddr uint32
+
+// dispatchedAddr is the local address of a dispatchedPacketConn. Each connection has a distinct one, as QUIC
+// multiplexes connections by their local addresses.
+type dispatchedAddr uint32
+
+func (a dispatchedAddr) Network() string {
+	return "dispatched"
+}
+
+func (a dispatchedAddr) String() string {
+	return "dispatched#" + strconv.FormatUint(uint64(a), 10)
+}
+
+// RemoteAddr returns the address of the destination.
+func (c *dispatchedPacketConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+// ReadFrom implements net.PacketConn.
+func (c *dispatchedPacketConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	for c.cache.IsEmpty() {
+		mb, err := c.link.Reader.ReadMultiBuffer()
+		if err != nil {
+			return 0, nil, err
+		}
+		c.cache = mb
+	}
+	// Each buffer holds a packet.
+	b := c.cache[0]
+	c.cache[0] = nil
+	c.cache = c.cache[1:]
+	n := copy(p, b.Bytes())
+	b.Release()
+	return n, c.remote, nil
+}
+
+// WriteTo implements net.PacketConn. The packet is sent to the destination regardless of addr.
+func (c *dispatchedPacketConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	if len(p) > buf.Size {
+		return 0, io.ErrShortBuffer
+	}
+	b := buf.New()
+	b.Write(p)
+	if err := c.link.Writer.WriteMultiBuffer(buf.MultiBuffer{b}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close implements net.PacketConn.
+func (c *dispatchedPacketConn) Close() error {
+	common.Interrupt(c.link.Reader)
+	common.Close(c.link.Writer)
+	return nil
+}
+
+// LocalAddr implements net.PacketConn.
+func (c *dispatchedPacketConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+// SetDeadline implements net.PacketConn.
+func (c *dispatchedPacketConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+// SetReadDeadline implements net.PacketConn.
+func (c *dispatchedPacketConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+// SetWriteDeadline implements net.PacketConn.
+func (c *dispatchedPacketConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
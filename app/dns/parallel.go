@@ -0,0 +1,182 @@
+//go:build !confonly
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/v2fly/v2ray-core/v5/common/errors"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/features/dns"
+	"github.com/v2fly/v2ray-core/v5/features/stats"
+)
+
+const (
+	defaultParallelConcurrency = 2
+
+	// demotionFailures is the number of failures in a row for a name server to be demoted.
+	demotionFailures = 3
+	minDemotion      = time.Second * 30
+	maxDemotion      = time.Minute * 10
+)
+
+// serverHealth tracks the failures in a row of a name server, which is demoted once they add up. The demotion lasts
+// longer each time the name server fails again.
+type serverHealth struct {
+	access       sync.Mutex
+	failures     uint32
+	demotedUntil time.Time
+}
+
+// record counts a query to the name server, and returns how long it is demoted for, if the query fails.
+func (h *serverHealth) record(failed bool) time.Duration {
+	h.access.Lock()
+	defer h.access.Unlock()
+
+	if !failed {
+		h.failures = 0
+		h.demotedUntil = time.Time{}
+		return 0
+	}
+	h.failures++
+	if h.failures < demotionFailures {
+		return 0
+	}
+	demotion := maxDemotion
+	if shift := h.failures - demotionFailures; shift < 8 {
+		if d := minDemotion << shift; d < maxDemotion {
+			demotion = d
+		}
+	}
+	h.demotedUntil = time.Now().Add(demotion)
+	return demotion
+}
+
+func (h *serverHealth) demoted() bool {
+	h.access.Lock()
+	defer h.access.Unlock()
+	return time.Now().Before(h.demotedUntil)
+}
+
+// serverCounters are the stat counters of a name server.
+type serverCounters struct {
+	query   stats.Counter
+	error   stats.Counter
+	latency stats.Counter
+}
+
+func newServerCounters(manager stats.Manager, name string) *serverCounters {
+	prefix := "dns>>>" + name
+	counters := &serverCounters{}
+	counters.query, _ = stats.GetOrRegisterCounter(manager, prefix+">>>query")
+	counters.error, _ = stats.GetOrRegisterCounter(manager, prefix+">>>error")
+	counters.latency, _ = stats.GetOrRegisterCounter(manager, prefix+">>>latency")
+	return counters
+}
+
+func (c *serverCounters) record(failed bool, elapsed time.Duration) {
+	if c.query != nil {
+		c.query.Add(1)
+	}
+	if failed {
+		if c.error != nil {
+			c.error.Add(1)
+		}
+		return
+	}
+	if c.latency != nil {
+		c.latency.Set(elapsed.Milliseconds())
+	}
+}
+
+// isServerError tells whether err is a failure of the name server, rather than an answer of the domain or an
+// abandoned query.
+func isServerError(err error) bool {
+	switch err {
+	case nil, context.Canceled, errExpectedIPNonMatch, dns.ErrEmptyResponse, dns.RCodeError(dnsmessage.RCodeNameError):
+		return false
+	}
+	return true
+}
+
+// isFinalError tells whether err answers the domain, so that no other name server is to be queried.
+func isFinalError(err error) bool {
+	return err != nil && err != context.Canceled && err != context.DeadlineExceeded && err != errExpectedIPNonMatch
+}
+
+// sortByHealth moves the demoted clients after the others, keeping their order otherwise.
+func sortByHealth(clients []*Client) []*Client {
+	sorted := make([]*Client, 0, len(clients))
+	var demoted []*Client
+	for _, client := range clients {
+		if client.health.demoted() {
+			demoted = append(demoted, client)
+			continue
+		}
+		sorted = append(sorted, client)
+	}
+	return append(sorted, demoted...)
+}
+
+type parallelResult struct {
+	client *Client
+	ips    []net.IP
+	err    error
+}
+
+// queryParallel races the clients by groups of concurrency, and returns the first valid answer. The next group is
+// only queried when every client of a group fails or times out.
+func (s *DNS) queryParallel(ctx context.Context, domain string, clients []*Client, option dns.IPOption) ([]net.IP, error) {
+	clients = sortByHealth(clients)
+	concurrency := s.parallelConcurrency
+	if concurrency == 0 {
+		concurrency = defaultParallelConcurrency
+	}
+
+	errs := []error{}
+	for len(clients) > 0 {
+		n := int(concurrency)
+		if n > len(clients) {
+			n = len(clients)
+		}
+		group := clients[:n]
+		clients = clients[n:]
+
+		groupCtx, cancel := context.WithCancel(ctx)
+		results := make(chan parallelResult, len(group))
+		for _, client := range group {
+			go func(client *Client) {
+				ips, err := client.QueryIP(groupCtx, domain, option, s.disableCache)
+				results <- parallelResult{client: client, ips: ips, err: err}
+			}(client)
+		}
+
+		var finalErr error
+		for range group {
+			result := <-results
+			if len(result.ips) > 0 {
+				cancel()
+				newError("domain ", domain, " answered first by server ", result.client.Name()).AtDebug().WriteToLog()
+				return result.ips, nil
+			}
+			if result.err != nil {
+				newError("failed to lookup ip for domain ", domain, " at server ", result.client.Name()).Base(result.err).WriteToLog()
+				errs = append(errs, result.err)
+			}
+			if finalErr == nil && isFinalError(result.err) {
+				finalErr = result.err
+			}
+		}
+		cancel()
+		if finalErr != nil {
+			return nil, finalErr
+		}
+	}
+
+	return nil, newError("returning nil for domain ", domain).Base(errors.Combine(errs...))
+}
@@ -0,0 +1,57 @@
+package dns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
+)
+
+func TestServerHealthDemotion(t *testing.T) {
+	var h serverHealth
+	for i := 1; i < demotionFailures; i++ {
+		if demotion := h.record(true); demotion != 0 || h.demoted() {
+			t.Fatal("unexpected demotion after ", i, " failures")
+		}
+	}
+	if demotion := h.record(true); demotion != minDemotion || !h.demoted() {
+		t.Error("expect demotion for ", minDemotion, ", got ", demotion)
+	}
+	if demotion := h.record(true); demotion != minDemotion*2 {
+		t.Error("expect longer demotion, got ", demotion)
+	}
+	for i := 0; i < 16; i++ {
+		h.record(true)
+	}
+	if demotion := h.record(true); demotion != maxDemotion {
+		t.Error("expect demotion capped at ", maxDemotion, ", got ", demotion)
+	}
+	if demotion := h.record(false); demotion != 0 || h.demoted() {
+		t.Error("expect demotion lifted on success")
+	}
+}
+
+func TestSortByHealth(t *testing.T) {
+	clients := []*Client{{}, {}, {}}
+	clients[0].health.demotedUntil = time.Now().Add(time.Minute)
+	sorted := sortByHealth(clients)
+	if sorted[0] != clients[1] || sorted[1] != clients[2] || sorted[2] != clients[0] {
+		t.Error("expect the demoted client last")
+	}
+}
+
+func TestIsServerError(t *testing.T) {
+	for _, err := range []error{nil, context.Canceled, errExpectedIPNonMatch, dns_feature.ErrEmptyResponse, dns_feature.RCodeError(dnsmessage.RCodeNameError)} {
+		if isServerError(err) {
+			t.Error("unexpected server error: ", err)
+		}
+	}
+	for _, err := range []error{context.DeadlineExceeded, dns_feature.RCodeError(dnsmessage.RCodeServerFailure)} {
+		if !isServerError(err) {
+			t.Error("expect server error: ", err)
+		}
+	}
+}
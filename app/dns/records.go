@@ -0,0 +1,490 @@
+//go:build !confonly
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/errors"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/session"
+	"github.com/v2fly/v2ray-core/v5/common/strmatcher"
+	"github.com/v2fly/v2ray-core/v5/common/task"
+	dns_feature "github.com/v2fly/v2ray-core/v5/features/dns"
+)
+
+const (
+	// defaultRecordTTL is the TTL of the records answered from the static hosts and IP lookups.
+	defaultRecordTTL = 600
+	// negativeRecordTTL is how long a negative answer without SOA record is cached.
+	negativeRecordTTL = time.Minute
+	// maxCNAMEDepth is the maximum number of CNAME records followed in the static hosts.
+	maxCNAMEDepth = 5
+)
+
+var errRecordQueryNotSupported = errors.New("record queries not supported")
+
+// exchangingServer is a name server exchanging DNS messages of any type.
+type exchangingServer interface {
+	Server
+	// exchangeMessage sends a packed DNS message to the name server and returns the packed response.
+	exchangeMessage(ctx context.Context, b []byte) ([]byte, error)
+}
+
+// hostRecords are the records of a domain in the static hosts, with the root as owner name.
+type hostRecords []dns.RR
+
+// parseHostRecords parses the records of the static hosts, as type and data in presentation format.
+func parseHostRecords(records []string) (hostRecords, error) {
+	parsed := make(hostRecords, 0, len(records))
+	for _, record := range records {
+		rr, err := dns.NewRR(". IN " + record)
+		if err != nil {
+			return nil, newError("failed to parse record ", record).Base(err)
+		}
+		if rr == nil {
+			return nil, newError("empty record")
+		}
+		switch rr.Header().Rrtype {
+		case dns.TypeA, dns.TypeAAAA:
+			return nil, newError("A and AAAA records are to be set as IP addresses: ", record)
+		case dns.TypeCNAME:
+			return nil, newError("CNAME records are to be set as canonical names: ", record)
+		}
+		rr.Header().Ttl = defaultRecordTTL
+		parsed = append(parsed, rr)
+	}
+	return parsed, nil
+}
+
+// lookupRecords returns the static records of qType for the FQDN domain, following the CNAME records of the static
+// hosts. The canonical name is returned as target if its records are to be resolved otherwise, in which case answer
+// holds the CNAME records followed. found is false if the domain has no records of qType in the static hosts.
+func (h *StaticHosts) lookupRecords(domain string, qType uint16) (answer []dns.RR, target string, found bool) {
+	name := domain
+	for depth := 0; depth <= maxCNAMEDepth; depth++ {
+		var cname string
+		var records []dns.RR
+		for _, id := range h.matchers.Match(strings.TrimSuffix(name, ".")) {
+			if cname == "" {
+				cname = h.cnames[id]
+			}
+			for _, rr := range h.records[id] {
+				if rr.Header().Rrtype == qType {
+					rr = dns.Copy(rr)
+					rr.Header().Name = name
+					records = append(records, rr)
+				}
+			}
+		}
+
+		switch {
+		case cname != "":
+			answer = append(answer, &dns.CNAME{
+				Hdr:    dns.RR_Header{Name: name, Rrtype: dns.TypeCNAME, Class: dns.ClassINET, Ttl: defaultRecordTTL},
+				Target: dns.Fqdn(cname),
+			})
+			if qType == dns.TypeCNAME {
+				return answer, "", true
+			}
+			name = dns.Fqdn(cname)
+		case len(records) > 0:
+			return append(answer, records...), "", true
+		default:
+			return answer, name, depth > 0
+		}
+	}
+	newError("too many CNAME records in static hosts for domain ", domain).AtWarning().WriteToLog()
+	return answer, name, true
+}
+
+// stripRules matches the domains whose answers are stripped of AAAA or HTTPS records.
+type stripRules struct {
+	aaaa  strmatcher.LinearIndexMatcher
+	https strmatcher.LinearIndexMatcher
+}
+
+func newStripRules(rules []*StripRule) (*stripRules, error) {
+	r := new(stripRules)
+	for _, rule := range rules {
+		for _, domain := range rule.Domain {
+			matcher, err := toStrMatcher(domain.Type, domain.Domain)
+			if err != nil {
+				return nil, newError("failed to create strip rule").Base(err)
+			}
+			if rule.Aaaa {
+				r.aaaa.Add(matcher)
+			}
+			if rule.Https {
+				r.https.Add(matcher)
+			}
+		}
+	}
+	return r, nil
+}
+
+func (r *stripRules) stripAAAA(domain string) bool {
+	return r.aaaa.MatchAny(domain)
+}
+
+func (r *stripRules) stripHTTPS(domain string) bool {
+	return r.https.MatchAny(domain)
+}
+
+// stripIPv6Hints removes the IPv6 hints of the HTTPS and SVCB records in answer.
+func stripIPv6Hints(answer []dns.RR) []dns.RR {
+	stripped := make([]dns.RR, 0, len(answer))
+	for _, rr := range answer {
+		var svcb *dns.SVCB
+		switch rr := rr.(type) {
+		case *dns.HTTPS:
+			svcb = &rr.SVCB
+		case *dns.SVCB:
+			svcb = rr
+		}
+		if svcb != nil {
+			values := make([]dns.SVCBKeyValue, 0, len(svcb.Value))
+			for _, value := range svcb.Value {
+				if value.Key() != dns.SVCB_IPV6HINT {
+					values = append(values, value)
+				}
+			}
+			rr = dns.Copy(rr)
+			switch rr := rr.(type) {
+			case *dns.HTTPS:
+				rr.Value = values
+			case *dns.SVCB:
+				rr.Value = values
+			}
+		}
+		stripped = append(stripped, rr)
+	}
+	return stripped
+}
+
+type recordKey struct {
+	server string
+	domain string
+	qType  uint16
+}
+
+type recordEntry struct {
+	answer []dns.RR
+	rcode  int
+	expire time.Time
+}
+
+// recordCache caches the answers of each name server to queries of types other than A and AAAA, which name servers
+// cache themselves.
+type recordCache struct {
+	sync.Mutex
+	config  *CacheConfig
+	entries map[recordKey]*recordEntry
+	cleanup *task.Periodic
+}
+
+func newRecordCache(config *CacheConfig) *recordCache {
+	c := &recordCache{
+		config:  config,
+		entries: make(map[recordKey]*recordEntry),
+	}
+	c.cleanup = &task.Periodic{
+		Interval: time.Minute,
+		Execute:  c.Cleanup,
+	}
+	return c
+}
+
+// Cleanup clears expired answers from cache.
+func (c *recordCache) Cleanup() error {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(c.entries) == 0 {
+		return newError("record cache nothing to do. stopping...")
+	}
+
+	now := time.Now()
+	for key, entry := range c.entries {
+		if entry.expire.Before(now) {
+			delete(c.entries, key)
+		}
+	}
+
+	if len(c.entries) == 0 {
+		c.entries = make(map[recordKey]*recordEntry)
+	}
+
+	return nil
+}
+
+// answerTTL returns the TTL of the answer in resp, which is given by the SOA record for negative answers.
+func answerTTL(resp *dns.Msg) time.Duration {
+	if len(resp.Answer) == 0 {
+		for _, rr := range resp.Ns {
+			if soa, ok := rr.(*dns.SOA); ok {
+				ttl := soa.Hdr.Ttl
+				if soa.Minttl < ttl {
+					ttl = soa.Minttl
+				}
+				return time.Duration(ttl) * time.Second
+			}
+		}
+		return negativeRecordTTL
+	}
+	ttl := resp.Answer[0].Header().Ttl
+	for _, rr := range resp.Answer[1:] {
+		if rr.Header().Ttl < ttl {
+			ttl = rr.Header().Ttl
+		}
+	}
+	return time.Duration(ttl) * time.Second
+}
+
+// Update caches the answer of server in resp to the query of qType for the FQDN domain.
+func (c *recordCache) Update(server string, domain string, qType uint16, resp *dns.Msg) {
+	ttl := answerTTL(resp)
+	if minTTL := time.Duration(c.config.GetMinTtl()) * time.Second; minTTL > 0 && ttl < minTTL {
+		ttl = minTTL
+	}
+	if maxTTL := time.Duration(c.config.GetMaxTtl()) * time.Second; maxTTL > 0 && ttl > maxTTL {
+		ttl = maxTTL
+	}
+
+	c.Lock()
+	c.entries[recordKey{server: server, domain: dns.CanonicalName(domain), qType: qType}] = &recordEntry{
+		answer: resp.Answer,
+		rcode:  resp.Rcode,
+		expire: time.Now().Add(ttl),
+	}
+	c.Unlock()
+	common.Must(c.cleanup.Start())
+}
+
+// find returns the cached answer of server to the query of qType for the FQDN domain, with the remaining time as TTLs.
+func (c *recordCache) find(server string, domain string, qType uint16) ([]dns.RR, int, bool) {
+	c.Lock()
+	entry, found := c.entries[recordKey{server: server, domain: dns.CanonicalName(domain), qType: qType}]
+	c.Unlock()
+	if !found {
+		return nil, 0, false
+	}
+	remaining := time.Until(entry.expire)
+	if remaining <= 0 {
+		return nil, 0, false
+	}
+
+	ttl := uint32(remaining / time.Second)
+	answer := make([]dns.RR, 0, len(entry.answer))
+	for _, rr := range entry.answer {
+		rr = dns.Copy(rr)
+		rr.Header().Ttl = ttl
+		answer = append(answer, rr)
+	}
+	return answer, entry.rcode, true
+}
+
+// Flush removes the cached answers of domain, or all cached answers if domain is empty, and returns the number of
+// answers removed.
+func (c *recordCache) Flush(domain string) int {
+	c.Lock()
+	defer c.Unlock()
+
+	if domain == "" {
+		n := len(c.entries)
+		c.entries = make(map[recordKey]*recordEntry)
+		return n
+	}
+
+	n := 0
+	domain = dns.CanonicalName(domain)
+	for key := range c.entries {
+		if key.domain == domain {
+			delete(c.entries, key)
+			n++
+		}
+	}
+	return n
+}
+
+// newSubnetOption returns the EDNS0 client subnet option of clientIP, with the same prefix as genEDNS0Options.
+func newSubnetOption(clientIP net.IP) *dns.EDNS0_SUBNET {
+	if len(clientIP) == net.IPv4len {
+		return &dns.EDNS0_SUBNET{Code: dns.EDNS0SUBNET, Family: 1, SourceNetmask: 24, Address: clientIP}
+	}
+	return &dns.EDNS0_SUBNET{Code: dns.EDNS0SUBNET, Family: 2, SourceNetmask: 96, Address: clientIP}
+}
+
+// QueryRecord sends a query of qType for the FQDN domain to the name server with the client's IP. Answers of the
+// domain are returned as responses, negative ones included, and failures of the name server as errors.
+func (c *Client) QueryRecord(ctx context.Context, domain string, qType uint16) (*dns.Msg, error) {
+	server, ok := c.server.(exchangingServer)
+	if !ok {
+		return nil, errRecordQueryNotSupported
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, 4*time.Second)
+	resp, err := c.exchangeRecordQuery(ctx, server, domain, qType)
+	cancel()
+	c.recordResult(err, time.Since(start))
+	return resp, err
+}
+
+func (c *Client) exchangeRecordQuery(ctx context.Context, server exchangingServer, domain string, qType uint16) (*dns.Msg, error) {
+	req := new(dns.Msg)
+	req.SetQuestion(domain, qType)
+	opt := &dns.OPT{Hdr: dns.RR_Header{Name: ".", Rrtype: dns.TypeOPT}}
+	opt.SetUDPSize(1350)
+	if c.validator != nil {
+		opt.SetDo()
+	}
+	if len(c.clientIP) > 0 {
+		opt.Option = append(opt.Option, newSubnetOption(c.clientIP))
+	}
+	req.Extra = append(req.Extra, opt)
+
+	b, err := req.Pack()
+	if err != nil {
+		return nil, newError("failed to pack query").Base(err)
+	}
+	b, err = server.exchangeMessage(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validator.verify(ctx, b); err != nil {
+		return nil, newError("bogus answer").Base(err)
+	}
+
+	resp := new(dns.Msg)
+	if err := resp.Unpack(b); err != nil {
+		return nil, newError("failed to parse response").Base(err)
+	}
+	if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+		return nil, dns_feature.RCodeError(resp.Rcode)
+	}
+	return resp, nil
+}
+
+// LookupRecord implements dns.RecordLookup.
+func (s *DNS) LookupRecord(ctx context.Context, query []byte) ([]byte, error) {
+	req := new(dns.Msg)
+	if err := req.Unpack(query); err != nil {
+		return nil, newError("failed to parse query").Base(err)
+	}
+	if len(req.Question) != 1 {
+		return nil, newError("unexpected number of questions: ", len(req.Question))
+	}
+
+	q := req.Question[0]
+	answer, rcode, err := s.lookupRecord(dns.CanonicalName(q.Name), q.Qtype, sourceFromContext(ctx))
+	if err != nil {
+		newError("failed to lookup ", dns.TypeToString[q.Qtype], " records for domain ", q.Name).Base(err).WriteToLog(session.ExportIDToError(ctx))
+	}
+
+	resp := new(dns.Msg)
+	resp.SetRcode(req, rcode)
+	resp.RecursionAvailable = true
+	resp.Answer = answer
+	return resp.Pack()
+}
+
+// lookupRecord answers the query of qType for the FQDN domain from the static hosts and the name servers.
+func (s *DNS) lookupRecord(domain string, qType uint16, source net.IP) ([]dns.RR, int, error) {
+	stripAAAA := s.strip.stripAAAA(strings.TrimSuffix(domain, "."))
+	if qType == dns.TypeAAAA && stripAAAA || qType == dns.TypeHTTPS && s.strip.stripHTTPS(strings.TrimSuffix(domain, ".")) {
+		newError("stripped ", dns.TypeToString[qType], " records of domain ", domain).AtDebug().WriteToLog()
+		return nil, dns.RcodeSuccess, nil
+	}
+
+	answer, target, found := s.hosts.lookupRecords(domain, qType)
+	if found && target == "" {
+		newError("returning ", len(answer), " static records for domain ", domain).WriteToLog()
+		return answer, dns.RcodeSuccess, nil
+	}
+
+	var records []dns.RR
+	var rcode int
+	var err error
+	switch qType {
+	case dns.TypeA, dns.TypeAAAA:
+		records, rcode, err = s.lookupIPRecords(target, qType, source)
+	default:
+		records, rcode, err = s.queryRecord(target, qType, source)
+	}
+	if stripAAAA {
+		records = stripIPv6Hints(records)
+	}
+	return append(answer, records...), rcode, err
+}
+
+// lookupIPRecords resolves the A or AAAA records of the FQDN domain as IP lookups do, FakeDNS included.
+func (s *DNS) lookupIPRecords(domain string, qType uint16, source net.IP) ([]dns.RR, int, error) {
+	option := *s.ipOption
+	option.IPv4Enable = option.IPv4Enable && qType == dns.TypeA
+	option.IPv6Enable = option.IPv6Enable && qType == dns.TypeAAAA
+	if !option.IPv4Enable && !option.IPv6Enable {
+		return nil, dns.RcodeSuccess, nil
+	}
+
+	ips, err := s.lookupIPInternal(domain, option, source)
+	switch rcode := dns_feature.RCodeFromError(err); {
+	case err == nil:
+	case err == dns_feature.ErrEmptyResponse:
+		return nil, dns.RcodeSuccess, nil
+	case rcode != 0:
+		return nil, int(rcode), nil
+	default:
+		return nil, dns.RcodeServerFailure, err
+	}
+
+	answer := make([]dns.RR, 0, len(ips))
+	header := dns.RR_Header{Name: domain, Rrtype: qType, Class: dns.ClassINET, Ttl: defaultRecordTTL}
+	for _, ip := range ips {
+		if qType == dns.TypeA {
+			answer = append(answer, &dns.A{Hdr: header, A: ip})
+		} else {
+			answer = append(answer, &dns.AAAA{Hdr: header, AAAA: ip})
+		}
+	}
+	return answer, dns.RcodeSuccess, nil
+}
+
+// queryRecord resolves the records of qType for the FQDN domain with the name servers able to answer any type of
+// queries and serving the client of source IP, one by one.
+func (s *DNS) queryRecord(domain string, qType uint16, source net.IP) ([]dns.RR, int, error) {
+	ctx := session.ContextWithInbound(s.ctx, &session.Inbound{Tag: s.tag})
+	errs := []error{}
+	for _, client := range s.sortClients(strings.TrimSuffix(domain, "."), source) {
+		if !s.disableCache {
+			if answer, rcode, found := s.records.find(client.Name(), domain, qType); found {
+				newError(client.Name(), " cache hit for ", dns.TypeToString[qType], " records of domain ", domain).AtDebug().WriteToLog()
+				return answer, rcode, nil
+			}
+		}
+
+		resp, err := client.QueryRecord(ctx, domain, qType)
+		if err == errRecordQueryNotSupported {
+			newError("skip ", dns.TypeToString[qType], " query for domain ", domain, " at server ", client.Name()).AtDebug().WriteToLog()
+			continue
+		}
+		if err != nil {
+			newError("failed to lookup ", dns.TypeToString[qType], " records for domain ", domain, " at server ", client.Name()).Base(err).WriteToLog()
+			errs = append(errs, err)
+			continue
+		}
+		if !s.disableCache {
+			s.records.Update(client.Name(), domain, qType, resp)
+		}
+		return resp.Answer, resp.Rcode, nil
+	}
+
+	return nil, dns.RcodeServerFailure, newError("returning nil for domain ", domain).Base(errors.Combine(errs...))
+}
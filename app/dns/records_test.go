@@ -0,0 +1,69 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/miekg/dns"
+)
+
+func TestParseHostRecords(t *testing.T) {
+	records, err := parseHostRecords([]string{"MX 10 mx.example.com.", "TXT \"v=spf1 -all\""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Header().Rrtype != dns.TypeMX || records[0].Header().Ttl != defaultRecordTTL {
+		t.Error("unexpected records: ", records)
+	}
+	for _, record := range []string{"A 1.2.3.4", "CNAME example.com.", "MX mx.example.com."} {
+		if _, err := parseHostRecords([]string{record}); err == nil {
+			t.Error("expect error for record: ", record)
+		}
+	}
+}
+
+func TestStripIPv6Hints(t *testing.T) {
+	answer := stripIPv6Hints([]dns.RR{
+		newTestRR("example.com. 300 IN HTTPS 1 . alpn=h2 ipv4hint=1.2.3.4 ipv6hint=2001:db8::1"),
+		newTestRR("example.com. 300 IN HTTPS 2 . ipv6hint=2001:db8::2"),
+	})
+	var records []string
+	for _, rr := range answer {
+		records = append(records, rr.String())
+	}
+	if r := cmp.Diff(records, []string{
+		"example.com.\t300\tIN\tHTTPS\t1 . alpn=\"h2\" ipv4hint=\"1.2.3.4\"",
+		"example.com.\t300\tIN\tHTTPS\t2 .",
+	}); r != "" {
+		t.Error(r)
+	}
+}
+
+func TestRecordCache(t *testing.T) {
+	c := newRecordCache(&CacheConfig{MinTtl: 60})
+	defer c.cleanup.Close()
+
+	resp := new(dns.Msg)
+	resp.Answer = []dns.RR{newTestRR("example.com. 10 IN MX 10 mx.example.com.")}
+	c.Update("test", "Example.com", dns.TypeMX, resp)
+
+	answer, rcode, found := c.find("test", "example.com.", dns.TypeMX)
+	if !found || rcode != dns.RcodeSuccess || len(answer) != 1 {
+		t.Fatal("expect cached answer, got ", answer, rcode, found)
+	}
+	if ttl := answer[0].Header().Ttl; ttl > 60 || ttl < 59 {
+		t.Error("expect TTL raised to 60, got ", ttl)
+	}
+	if _, _, found := c.find("test", "example.com.", dns.TypeTXT); found {
+		t.Error("unexpected answer of TXT query")
+	}
+	if _, _, found := c.find("other", "example.com.", dns.TypeMX); found {
+		t.Error("unexpected answer of other server")
+	}
+	if n := c.Flush("example.com"); n != 1 {
+		t.Error("expect 1 answer flushed, got ", n)
+	}
+	if _, _, found := c.find("test", "example.com.", dns.TypeMX); found {
+		t.Error("unexpected answer after flush")
+	}
+}
@@ -0,0 +1,164 @@
+package observatory
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	v2net "github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tagged"
+)
+
+const (
+	defaultBandwidthSize     = 1024 * 1024
+	defaultBandwidthInterval = 10 * time.Minute
+	defaultBandwidthTimeout  = 30 * time.Second
+)
+
+// MeasureBandwidth downloads the URL of config through the outbound, and returns the throughput in bytes per second.
+// The time to connect and to receive the response header is not counted.
+func MeasureBandwidth(ctx context.Context, config *BandwidthProbeConfig, outbound string) (int64, error) {
+	size := config.Size
+	if size <= 0 {
+		size = defaultBandwidthSize
+	}
+	timeout := time.Duration(config.Timeout)
+	if timeout <= 0 {
+		timeout = defaultBandwidthTimeout
+	}
+	downloadURL := config.Url
+	if downloadURL == "" {
+		return 0, newError("URL of bandwidth probe is not specified")
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy: func(*http.Request) (*url.URL, error) {
+				return nil, nil
+			},
+			DialContext: func(_ context.Context, network, addr string) (net.Conn, error) {
+				dest, err := v2net.ParseDestination(network + ":" + addr)
+				if err != nil {
+					return nil, newError("cannot understand address").Base(err)
+				}
+				return tagged.Dialer(ctx, dest, outbound)
+			},
+			DisableKeepAlives:  true,
+			DisableCompression: true,
+		},
+		Timeout: timeout,
+	}
+	response, err := client.Get(downloadURL)
+	if err != nil {
+		return 0, newError("failed to download ", downloadURL).Base(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return 0, newError("unexpected status ", response.Status, " of ", downloadURL)
+	}
+
+	start := time.Now()
+	n, err := io.CopyN(io.Discard, response.Body, size)
+	elapsed := time.Since(start)
+	if err != nil && err != io.EOF {
+		return 0, newError("failed to download ", downloadURL, " after ", n, " bytes").Base(err)
+	}
+	if n == 0 {
+		return 0, newError("empty response of ", downloadURL)
+	}
+	if elapsed <= 0 {
+		elapsed = time.Nanosecond
+	}
+	return int64(float64(n) / elapsed.Seconds()), nil
+}
+
+type bandwidthResult struct {
+	bandwidth   int64
+	measureTime time.Time
+	lastTryTime time.Time
+}
+
+// BandwidthProber measures the throughput of outbounds, at most once in the configured interval for each of them, as
+// a measurement downloads a considerable amount of data.
+type BandwidthProber struct {
+	config   *BandwidthProbeConfig
+	interval time.Duration
+
+	access  sync.Mutex
+	results map[string]*bandwidthResult
+
+	now func() time.Time
+}
+
+// NewBandwidthProber creates a new BandwidthProber with config.
+func NewBandwidthProber(config *BandwidthProbeConfig) *BandwidthProber {
+	interval := time.Duration(config.Interval)
+	if interval <= 0 {
+		interval = defaultBandwidthInterval
+	}
+	return &BandwidthProber{
+		config:   config,
+		interval: interval,
+		results:  make(map[string]*bandwidthResult),
+		now:      time.Now,
+	}
+}
+
+// Probe measures the throughput of the outbound, unless it was tried within the interval. It returns whether a new
+// bandwidth is measured.
+func (p *BandwidthProber) Probe(ctx context.Context, outbound string) bool {
+	p.access.Lock()
+	result, found := p.results[outbound]
+	if !found {
+		result = &bandwidthResult{}
+		p.results[outbound] = result
+	}
+	if !result.lastTryTime.IsZero() && p.now().Sub(result.lastTryTime) < p.interval {
+		p.access.Unlock()
+		return false
+	}
+	result.lastTryTime = p.now()
+	p.access.Unlock()
+
+	bandwidth, err := MeasureBandwidth(ctx, p.config, outbound)
+
+	p.access.Lock()
+	defer p.access.Unlock()
+	if err != nil {
+		newError("failed to measure bandwidth of outbound ", outbound).Base(err).AtInfo().WriteToLog()
+		return false
+	}
+	newError("the outbound ", outbound, " has a bandwidth of ", bandwidth, " bytes per second").AtInfo().WriteToLog()
+	result.bandwidth = bandwidth
+	result.measureTime = p.now()
+	return true
+}
+
+// FillStatus sets the last measured bandwidth of the outbound in status.
+func (p *BandwidthProber) FillStatus(status *OutboundStatus) {
+	p.access.Lock()
+	defer p.access.Unlock()
+	if result, found := p.results[status.OutboundTag]; found && !result.measureTime.IsZero() {
+		status.Bandwidth = result.bandwidth
+		status.LastBandwidthTime = result.measureTime.Unix()
+	}
+}
+
+// Cleanup removes the results of outbounds not in tags.
+func (p *BandwidthProber) Cleanup(tags []string) {
+	p.access.Lock()
+	defer p.access.Unlock()
+	keep := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		keep[tag] = true
+	}
+	for tag := range p.results {
+		if !keep[tag] {
+			delete(p.results, tag)
+		}
+	}
+}
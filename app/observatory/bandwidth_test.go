@@ -0,0 +1,72 @@
+package observatory_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
+)
+
+func TestBandwidthProber(t *testing.T) {
+	payload := make([]byte, 256*1024)
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	config := &observatory.BandwidthProbeConfig{
+		Url:      server.URL,
+		Size:     128 * 1024,
+		Interval: int64(time.Hour),
+	}
+	bandwidth, err := observatory.MeasureBandwidth(context.Background(), config, "proxy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bandwidth <= 0 {
+		t.Error("expect positive bandwidth, got ", bandwidth)
+	}
+
+	prober := observatory.NewBandwidthProber(config)
+	if !prober.Probe(context.Background(), "proxy") {
+		t.Error("expect bandwidth to be measured")
+	}
+	if prober.Probe(context.Background(), "proxy") {
+		t.Error("expect bandwidth not to be measured again within the interval")
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Error("expect the second probe within the interval to be skipped, got ", n-1, " probes")
+	}
+	status := &observatory.OutboundStatus{OutboundTag: "proxy"}
+	prober.FillStatus(status)
+	if status.Bandwidth <= 0 || status.LastBandwidthTime == 0 {
+		t.Error("expect bandwidth to be filled, got ", status.String())
+	}
+
+	prober.Cleanup(nil)
+	status = &observatory.OutboundStatus{OutboundTag: "proxy"}
+	prober.FillStatus(status)
+	if status.Bandwidth != 0 {
+		t.Error("expect bandwidth to be removed, got ", status.Bandwidth)
+	}
+
+	config.Url = server.URL + "/missing"
+	if _, err := observatory.MeasureBandwidth(context.Background(), config, "proxy"); err == nil {
+		t.Error("expect failure on not found")
+	}
+
+	config.Url = ""
+	if _, err := observatory.MeasureBandwidth(context.Background(), config, "proxy"); err == nil {
+		t.Error("expect failure without URL")
+	}
+}
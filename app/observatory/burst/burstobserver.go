@@ -9,8 +9,12 @@ import (
 	core "github.com/v2fly/v2ray-core/v5"
 	"github.com/v2fly/v2ray-core/v5/app/observatory"
 	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/environment"
+	"github.com/v2fly/v2ray-core/v5/common/environment/envctx"
 	"github.com/v2fly/v2ray-core/v5/common/signal/done"
+	"github.com/v2fly/v2ray-core/v5/common/signal/pubsub"
 	"github.com/v2fly/v2ray-core/v5/features/extension"
+	"github.com/v2fly/v2ray-core/v5/features/extension/storage"
 	"github.com/v2fly/v2ray-core/v5/features/outbound"
 )
 
@@ -24,8 +28,16 @@ type Observer struct {
 	finished *done.Instance
 
 	ohm outbound.Manager
+
+	storage storage.ScopedPersistentStorage
+	pub     *pubsub.Service
 }
 
+const (
+	persistentStorageKey = "healthPing"
+	statusTopic          = "status"
+)
+
 func (o *Observer) GetObservation(ctx context.Context) (proto.Message, error) {
 	return &observatory.ObservationResult{Status: o.createResult()}, nil
 }
@@ -35,27 +47,63 @@ func (o *Observer) createResult() []*observatory.OutboundStatus {
 	o.hp.access.Lock()
 	defer o.hp.access.Unlock()
 	for name, value := range o.hp.Results {
-		status := observatory.OutboundStatus{
-			Alive:           value.getStatistics().All != value.getStatistics().Fail,
-			Delay:           value.getStatistics().Average.Milliseconds(),
-			LastErrorReason: "",
-			OutboundTag:     name,
-			LastSeenTime:    0,
-			LastTryTime:     0,
-			HealthPing: &observatory.HealthPingMeasurementResult{
-				All:       int64(value.getStatistics().All),
-				Fail:      int64(value.getStatistics().Fail),
-				Deviation: int64(value.getStatistics().Deviation),
-				Average:   int64(value.getStatistics().Average),
-				Max:       int64(value.getStatistics().Max),
-				Min:       int64(value.getStatistics().Min),
-			},
-		}
-		result = append(result, &status)
+		result = append(result, o.createStatusLockHolderOnly(name, value))
 	}
 	return result
 }
 
+func (o *Observer) createStatusLockHolderOnly(name string, value *HealthPingRTTS) *observatory.OutboundStatus {
+	stats := value.getStatistics()
+	status := &observatory.OutboundStatus{
+		Alive:           stats.All != stats.Fail,
+		Delay:           stats.Average.Milliseconds(),
+		LastErrorReason: "",
+		OutboundTag:     name,
+		Stale:           o.hp.stale[name],
+		LastSeenTime:    0,
+		LastTryTime:     0,
+		HealthPing: &observatory.HealthPingMeasurementResult{
+			All:       int64(stats.All),
+			Fail:      int64(stats.Fail),
+			Deviation: int64(stats.Deviation),
+			Average:   int64(stats.Average),
+			Max:       int64(stats.Max),
+			Min:       int64(stats.Min),
+		},
+	}
+	if probe := o.hp.Settings.Probe; probe != nil {
+		status.ProbeResult = []*observatory.ProbeKindResult{{
+			Kind:        probe.Kind,
+			Destination: probe.Destination,
+			Alive:       status.Alive,
+			Delay:       status.Delay,
+		}}
+	}
+	if o.hp.bandwidth != nil {
+		o.hp.bandwidth.FillStatus(status)
+	}
+	return status
+}
+
+// publishStatus publishes the status of the outbound to subscribers.
+func (o *Observer) publishStatus(tag string) {
+	o.hp.access.Lock()
+	value, found := o.hp.Results[tag]
+	var status *observatory.OutboundStatus
+	if found {
+		status = o.createStatusLockHolderOnly(tag, value)
+	}
+	o.hp.access.Unlock()
+	if status != nil {
+		o.pub.Publish(statusTopic, status)
+	}
+}
+
+// SubscribeObservation implements extension.ObservationSubscribable.
+func (o *Observer) SubscribeObservation() *pubsub.Subscriber {
+	return o.pub.Subscribe(statusTopic)
+}
+
 func (o *Observer) Type() interface{} {
 	return extension.ObservatoryType()
 }
@@ -63,6 +111,8 @@ func (o *Observer) Type() interface{} {
 func (o *Observer) Start() error {
 	if o.config != nil && len(o.config.SubjectSelector) != 0 {
 		o.finished = done.New()
+		o.restore()
+		o.hp.checkDone = o.persist
 		o.hp.StartScheduler(func() ([]string, error) {
 			hs, ok := o.ohm.(outbound.HandlerSelector)
 			if !ok {
@@ -79,11 +129,46 @@ func (o *Observer) Start() error {
 func (o *Observer) Close() error {
 	if o.finished != nil {
 		o.hp.StopScheduler()
+		o.persist()
 		return o.finished.Close()
 	}
 	return nil
 }
 
+// restore loads the ping history saved by the last run from the persistent storage.
+func (o *Observer) restore() {
+	if o.storage == nil {
+		return
+	}
+	value, err := o.storage.Get(o.ctx, []byte(persistentStorageKey))
+	if err != nil {
+		newError("no saved ping history restored").Base(err).AtDebug().WriteToLog()
+		return
+	}
+	snapshot := &HealthPingSnapshot{}
+	if err := proto.Unmarshal(value, snapshot); err != nil {
+		newError("failed to restore saved ping history").Base(err).AtWarning().WriteToLog()
+		return
+	}
+	o.hp.Restore(snapshot)
+	newError("restored ping history of ", len(snapshot.History), " outbounds").AtInfo().WriteToLog()
+}
+
+// persist saves the ping history to the persistent storage.
+func (o *Observer) persist() {
+	if o.storage == nil {
+		return
+	}
+	value, err := proto.Marshal(o.hp.Snapshot())
+	if err != nil {
+		newError("failed to encode ping history").Base(err).AtWarning().WriteToLog()
+		return
+	}
+	if err := o.storage.Put(o.ctx, []byte(persistentStorageKey), value); err != nil {
+		newError("failed to save ping history").Base(err).AtDebug().WriteToLog()
+	}
+}
+
 func New(ctx context.Context, config *Config) (*Observer, error) {
 	var outboundManager outbound.Manager
 	err := core.RequireFeatures(ctx, func(om outbound.Manager) {
@@ -93,12 +178,18 @@ func New(ctx context.Context, config *Config) (*Observer, error) {
 		return nil, newError("Cannot get depended features").Base(err)
 	}
 	hp := NewHealthPing(ctx, config.PingConfig)
-	return &Observer{
+	observer := &Observer{
 		config: config,
 		ctx:    ctx,
 		ohm:    outboundManager,
 		hp:     hp,
-	}, nil
+		pub:    pubsub.NewService(),
+	}
+	hp.resultPut = observer.publishStatus
+	if env, ok := envctx.EnvironmentFromContext(ctx).(environment.AppEnvironment); ok {
+		observer.storage = env.PersistentStorage()
+	}
+	return observer, nil
 }
 
 func init() {
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
 	"github.com/v2fly/v2ray-core/v5/common/dice"
 )
 
@@ -17,6 +18,9 @@ type HealthPingSettings struct {
 	Interval      time.Duration `json:"interval"`
 	SamplingCount int           `json:"sampling"`
 	Timeout       time.Duration `json:"timeout"`
+
+	Probe          *observatory.ProbeConfig          `json:"probe"`
+	BandwidthProbe *observatory.BandwidthProbeConfig `json:"bandwidthProbe"`
 }
 
 // HealthPing is the health checker for balancers
@@ -28,6 +32,14 @@ type HealthPing struct {
 
 	Settings *HealthPingSettings
 	Results  map[string]*HealthPingRTTS
+
+	bandwidth *observatory.BandwidthProber
+	// stale holds the outbounds with results restored from a snapshot, and not pinged since
+	stale map[string]bool
+	// checkDone is called after each scheduled check
+	checkDone func()
+	// resultPut is called after a ping result of an outbound is put
+	resultPut func(tag string)
 }
 
 // NewHealthPing creates a new HealthPing with settings
@@ -40,6 +52,9 @@ func NewHealthPing(ctx context.Context, config *HealthPingConfig) *HealthPing {
 			Interval:      time.Duration(config.Interval),
 			SamplingCount: int(config.SamplingCount),
 			Timeout:       time.Duration(config.Timeout),
+			Probe:         config.Probe,
+
+			BandwidthProbe: config.BandwidthProbe,
 		}
 	}
 	if settings.Destination == "" {
@@ -62,11 +77,15 @@ func NewHealthPing(ctx context.Context, config *HealthPingConfig) *HealthPing {
 		// a larger timeout could possibly makes checks run longer
 		settings.Timeout = time.Duration(5) * time.Second
 	}
-	return &HealthPing{
+	h := &HealthPing{
 		ctx:      ctx,
 		Settings: settings,
 		Results:  nil,
 	}
+	if settings.BandwidthProbe != nil {
+		h.bandwidth = observatory.NewBandwidthProber(settings.BandwidthProbe)
+	}
+	return h
 }
 
 // StartScheduler implements the HealthChecker
@@ -89,6 +108,10 @@ func (h *HealthPing) StartScheduler(selector func() ([]string, error)) {
 				}
 				h.doCheck(tags, interval, h.Settings.SamplingCount)
 				h.Cleanup(tags)
+				h.probeBandwidth(tags)
+				if h.checkDone != nil {
+					h.checkDone()
+				}
 			}()
 			select {
 			case <-ticker.C:
@@ -137,12 +160,17 @@ func (h *HealthPing) doCheck(tags []string, duration time.Duration, rounds int)
 
 	for _, tag := range tags {
 		handler := tag
-		client := newPingClient(
-			h.ctx,
-			h.Settings.Destination,
-			h.Settings.Timeout,
-			handler,
-		)
+		var client *pingClient
+		if h.Settings.Probe != nil {
+			client = newProbePingClient(h.ctx, h.Settings.Probe, h.Settings.Timeout, handler)
+		} else {
+			client = newPingClient(
+				h.ctx,
+				h.Settings.Destination,
+				h.Settings.Timeout,
+				handler,
+			)
+		}
 		for i := 0; i < rounds; i++ {
 			delay := time.Duration(0)
 			if duration > 0 {
@@ -168,7 +196,7 @@ func (h *HealthPing) doCheck(tags []string, duration time.Duration, rounds int)
 				}
 				newError(fmt.Sprintf(
 					"error ping %s with %s: %s",
-					h.Settings.Destination,
+					h.destinationName(),
 					handler,
 					err,
 				)).AtWarning().WriteToLog()
@@ -184,14 +212,29 @@ func (h *HealthPing) doCheck(tags []string, duration time.Duration, rounds int)
 		if rtt.value > 0 {
 			// should not put results when network is down
 			h.PutResult(rtt.handler, rtt.value)
+			if h.resultPut != nil {
+				h.resultPut(rtt.handler)
+			}
 		}
 	}
 }
 
+func (h *HealthPing) destinationName() string {
+	if probe := h.Settings.Probe; probe != nil {
+		return probe.Kind.String() + " " + probe.Destination
+	}
+	return h.Settings.Destination
+}
+
 // PutResult puts a ping rtt to results
 func (h *HealthPing) PutResult(tag string, rtt time.Duration) {
 	h.access.Lock()
 	defer h.access.Unlock()
+	delete(h.stale, tag)
+	h.resultLockHolderOnly(tag).Put(rtt)
+}
+
+func (h *HealthPing) resultLockHolderOnly(tag string) *HealthPingRTTS {
 	if h.Results == nil {
 		h.Results = make(map[string]*HealthPingRTTS)
 	}
@@ -205,12 +248,80 @@ func (h *HealthPing) PutResult(tag string, rtt time.Duration) {
 		r = NewHealthPingResult(h.Settings.SamplingCount, validity)
 		h.Results[tag] = r
 	}
-	r.Put(rtt)
+	return r
+}
+
+// Snapshot returns the ping history of all outbounds
+func (h *HealthPing) Snapshot() *HealthPingSnapshot {
+	h.access.Lock()
+	defer h.access.Unlock()
+	snapshot := &HealthPingSnapshot{}
+	for tag, r := range h.Results {
+		snapshot.History = append(snapshot.History, &HealthPingHistory{
+			OutboundTag: tag,
+			Record:      r.records(),
+		})
+	}
+	return snapshot
+}
+
+// Restore puts the ping history in snapshot to results, and marks the
+// outbounds as stale until they are pinged again. The history is moved
+// forward in time to end now, so that it is still valid after a long
+// downtime, until replaced by new pings
+func (h *HealthPing) Restore(snapshot *HealthPingSnapshot) {
+	h.access.Lock()
+	defer h.access.Unlock()
+	if h.stale == nil {
+		h.stale = make(map[string]bool)
+	}
+	var latest int64
+	for _, history := range snapshot.History {
+		for _, record := range history.Record {
+			if record.Time > latest {
+				latest = record.Time
+			}
+		}
+	}
+	var shift time.Duration
+	if now := time.Now(); latest > 0 && now.After(time.Unix(0, latest)) {
+		shift = now.Sub(time.Unix(0, latest))
+	}
+	for _, history := range snapshot.History {
+		r := h.resultLockHolderOnly(history.OutboundTag)
+		for _, record := range history.Record {
+			r.putAt(time.Unix(0, record.Time).Add(shift), time.Duration(record.Rtt))
+		}
+		h.stale[history.OutboundTag] = true
+	}
+}
+
+// probeBandwidth measures the throughput of alive outbounds in tags one by one,
+// if the bandwidth probe is configured
+func (h *HealthPing) probeBandwidth(tags []string) {
+	if h.bandwidth == nil {
+		return
+	}
+	for _, tag := range tags {
+		h.access.Lock()
+		alive := false
+		if r, ok := h.Results[tag]; ok {
+			stats := r.getStatistics()
+			alive = stats.All != stats.Fail
+		}
+		h.access.Unlock()
+		if alive {
+			h.bandwidth.Probe(h.ctx, tag)
+		}
+	}
 }
 
 // Cleanup removes results of removed handlers,
 // tags should be all valid tags of the Balancer now
 func (h *HealthPing) Cleanup(tags []string) {
+	if h.bandwidth != nil {
+		h.bandwidth.Cleanup(tags)
+	}
 	h.access.Lock()
 	defer h.access.Unlock()
 	for tag := range h.Results {
@@ -223,6 +334,7 @@ func (h *HealthPing) Cleanup(tags []string) {
 		}
 		if !found {
 			delete(h.Results, tag)
+			delete(h.stale, tag)
 		}
 	}
 }
@@ -56,6 +56,10 @@ func (h *HealthPingRTTS) GetWithCache() *HealthPingStats {
 
 // Put puts a new rtt to the HealthPingResult
 func (h *HealthPingRTTS) Put(d time.Duration) {
+	h.putAt(time.Now(), d)
+}
+
+func (h *HealthPingRTTS) putAt(now time.Time, d time.Duration) {
 	if h.rtts == nil {
 		h.rtts = make([]*pingRTT, h.cap)
 		for i := 0; i < h.cap; i++ {
@@ -64,11 +68,26 @@ func (h *HealthPingRTTS) Put(d time.Duration) {
 		h.idx = -1
 	}
 	h.idx = h.calcIndex(1)
-	now := time.Now()
 	h.rtts[h.idx].time = now
 	h.rtts[h.idx].value = d
 }
 
+// records returns the rtts put, from oldest to latest
+func (h *HealthPingRTTS) records() []*HealthPingRecord {
+	var records []*HealthPingRecord
+	for i := 1; i <= len(h.rtts); i++ {
+		rtt := h.rtts[h.calcIndex(i)]
+		if rtt.value == 0 {
+			continue
+		}
+		records = append(records, &HealthPingRecord{
+			Time: rtt.time.UnixNano(),
+			Rtt:  int64(rtt.value),
+		})
+	}
+	return records
+}
+
 func (h *HealthPingRTTS) calcIndex(step int) int {
 	idx := h.idx
 	idx += step
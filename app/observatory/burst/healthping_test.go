@@ -0,0 +1,41 @@
+package burst_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/app/observatory/burst"
+)
+
+func TestHealthPingSnapshot(t *testing.T) {
+	config := &burst.HealthPingConfig{SamplingCount: 4}
+	hp := burst.NewHealthPing(context.Background(), config)
+	hp.PutResult("a", 100*time.Millisecond)
+	hp.PutResult("a", 300*time.Millisecond)
+	hp.PutResult("b", 50*time.Millisecond)
+
+	snapshot := hp.Snapshot()
+	if len(snapshot.History) != 2 {
+		t.Fatal("expect history of 2 outbounds, got ", snapshot.String())
+	}
+
+	restored := burst.NewHealthPing(context.Background(), config)
+	restored.Restore(snapshot)
+	stats := restored.Results["a"].Get()
+	if stats.All != 2 || stats.Average != 200*time.Millisecond {
+		t.Error("unexpected restored statistics: ", stats)
+	}
+
+	// Results saved long ago are kept, as if the last one was just put.
+	old := burst.NewHealthPing(context.Background(), config)
+	for _, history := range snapshot.History {
+		for _, record := range history.Record {
+			record.Time -= int64(time.Hour)
+		}
+	}
+	old.Restore(snapshot)
+	if stats := old.Results["a"].Get(); stats.All != 2 || stats.Average != 200*time.Millisecond {
+		t.Error("expect old results to be kept, got ", stats)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/transport/internet/tagged"
 )
@@ -12,6 +13,12 @@ import (
 type pingClient struct {
 	destination string
 	httpClient  *http.Client
+
+	// probe, if set, replaces the HTTP request to destination.
+	ctx     context.Context
+	probe   *observatory.ProbeConfig
+	handler string
+	timeout time.Duration
 }
 
 func newPingClient(ctx context.Context, destination string, timeout time.Duration, handler string) *pingClient {
@@ -21,6 +28,15 @@ func newPingClient(ctx context.Context, destination string, timeout time.Duratio
 	}
 }
 
+func newProbePingClient(ctx context.Context, probe *observatory.ProbeConfig, timeout time.Duration, handler string) *pingClient {
+	return &pingClient{
+		ctx:     ctx,
+		probe:   probe,
+		handler: handler,
+		timeout: timeout,
+	}
+}
+
 func newDirectPingClient(destination string, timeout time.Duration) *pingClient {
 	return &pingClient{
 		destination: destination,
@@ -51,6 +67,13 @@ func newHTTPClient(ctxv context.Context, handler string, timeout time.Duration)
 
 // MeasureDelay returns the delay time of the request to dest
 func (s *pingClient) MeasureDelay() (time.Duration, error) {
+	if s.probe != nil {
+		delay, err := observatory.Probe(s.ctx, s.probe, s.handler, s.timeout)
+		if err != nil {
+			return rttFailed, err
+		}
+		return delay, nil
+	}
 	if s.httpClient == nil {
 		panic("pingClient no initialized")
 	}
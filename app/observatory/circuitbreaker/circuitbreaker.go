@@ -0,0 +1,209 @@
+package circuitbreaker
+
+//go:generate go run github.com/v2fly/v2ray-core/v5/common/errors/errorgen
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/features/extension"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenDuration     = 30 * time.Second
+)
+
+type circuit struct {
+	state     observatory.CircuitStatus_State
+	failures  int64
+	openTime  time.Time
+	lastError string
+	// trialTime is the time the trial connection of a half-open circuit is admitted, or zero if none is in flight.
+	trialTime time.Time
+}
+
+// CircuitBreaker is an implementation of extension.CircuitBreaker. The circuit of an outbound opens after a number
+// of connection failures in a row, and balancers skip the outbound. Once the open duration passes, the circuit is
+// half-open and a single trial connection is admitted: the circuit closes if it succeeds, or opens again if it fails.
+// Another trial is admitted if the result is not reported within the open duration.
+type CircuitBreaker struct {
+	config           *Config
+	failureThreshold int64
+	openDuration     time.Duration
+
+	access   sync.Mutex
+	circuits map[string]*circuit
+
+	now func() time.Time
+}
+
+// New creates a new CircuitBreaker with config.
+func New(ctx context.Context, config *Config) (*CircuitBreaker, error) {
+	b := &CircuitBreaker{
+		config:           config,
+		failureThreshold: int64(config.FailureThreshold),
+		openDuration:     time.Duration(config.OpenDuration),
+		circuits:         make(map[string]*circuit),
+		now:              time.Now,
+	}
+	if b.failureThreshold <= 0 {
+		b.failureThreshold = defaultFailureThreshold
+	}
+	if b.openDuration <= 0 {
+		b.openDuration = defaultOpenDuration
+	}
+	return b, nil
+}
+
+// Type implements common.HasType.
+func (b *CircuitBreaker) Type() interface{} {
+	return extension.CircuitBreakerType()
+}
+
+// Start implements common.Runnable.
+func (b *CircuitBreaker) Start() error {
+	return nil
+}
+
+// Close implements common.Closable.
+func (b *CircuitBreaker) Close() error {
+	return nil
+}
+
+func (b *CircuitBreaker) isSubject(tag string) bool {
+	if len(b.config.SubjectSelector) == 0 {
+		return tag != ""
+	}
+	for _, selector := range b.config.SubjectSelector {
+		if strings.HasPrefix(tag, selector) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateState moves an open circuit to half-open once the open duration passes.
+func (b *CircuitBreaker) updateState(c *circuit) {
+	if c.state == observatory.CircuitStatus_Open && b.now().Sub(c.openTime) >= b.openDuration {
+		c.state = observatory.CircuitStatus_HalfOpen
+		c.trialTime = time.Time{}
+	}
+}
+
+// inTrial returns whether the trial connection of a half-open circuit is in flight.
+func (b *CircuitBreaker) inTrial(c *circuit) bool {
+	return c.state == observatory.CircuitStatus_HalfOpen && !c.trialTime.IsZero() &&
+		b.now().Sub(c.trialTime) < b.openDuration
+}
+
+// ReportResult implements extension.CircuitBreaker.
+func (b *CircuitBreaker) ReportResult(tag string, err error) {
+	if !b.isSubject(tag) {
+		return
+	}
+
+	b.access.Lock()
+	defer b.access.Unlock()
+
+	c, found := b.circuits[tag]
+	if !found {
+		if err == nil {
+			return
+		}
+		c = &circuit{}
+		b.circuits[tag] = c
+	}
+	b.updateState(c)
+	c.trialTime = time.Time{}
+
+	if err == nil {
+		if c.state != observatory.CircuitStatus_Closed {
+			newError("circuit of [", tag, "] closed").AtInfo().WriteToLog()
+		}
+		c.state = observatory.CircuitStatus_Closed
+		c.failures = 0
+		return
+	}
+
+	c.failures++
+	c.lastError = err.Error()
+	switch {
+	case c.state == observatory.CircuitStatus_HalfOpen,
+		c.state == observatory.CircuitStatus_Closed && c.failures >= b.failureThreshold:
+		newError("circuit of [", tag, "] opened after ", c.failures, " failures").Base(err).AtWarning().WriteToLog()
+		c.state = observatory.CircuitStatus_Open
+		c.openTime = b.now()
+	}
+}
+
+// Allow implements extension.CircuitBreaker.
+func (b *CircuitBreaker) Allow(tag string) bool {
+	b.access.Lock()
+	defer b.access.Unlock()
+
+	c, found := b.circuits[tag]
+	if !found {
+		return true
+	}
+	b.updateState(c)
+	return c.state != observatory.CircuitStatus_Open && !b.inTrial(c)
+}
+
+// Admit implements extension.CircuitBreaker.
+func (b *CircuitBreaker) Admit(tag string) bool {
+	b.access.Lock()
+	defer b.access.Unlock()
+
+	c, found := b.circuits[tag]
+	if !found {
+		return true
+	}
+	b.updateState(c)
+	if c.state != observatory.CircuitStatus_HalfOpen {
+		return true
+	}
+	if b.inTrial(c) {
+		return false
+	}
+	c.trialTime = b.now()
+	return true
+}
+
+// GetCircuitStatus implements extension.CircuitBreaker.
+func (b *CircuitBreaker) GetCircuitStatus(ctx context.Context) (proto.Message, error) {
+	b.access.Lock()
+	defer b.access.Unlock()
+
+	result := &observatory.CircuitBreakerResult{}
+	for tag, c := range b.circuits {
+		b.updateState(c)
+		status := &observatory.CircuitStatus{
+			OutboundTag:     tag,
+			State:           c.state,
+			Failures:        c.failures,
+			LastErrorReason: c.lastError,
+		}
+		if !c.openTime.IsZero() {
+			status.OpenTime = c.openTime.Unix()
+		}
+		result.Circuit = append(result.Circuit, status)
+	}
+	sort.Slice(result.Circuit, func(i, j int) bool {
+		return result.Circuit[i].OutboundTag < result.Circuit[j].OutboundTag
+	})
+	return result, nil
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return New(ctx, config.(*Config))
+	}))
+}
@@ -0,0 +1,95 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
+	"github.com/v2fly/v2ray-core/v5/common"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker, err := New(context.Background(), &Config{
+		SubjectSelector:  []string{"proxy"},
+		FailureThreshold: 3,
+		OpenDuration:     int64(time.Minute),
+	})
+	common.Must(err)
+	now := time.Unix(1000, 0)
+	breaker.now = func() time.Time { return now }
+
+	failure := errors.New("connection refused")
+	expectState := func(tag string, state observatory.CircuitStatus_State) {
+		t.Helper()
+		result, err := breaker.GetCircuitStatus(context.Background())
+		common.Must(err)
+		for _, c := range result.(*observatory.CircuitBreakerResult).Circuit {
+			if c.OutboundTag == tag {
+				if c.State != state {
+					t.Error("expect circuit of ", tag, " to be ", state, ", got ", c.State)
+				}
+				return
+			}
+		}
+		t.Error("circuit of ", tag, " not found")
+	}
+
+	// Failures in a row below the threshold, and those of outbounds not tracked, keep the circuit closed.
+	breaker.ReportResult("proxy-a", failure)
+	breaker.ReportResult("proxy-a", failure)
+	breaker.ReportResult("proxy-a", nil)
+	breaker.ReportResult("proxy-a", failure)
+	breaker.ReportResult("proxy-a", failure)
+	expectState("proxy-a", observatory.CircuitStatus_Closed)
+	for i := 0; i < 10; i++ {
+		breaker.ReportResult("direct", failure)
+	}
+	if !breaker.Allow("direct") {
+		t.Error("expect outbound not tracked to be allowed")
+	}
+
+	breaker.ReportResult("proxy-a", failure)
+	expectState("proxy-a", observatory.CircuitStatus_Open)
+	if breaker.Allow("proxy-a") {
+		t.Error("expect open circuit not to be allowed")
+	}
+
+	// Half-open after the open duration, admits a single trial, and opens again on failure.
+	now = now.Add(time.Minute)
+	if !breaker.Allow("proxy-a") {
+		t.Error("expect half-open circuit to be allowed")
+	}
+	expectState("proxy-a", observatory.CircuitStatus_HalfOpen)
+	if !breaker.Admit("proxy-a") {
+		t.Error("expect trial connection to be admitted")
+	}
+	if breaker.Allow("proxy-a") || breaker.Admit("proxy-a") {
+		t.Error("expect half-open circuit with a trial in flight not to be allowed")
+	}
+	if !breaker.Admit("proxy-b") {
+		t.Error("expect outbound without circuit to be admitted")
+	}
+	breaker.ReportResult("proxy-a", failure)
+	expectState("proxy-a", observatory.CircuitStatus_Open)
+	if breaker.Allow("proxy-a") {
+		t.Error("expect reopened circuit not to be allowed")
+	}
+
+	// Admits another trial if the result of the last one is not reported in time, and closes on success.
+	now = now.Add(time.Minute)
+	expectState("proxy-a", observatory.CircuitStatus_HalfOpen)
+	if !breaker.Admit("proxy-a") {
+		t.Error("expect trial connection to be admitted")
+	}
+	now = now.Add(time.Minute)
+	if !breaker.Admit("proxy-a") {
+		t.Error("expect another trial connection to be admitted")
+	}
+	breaker.ReportResult("proxy-a", nil)
+	expectState("proxy-a", observatory.CircuitStatus_Closed)
+	if !breaker.Allow("proxy-a") {
+		t.Error("expect closed circuit to be allowed")
+	}
+}
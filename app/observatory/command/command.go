@@ -7,6 +7,8 @@ package command
 
 import (
 	"context"
+	"sort"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc"
@@ -25,24 +27,29 @@ type service struct {
 	observatory extension.Observatory
 }
 
+func (s *service) getObservatory(tag string) (extension.Observatory, error) {
+	if tag == "" {
+		return s.observatory, nil
+	}
+	tagged, ok := s.observatory.(features.TaggedFeatures)
+	if !ok {
+		return nil, newError("observatory is not tagged")
+	}
+	fet, err := tagged.GetFeaturesByTag(tag)
+	if err != nil {
+		return nil, newError("cannot get tagged observatory").Base(err)
+	}
+	return fet.(extension.Observatory), nil
+}
+
 func (s *service) GetOutboundStatus(ctx context.Context, request *GetOutboundStatusRequest) (*GetOutboundStatusResponse, error) {
-	var result proto.Message
-	if request.Tag == "" {
-		observeResult, err := s.observatory.GetObservation(ctx)
-		if err != nil {
-			return nil, newError("cannot get observation").Base(err)
-		}
-		result = observeResult
-	} else {
-		fet, err := s.observatory.(features.TaggedFeatures).GetFeaturesByTag(request.Tag)
-		if err != nil {
-			return nil, newError("cannot get tagged observatory").Base(err)
-		}
-		observeResult, err := fet.(extension.Observatory).GetObservation(ctx)
-		if err != nil {
-			return nil, newError("cannot get observation").Base(err)
-		}
-		result = observeResult
+	observer, err := s.getObservatory(request.Tag)
+	if err != nil {
+		return nil, err
+	}
+	result, err := observer.GetObservation(ctx)
+	if err != nil {
+		return nil, newError("cannot get observation").Base(err)
 	}
 	retdata := result.(*observatory.ObservationResult)
 	return &GetOutboundStatusResponse{
@@ -50,6 +57,157 @@ func (s *service) GetOutboundStatus(ctx context.Context, request *GetOutboundSta
 	}, nil
 }
 
+func (s *service) GetCircuitStatus(ctx context.Context, request *GetCircuitStatusRequest) (*GetCircuitStatusResponse, error) {
+	breaker, ok := s.v.GetFeature(extension.CircuitBreakerType()).(extension.CircuitBreaker)
+	if !ok {
+		return nil, newError("circuit breaker is not enabled")
+	}
+	result, err := breaker.GetCircuitStatus(ctx)
+	if err != nil {
+		return nil, newError("cannot get circuit status").Base(err)
+	}
+	return &GetCircuitStatusResponse{
+		Status: result.(*observatory.CircuitBreakerResult),
+	}, nil
+}
+
+func (s *service) SubscribeOutboundStatus(request *SubscribeOutboundStatusRequest, stream ObservatoryService_SubscribeOutboundStatusServer) error {
+	observer, err := s.getObservatory(request.Tag)
+	if err != nil {
+		return err
+	}
+	subscribable, ok := observer.(extension.ObservationSubscribable)
+	if !ok {
+		return newError("observatory does not support subscription")
+	}
+	// Subscribe before taking the snapshot, so that no change in between is lost.
+	subscriber := subscribable.SubscribeObservation()
+	defer subscriber.Close()
+
+	ctx := stream.Context()
+	queue := newStatusQueue()
+	if err := queue.putObservation(ctx, observer); err != nil {
+		return err
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-subscriber.Wait():
+				queue.put(msg.(*observatory.OutboundStatus))
+				buffer := subscriber.Wait()
+				if len(buffer) < cap(buffer)-1 {
+					continue
+				}
+				// The buffer of the subscriber was full, and statuses may have been dropped. Take the buffered ones,
+				// and then a snapshot newer than all of them.
+				for len(buffer) > 0 {
+					queue.put((<-buffer).(*observatory.OutboundStatus))
+				}
+				if err := queue.putObservation(ctx, observer); err != nil {
+					newError("failed to resend observation").Base(err).AtWarning().WriteToLog()
+				}
+			}
+		}
+	}()
+
+	sent := make(map[string]*observatory.OutboundStatus)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-queue.notify:
+		}
+		for _, status := range queue.take() {
+			changes := statusChanges(sent[status.OutboundTag], status, request.DelayThreshold)
+			if len(changes) == 0 {
+				continue
+			}
+			sent[status.OutboundTag] = status
+			if err := stream.Send(&SubscribeOutboundStatusResponse{Change: changes, Status: status}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// statusQueue holds the statuses to send to a subscriber. Only the latest status of each outbound is kept, so that
+// a slow subscriber skips intermediate statuses instead of losing the latest ones.
+type statusQueue struct {
+	access  sync.Mutex
+	pending map[string]*observatory.OutboundStatus
+	notify  chan struct{}
+}
+
+func newStatusQueue() *statusQueue {
+	return &statusQueue{
+		pending: make(map[string]*observatory.OutboundStatus),
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+func (q *statusQueue) put(status *observatory.OutboundStatus) {
+	q.access.Lock()
+	q.pending[status.OutboundTag] = status
+	q.access.Unlock()
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// putObservation puts the current status of each outbound from observer.
+func (q *statusQueue) putObservation(ctx context.Context, observer extension.Observatory) error {
+	result, err := observer.GetObservation(ctx)
+	if err != nil {
+		return newError("cannot get observation").Base(err)
+	}
+	for _, status := range result.(*observatory.ObservationResult).Status {
+		q.put(proto.Clone(status).(*observatory.OutboundStatus))
+	}
+	return nil
+}
+
+// take removes and returns the pending statuses, ordered by outbound tag.
+func (q *statusQueue) take() []*observatory.OutboundStatus {
+	q.access.Lock()
+	defer q.access.Unlock()
+	statuses := make([]*observatory.OutboundStatus, 0, len(q.pending))
+	for _, status := range q.pending {
+		statuses = append(statuses, status)
+	}
+	q.pending = make(map[string]*observatory.OutboundStatus)
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].OutboundTag < statuses[j].OutboundTag
+	})
+	return statuses
+}
+
+// statusChanges returns what changed in the current status of an outbound since the previous one sent.
+func statusChanges(previous, current *observatory.OutboundStatus, delayThreshold int64) []SubscribeOutboundStatusResponse_Change {
+	if previous == nil {
+		return []SubscribeOutboundStatusResponse_Change{SubscribeOutboundStatusResponse_Initial}
+	}
+	var changes []SubscribeOutboundStatusResponse_Change
+	if previous.Alive != current.Alive {
+		changes = append(changes, SubscribeOutboundStatusResponse_Alive)
+	}
+	if current.Alive && previous.Alive {
+		diff := current.Delay - previous.Delay
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > delayThreshold {
+			changes = append(changes, SubscribeOutboundStatusResponse_Delay)
+		}
+	}
+	if previous.LastErrorReason != current.LastErrorReason {
+		changes = append(changes, SubscribeOutboundStatusResponse_LastErrorReason)
+	}
+	return changes
+}
+
 func (s *service) Register(server *grpc.Server) {
 	RegisterObservatoryServiceServer(server, s)
 }
@@ -17,6 +17,10 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ObservatoryServiceClient interface {
 	GetOutboundStatus(ctx context.Context, in *GetOutboundStatusRequest, opts ...grpc.CallOption) (*GetOutboundStatusResponse, error)
+	GetCircuitStatus(ctx context.Context, in *GetCircuitStatusRequest, opts ...grpc.CallOption) (*GetCircuitStatusResponse, error)
+	// SubscribeOutboundStatus streams the status of an outbound when it
+	// changes.
+	SubscribeOutboundStatus(ctx context.Context, in *SubscribeOutboundStatusRequest, opts ...grpc.CallOption) (ObservatoryService_SubscribeOutboundStatusClient, error)
 }
 
 type observatoryServiceClient struct {
@@ -36,11 +40,56 @@ func (c *observatoryServiceClient) GetOutboundStatus(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *observatoryServiceClient) GetCircuitStatus(ctx context.Context, in *GetCircuitStatusRequest, opts ...grpc.CallOption) (*GetCircuitStatusResponse, error) {
+	out := new(GetCircuitStatusResponse)
+	err := c.cc.Invoke(ctx, "/v2ray.core.app.observatory.command.ObservatoryService/GetCircuitStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *observatoryServiceClient) SubscribeOutboundStatus(ctx context.Context, in *SubscribeOutboundStatusRequest, opts ...grpc.CallOption) (ObservatoryService_SubscribeOutboundStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ObservatoryService_ServiceDesc.Streams[0], "/v2ray.core.app.observatory.command.ObservatoryService/SubscribeOutboundStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &observatoryServiceSubscribeOutboundStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ObservatoryService_SubscribeOutboundStatusClient interface {
+	Recv() (*SubscribeOutboundStatusResponse, error)
+	grpc.ClientStream
+}
+
+type observatoryServiceSubscribeOutboundStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *observatoryServiceSubscribeOutboundStatusClient) Recv() (*SubscribeOutboundStatusResponse, error) {
+	m := new(SubscribeOutboundStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ObservatoryServiceServer is the server API for ObservatoryService service.
 // All implementations must embed UnimplementedObservatoryServiceServer
 // for forward compatibility
 type ObservatoryServiceServer interface {
 	GetOutboundStatus(context.Context, *GetOutboundStatusRequest) (*GetOutboundStatusResponse, error)
+	GetCircuitStatus(context.Context, *GetCircuitStatusRequest) (*GetCircuitStatusResponse, error)
+	// SubscribeOutboundStatus streams the status of an outbound when it
+	// changes.
+	SubscribeOutboundStatus(*SubscribeOutboundStatusRequest, ObservatoryService_SubscribeOutboundStatusServer) error
 	mustEmbedUnimplementedObservatoryServiceServer()
 }
 
@@ -51,6 +100,12 @@ type UnimplementedObservatoryServiceServer struct {
 func (UnimplementedObservatoryServiceServer) GetOutboundStatus(context.Context, *GetOutboundStatusRequest) (*GetOutboundStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOutboundStatus not implemented")
 }
+func (UnimplementedObservatoryServiceServer) GetCircuitStatus(context.Context, *GetCircuitStatusRequest) (*GetCircuitStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCircuitStatus not implemented")
+}
+func (UnimplementedObservatoryServiceServer) SubscribeOutboundStatus(*SubscribeOutboundStatusRequest, ObservatoryService_SubscribeOutboundStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeOutboundStatus not implemented")
+}
 func (UnimplementedObservatoryServiceServer) mustEmbedUnimplementedObservatoryServiceServer() {}
 
 // UnsafeObservatoryServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -82,6 +137,45 @@ func _ObservatoryService_GetOutboundStatus_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ObservatoryService_GetCircuitStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCircuitStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObservatoryServiceServer).GetCircuitStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.observatory.command.ObservatoryService/GetCircuitStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObservatoryServiceServer).GetCircuitStatus(ctx, req.(*GetCircuitStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ObservatoryService_SubscribeOutboundStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeOutboundStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ObservatoryServiceServer).SubscribeOutboundStatus(m, &observatoryServiceSubscribeOutboundStatusServer{stream})
+}
+
+type ObservatoryService_SubscribeOutboundStatusServer interface {
+	Send(*SubscribeOutboundStatusResponse) error
+	grpc.ServerStream
+}
+
+type observatoryServiceSubscribeOutboundStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *observatoryServiceSubscribeOutboundStatusServer) Send(m *SubscribeOutboundStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ObservatoryService_ServiceDesc is the grpc.ServiceDesc for ObservatoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -93,7 +187,17 @@ var ObservatoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOutboundStatus",
 			Handler:    _ObservatoryService_GetOutboundStatus_Handler,
 		},
+		{
+			MethodName: "GetCircuitStatus",
+			Handler:    _ObservatoryService_GetCircuitStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeOutboundStatus",
+			Handler:       _ObservatoryService_SubscribeOutboundStatus_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "app/observatory/command/command.proto",
 }
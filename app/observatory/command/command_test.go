@@ -0,0 +1,130 @@
+package command
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/signal/pubsub"
+)
+
+type subscribableObservatory struct {
+	access sync.Mutex
+	status []*observatory.OutboundStatus
+	pub    *pubsub.Service
+}
+
+func (o *subscribableObservatory) Type() interface{} { return nil }
+func (o *subscribableObservatory) Start() error      { return nil }
+func (o *subscribableObservatory) Close() error      { return nil }
+func (o *subscribableObservatory) GetObservation(ctx context.Context) (proto.Message, error) {
+	o.access.Lock()
+	defer o.access.Unlock()
+	return &observatory.ObservationResult{Status: o.status}, nil
+}
+
+// publish updates the status of an outbound, and publishes it.
+func (o *subscribableObservatory) publish(status *observatory.OutboundStatus) {
+	o.access.Lock()
+	found := false
+	for i, s := range o.status {
+		if s.OutboundTag == status.OutboundTag {
+			o.status[i] = status
+			found = true
+		}
+	}
+	if !found {
+		o.status = append(o.status, status)
+	}
+	o.access.Unlock()
+	o.pub.Publish("status", status)
+}
+
+func (o *subscribableObservatory) SubscribeObservation() *pubsub.Subscriber {
+	return o.pub.Subscribe("status")
+}
+
+func TestSubscribeOutboundStatus(t *testing.T) {
+	observer := &subscribableObservatory{
+		status: []*observatory.OutboundStatus{{OutboundTag: "a", Alive: true, Delay: 100}},
+		pub:    pubsub.NewService(),
+	}
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterObservatoryServiceServer(server, &service{observatory: observer})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	common.Must(err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := NewObservatoryServiceClient(conn).SubscribeOutboundStatus(ctx, &SubscribeOutboundStatusRequest{DelayThreshold: 20})
+	common.Must(err)
+
+	expect := func(tag string, changes ...SubscribeOutboundStatusResponse_Change) {
+		t.Helper()
+		resp, err := stream.Recv()
+		common.Must(err)
+		if resp.Status.OutboundTag != tag || len(resp.Change) != len(changes) {
+			t.Fatal("expect changes ", changes, " of ", tag, ", got ", resp.String())
+		}
+		for i, c := range changes {
+			if resp.Change[i] != c {
+				t.Fatal("expect changes ", changes, " of ", tag, ", got ", resp.String())
+			}
+		}
+	}
+
+	expect("a", SubscribeOutboundStatusResponse_Initial)
+
+	// Statuses of an outbound not sent yet are replaced by newer ones, and changes are against the status last
+	// sent, so that small changes add up.
+	for _, status := range []*observatory.OutboundStatus{
+		{OutboundTag: "a", Alive: true, Delay: 110},
+		{OutboundTag: "a", Alive: true, Delay: 130},
+		{OutboundTag: "b", Alive: true, Delay: 50},
+		{OutboundTag: "a", Alive: false, LastErrorReason: "timeout"},
+	} {
+		observer.publish(status)
+	}
+	latest := make(map[string]*SubscribeOutboundStatusResponse)
+	for latest["b"] == nil || latest["a"] == nil || latest["a"].Status.Alive {
+		resp, err := stream.Recv()
+		common.Must(err)
+		if resp.Status.OutboundTag == "a" && resp.Status.Alive && (len(resp.Change) != 1 || resp.Change[0] != SubscribeOutboundStatusResponse_Delay || resp.Status.Delay != 130) {
+			t.Fatal("unexpected change of a: ", resp.String())
+		}
+		latest[resp.Status.OutboundTag] = resp
+	}
+	if changes := latest["a"].Change; len(changes) != 2 || changes[0] != SubscribeOutboundStatusResponse_Alive || changes[1] != SubscribeOutboundStatusResponse_LastErrorReason {
+		t.Error("unexpected changes of a: ", latest["a"].String())
+	}
+	if changes := latest["b"].Change; len(changes) != 1 || changes[0] != SubscribeOutboundStatusResponse_Initial {
+		t.Error("unexpected changes of b: ", latest["b"].String())
+	}
+
+	// No outbound is missed, even if more statuses are published at once than the subscriber buffers.
+	const count = 100
+	for i := 0; i < count; i++ {
+		observer.publish(&observatory.OutboundStatus{OutboundTag: "c" + strconv.Itoa(i), Alive: true})
+	}
+	seen := make(map[string]bool)
+	for len(seen) < count {
+		resp, err := stream.Recv()
+		common.Must(err)
+		seen[resp.Status.OutboundTag] = true
+	}
+}
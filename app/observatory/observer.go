@@ -16,11 +16,15 @@ import (
 
 	core "github.com/v2fly/v2ray-core/v5"
 	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/environment"
+	"github.com/v2fly/v2ray-core/v5/common/environment/envctx"
 	v2net "github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/common/session"
 	"github.com/v2fly/v2ray-core/v5/common/signal/done"
+	"github.com/v2fly/v2ray-core/v5/common/signal/pubsub"
 	"github.com/v2fly/v2ray-core/v5/common/task"
 	"github.com/v2fly/v2ray-core/v5/features/extension"
+	"github.com/v2fly/v2ray-core/v5/features/extension/storage"
 	"github.com/v2fly/v2ray-core/v5/features/outbound"
 	"github.com/v2fly/v2ray-core/v5/transport/internet/tagged"
 )
@@ -34,27 +38,47 @@ type Observer struct {
 
 	finished *done.Instance
 
-	ohm outbound.Manager
+	ohm       outbound.Manager
+	bandwidth *BandwidthProber
+
+	storage storage.ScopedPersistentStorage
+	pub     *pubsub.Service
 }
 
+const (
+	persistentStorageKey = "observation"
+	statusTopic          = "status"
+	defaultProbeTimeout  = 5 * time.Second
+)
+
 func (o *Observer) GetObservation(ctx context.Context) (proto.Message, error) {
 	return &ObservationResult{Status: o.status}, nil
 }
 
+// SubscribeObservation implements extension.ObservationSubscribable.
+func (o *Observer) SubscribeObservation() *pubsub.Subscriber {
+	return o.pub.Subscribe(statusTopic)
+}
+
 func (o *Observer) Type() interface{} {
 	return extension.ObservatoryType()
 }
 
 func (o *Observer) Start() error {
 	if o.config != nil && len(o.config.SubjectSelector) != 0 {
+		o.restore()
 		o.finished = done.New()
 		go o.background()
+		if o.bandwidth != nil {
+			go o.backgroundBandwidth()
+		}
 	}
 	return nil
 }
 
 func (o *Observer) Close() error {
 	if o.finished != nil {
+		o.persist()
 		return o.finished.Close()
 	}
 	return nil
@@ -72,19 +96,59 @@ func (o *Observer) background() {
 		sort.Strings(outbounds)
 
 		o.updateStatus(outbounds)
+		if o.bandwidth != nil {
+			o.bandwidth.Cleanup(outbounds)
+		}
 
 		for _, v := range outbounds {
 			result := o.probe(v)
-			o.updateStatusForResult(v, &result)
+			o.updateStatusForResult(v, result)
 			if o.finished.Done() {
 				return
 			}
-			sleepTime := time.Second * 10
-			if o.config.ProbeInterval != 0 {
-				sleepTime = time.Duration(o.config.ProbeInterval)
+			time.Sleep(o.probeInterval())
+		}
+		o.persist()
+	}
+}
+
+// backgroundBandwidth measures the throughput of alive outbounds, apart from the probes, as a measurement may take
+// much longer than a probe.
+func (o *Observer) backgroundBandwidth() {
+	for !o.finished.Done() {
+		for _, outbound := range o.aliveOutbounds() {
+			if o.bandwidth.Probe(o.ctx, outbound) {
+				o.updateBandwidth(outbound)
+			}
+			if o.finished.Done() {
+				return
 			}
-			time.Sleep(sleepTime)
 		}
+		time.Sleep(o.probeInterval())
+	}
+}
+
+// aliveOutbounds returns the tags of outbounds alive in the last probe.
+func (o *Observer) aliveOutbounds() []string {
+	o.statusLock.Lock()
+	defer o.statusLock.Unlock()
+	var outbounds []string
+	for _, status := range o.status {
+		if status.Alive && !status.Stale {
+			outbounds = append(outbounds, status.OutboundTag)
+		}
+	}
+	return outbounds
+}
+
+// updateBandwidth sets the last measured bandwidth of the outbound in its status.
+func (o *Observer) updateBandwidth(outbound string) {
+	o.statusLock.Lock()
+	defer o.statusLock.Unlock()
+	if location := o.findStatusLocationLockHolderOnly(outbound); location != -1 {
+		status := o.status[location]
+		o.bandwidth.FillStatus(status)
+		o.pub.Publish(statusTopic, proto.Clone(status))
 	}
 }
 
@@ -95,7 +159,25 @@ func (o *Observer) updateStatus(outbounds []string) {
 	_ = outbounds
 }
 
-func (o *Observer) probe(outbound string) ProbeResult {
+func (o *Observer) probeInterval() time.Duration {
+	if o.config.ProbeInterval != 0 {
+		return time.Duration(o.config.ProbeInterval)
+	}
+	return time.Second * 10
+}
+
+func (o *Observer) probeTimeout() time.Duration {
+	if o.config.ProbeTimeout > 0 {
+		return time.Duration(o.config.ProbeTimeout)
+	}
+	return defaultProbeTimeout
+}
+
+func (o *Observer) probe(outbound string) *ProbeResult {
+	if len(o.config.Probe) > 0 {
+		return o.probeAll(outbound)
+	}
+
 	errorCollectorForRequest := newErrorCollector()
 
 	httpTransport := http.Transport{
@@ -123,7 +205,7 @@ func (o *Observer) probe(outbound string) ProbeResult {
 			}
 			return connection, nil
 		},
-		TLSHandshakeTimeout: time.Second * 5,
+		TLSHandshakeTimeout: o.probeTimeout(),
 	}
 	httpClient := &http.Client{
 		Transport: &httpTransport,
@@ -131,7 +213,7 @@ func (o *Observer) probe(outbound string) ProbeResult {
 			return http.ErrUseLastResponse
 		},
 		Jar:     nil,
-		Timeout: time.Second * 5,
+		Timeout: o.probeTimeout(),
 	}
 	var GETTime time.Duration
 	err := task.Run(o.ctx, func() error {
@@ -158,10 +240,28 @@ func (o *Observer) probe(outbound string) ProbeResult {
 		fullerr = newError("the outbound ", outbound, " is dead:").Base(fullerr)
 		fullerr = fullerr.AtInfo()
 		fullerr.WriteToLog()
-		return ProbeResult{Alive: false, LastErrorReason: fullerr.Error()}
+		return &ProbeResult{Alive: false, LastErrorReason: fullerr.Error()}
 	}
 	newError("the outbound ", outbound, " is alive:", GETTime.Seconds()).AtInfo().WriteToLog()
-	return ProbeResult{Alive: true, Delay: GETTime.Milliseconds()}
+	return &ProbeResult{Alive: true, Delay: GETTime.Milliseconds()}
+}
+
+func (o *Observer) probeAll(outbound string) *ProbeResult {
+	errorCollectorForRequest := newErrorCollector()
+	trackedCtx := session.TrackedConnectionError(o.ctx, errorCollectorForRequest)
+	result := ProbeAll(trackedCtx, o.config.Probe, outbound, o.probeTimeout())
+	if !result.Alive {
+		fullerr := newError("underlying connection failed").Base(errorCollectorForRequest.UnderlyingError())
+		fullerr = newError("with outbound handler report").Base(fullerr)
+		fullerr = newError(result.LastErrorReason).Base(fullerr)
+		fullerr = newError("the outbound ", outbound, " is dead:").Base(fullerr)
+		fullerr = fullerr.AtInfo()
+		fullerr.WriteToLog()
+		result.LastErrorReason = fullerr.Error()
+		return result
+	}
+	newError("the outbound ", outbound, " is alive:", time.Duration(result.Delay)*time.Millisecond).AtInfo().WriteToLog()
+	return result
 }
 
 func (o *Observer) updateStatusForResult(outbound string, result *ProbeResult) {
@@ -178,6 +278,8 @@ func (o *Observer) updateStatusForResult(outbound string, result *ProbeResult) {
 	status.LastTryTime = time.Now().Unix()
 	status.OutboundTag = outbound
 	status.Alive = result.Alive
+	status.Stale = false
+	status.ProbeResult = result.ProbeResult
 	if result.Alive {
 		status.Delay = result.Delay
 		status.LastSeenTime = status.LastTryTime
@@ -186,6 +288,51 @@ func (o *Observer) updateStatusForResult(outbound string, result *ProbeResult) {
 		status.LastErrorReason = result.LastErrorReason
 		status.Delay = 99999999
 	}
+	if o.bandwidth != nil {
+		o.bandwidth.FillStatus(status)
+	}
+	o.pub.Publish(statusTopic, proto.Clone(status))
+}
+
+// restore loads the status saved by the last run from the persistent storage, marked as stale until probed again.
+func (o *Observer) restore() {
+	if o.storage == nil {
+		return
+	}
+	value, err := o.storage.Get(o.ctx, []byte(persistentStorageKey))
+	if err != nil {
+		newError("no saved observation restored").Base(err).AtDebug().WriteToLog()
+		return
+	}
+	result := &ObservationResult{}
+	if err := proto.Unmarshal(value, result); err != nil {
+		newError("failed to restore saved observation").Base(err).AtWarning().WriteToLog()
+		return
+	}
+	o.statusLock.Lock()
+	defer o.statusLock.Unlock()
+	for _, status := range result.Status {
+		status.Stale = true
+	}
+	o.status = result.Status
+	newError("restored observation of ", len(o.status), " outbounds").AtInfo().WriteToLog()
+}
+
+// persist saves the status to the persistent storage.
+func (o *Observer) persist() {
+	if o.storage == nil {
+		return
+	}
+	o.statusLock.Lock()
+	value, err := proto.Marshal(&ObservationResult{Status: o.status})
+	o.statusLock.Unlock()
+	if err != nil {
+		newError("failed to encode observation").Base(err).AtWarning().WriteToLog()
+		return
+	}
+	if err := o.storage.Put(o.ctx, []byte(persistentStorageKey), value); err != nil {
+		newError("failed to save observation").Base(err).AtDebug().WriteToLog()
+	}
 }
 
 func (o *Observer) findStatusLocationLockHolderOnly(outbound string) int {
@@ -205,11 +352,19 @@ func New(ctx context.Context, config *Config) (*Observer, error) {
 	if err != nil {
 		return nil, newError("Cannot get depended features").Base(err)
 	}
-	return &Observer{
+	observer := &Observer{
 		config: config,
 		ctx:    ctx,
 		ohm:    outboundManager,
-	}, nil
+		pub:    pubsub.NewService(),
+	}
+	if config.BandwidthProbe != nil {
+		observer.bandwidth = NewBandwidthProber(config.BandwidthProbe)
+	}
+	if env, ok := envctx.EnvironmentFromContext(ctx).(environment.AppEnvironment); ok {
+		observer.storage = env.PersistentStorage()
+	}
+	return observer, nil
 }
 
 func init() {
@@ -0,0 +1,228 @@
+package observatory
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/v2fly/v2ray-core/v5/common/dice"
+	v2net "github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tagged"
+)
+
+const defaultProbeDomain = "www.google.com"
+
+// Probe checks the outbound with the probe of config, and returns the time it takes.
+func Probe(ctx context.Context, config *ProbeConfig, outbound string, timeout time.Duration) (time.Duration, error) {
+	switch config.Kind {
+	case ProbeConfig_HTTP:
+		return probeHTTP(ctx, config, outbound, timeout)
+	case ProbeConfig_TCP:
+		return probeTCP(ctx, config, outbound, timeout)
+	case ProbeConfig_TLS:
+		return probeTLS(ctx, config, outbound, timeout)
+	case ProbeConfig_DNS:
+		return probeDNS(ctx, config, outbound, timeout)
+	case ProbeConfig_UDP:
+		return probeUDP(ctx, config, outbound, timeout)
+	default:
+		return 0, newError("unknown probe kind: ", config.Kind)
+	}
+}
+
+// ProbeAll runs each probe in configs against the outbound. The outbound is alive if all probes succeed, and its
+// delay is that of the first probe.
+func ProbeAll(ctx context.Context, configs []*ProbeConfig, outbound string, timeout time.Duration) *ProbeResult {
+	result := &ProbeResult{Alive: true}
+	for i, config := range configs {
+		kindResult := &ProbeKindResult{
+			Kind:        config.Kind,
+			Destination: config.Destination,
+		}
+		delay, err := Probe(ctx, config, outbound, timeout)
+		if err != nil {
+			kindResult.LastErrorReason = err.Error()
+			result.Alive = false
+			result.LastErrorReason = newError(config.Kind, " probe to ", config.Destination, " failed").Base(err).Error()
+		} else {
+			kindResult.Alive = true
+			kindResult.Delay = delay.Milliseconds()
+			if i == 0 {
+				result.Delay = kindResult.Delay
+			}
+		}
+		result.ProbeResult = append(result.ProbeResult, kindResult)
+	}
+	return result
+}
+
+func dialProbe(ctx context.Context, network v2net.Network, address string, defaultPort v2net.Port, outbound string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		if defaultPort == 0 {
+			return nil, newError("invalid probe destination: ", address).Base(err)
+		}
+		host, port = address, defaultPort.String()
+	}
+	dest, err := v2net.ParseDestination(network.SystemString() + ":" + net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, newError("invalid probe destination: ", address).Base(err)
+	}
+	return tagged.Dialer(ctx, dest, outbound)
+}
+
+func probeHTTP(ctx context.Context, config *ProbeConfig, outbound string, timeout time.Duration) (time.Duration, error) {
+	probeURL := config.Destination
+	if probeURL == "" {
+		probeURL = "https://api.v2fly.org/checkConnection.svgz"
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy: func(*http.Request) (*url.URL, error) {
+				return nil, nil
+			},
+			DialContext: func(_ context.Context, network, addr string) (net.Conn, error) {
+				dest, err := v2net.ParseDestination(network + ":" + addr)
+				if err != nil {
+					return nil, newError("cannot understand address").Base(err)
+				}
+				return tagged.Dialer(ctx, dest, outbound)
+			},
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+		Timeout: timeout,
+	}
+	start := time.Now()
+	response, err := client.Get(probeURL)
+	if err != nil {
+		return 0, err
+	}
+	response.Body.Close()
+	return time.Since(start), nil
+}
+
+// probeTCP measures the time to the first byte of response from the destination. A connection through a proxy
+// outbound is established locally before the proxy reaches the destination, so only a response proves it does.
+func probeTCP(ctx context.Context, config *ProbeConfig, outbound string, timeout time.Duration) (time.Duration, error) {
+	start := time.Now()
+	conn, err := dialProbe(ctx, v2net.Network_TCP, config.Destination, 0, outbound)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(start.Add(timeout))
+	if len(config.Payload) > 0 {
+		if _, err := conn.Write(config.Payload); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		return 0, newError("no response").Base(err)
+	}
+	return time.Since(start), nil
+}
+
+func probeTLS(ctx context.Context, config *ProbeConfig, outbound string, timeout time.Duration) (time.Duration, error) {
+	serverName := config.ServerName
+	if serverName == "" {
+		host, _, err := net.SplitHostPort(config.Destination)
+		if err != nil {
+			host = config.Destination
+		}
+		serverName = host
+	}
+
+	start := time.Now()
+	conn, err := dialProbe(ctx, v2net.Network_TCP, config.Destination, 443, outbound)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(start.Add(timeout))
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: serverName})
+	if err := tlsConn.Handshake(); err != nil {
+		return 0, newError("TLS handshake failed").Base(err)
+	}
+	return time.Since(start), nil
+}
+
+func probeDNS(ctx context.Context, config *ProbeConfig, outbound string, timeout time.Duration) (time.Duration, error) {
+	domain := config.Domain
+	if domain == "" {
+		domain = defaultProbeDomain
+	}
+	name, err := dnsmessage.NewName(dnsFQDN(domain))
+	if err != nil {
+		return 0, newError("invalid probe domain: ", domain).Base(err)
+	}
+	id := dice.RollUint16()
+	query, err := (&dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id, RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET}},
+	}).Pack()
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	conn, err := dialProbe(ctx, v2net.Network_UDP, config.Destination, 53, outbound)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(start.Add(timeout))
+	if _, err := conn.Write(query); err != nil {
+		return 0, err
+	}
+	b := make([]byte, 2048)
+	for {
+		n, err := conn.Read(b)
+		if err != nil {
+			return 0, newError("no DNS response").Base(err)
+		}
+		var parser dnsmessage.Parser
+		header, err := parser.Start(b[:n])
+		if err != nil || header.ID != id || !header.Response {
+			continue
+		}
+		if header.RCode != dnsmessage.RCodeSuccess && header.RCode != dnsmessage.RCodeNameError {
+			return 0, newError("DNS server responded ", header.RCode)
+		}
+		return time.Since(start), nil
+	}
+}
+
+func dnsFQDN(domain string) string {
+	if domain[len(domain)-1] == '.' {
+		return domain
+	}
+	return domain + "."
+}
+
+func probeUDP(ctx context.Context, config *ProbeConfig, outbound string, timeout time.Duration) (time.Duration, error) {
+	if len(config.Payload) == 0 {
+		return 0, newError("empty payload of UDP probe")
+	}
+	start := time.Now()
+	conn, err := dialProbe(ctx, v2net.Network_UDP, config.Destination, 0, outbound)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(start.Add(timeout))
+	if _, err := conn.Write(config.Payload); err != nil {
+		return 0, err
+	}
+	if _, err := conn.Read(make([]byte, 2048)); err != nil {
+		return 0, newError("no UDP response").Base(err)
+	}
+	return time.Since(start), nil
+}
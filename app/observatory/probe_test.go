@@ -0,0 +1,110 @@
+package observatory_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
+	"github.com/v2fly/v2ray-core/v5/common"
+	v2net "github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tagged"
+)
+
+func init() {
+	tagged.Dialer = func(ctx context.Context, dest v2net.Destination, tag string) (net.Conn, error) {
+		return net.Dial(dest.Network.SystemString(), dest.NetAddr())
+	}
+}
+
+func TestTCPProbe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+	// The server greets, stays silent, or closes the connection.
+	behavior := make(chan string, 1)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			switch <-behavior {
+			case "greet":
+				conn.Write([]byte("SSH-2.0-test\r\n"))
+				defer conn.Close()
+			case "silent":
+				defer conn.Close()
+			default:
+				conn.Close()
+			}
+		}
+	}()
+
+	probe := &observatory.ProbeConfig{Kind: observatory.ProbeConfig_TCP, Destination: listener.Addr().String()}
+	behavior <- "greet"
+	if _, err := observatory.Probe(context.Background(), probe, "proxy", 200*time.Millisecond); err != nil {
+		t.Error("expect TCP probe to succeed, got ", err)
+	}
+	behavior <- "silent"
+	if _, err := observatory.Probe(context.Background(), probe, "proxy", 200*time.Millisecond); err == nil {
+		t.Error("expect TCP probe to fail without response")
+	}
+	behavior <- "close"
+	if _, err := observatory.Probe(context.Background(), probe, "proxy", 200*time.Millisecond); err == nil {
+		t.Error("expect TCP probe to fail on closed connection")
+	}
+}
+
+func TestUDPAndDNSProbe(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	common.Must(err)
+	defer conn.Close()
+	go func() {
+		b := make([]byte, 2048)
+		for {
+			n, addr, err := conn.ReadFrom(b)
+			if err != nil {
+				return
+			}
+			var msg dnsmessage.Message
+			if err := msg.Unpack(b[:n]); err != nil {
+				// Not a DNS query, echo it back.
+				conn.WriteTo(b[:n], addr)
+				continue
+			}
+			msg.Header.Response = true
+			msg.Answers = []dnsmessage.Resource{{
+				Header: dnsmessage.ResourceHeader{Name: msg.Questions[0].Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET},
+				Body:   &dnsmessage.AResource{A: [4]byte{127, 0, 0, 1}},
+			}}
+			response, err := msg.Pack()
+			common.Must(err)
+			conn.WriteTo(response, addr)
+		}
+	}()
+
+	result := observatory.ProbeAll(context.Background(), []*observatory.ProbeConfig{
+		{Kind: observatory.ProbeConfig_DNS, Destination: conn.LocalAddr().String(), Domain: "example.com"},
+		{Kind: observatory.ProbeConfig_UDP, Destination: conn.LocalAddr().String(), Payload: []byte("ping")},
+	}, "proxy", time.Second)
+	if !result.Alive || len(result.ProbeResult) != 2 {
+		t.Fatal("expect all probes to succeed, got ", result.String())
+	}
+	for _, r := range result.ProbeResult {
+		if !r.Alive {
+			t.Error("expect ", r.Kind, " probe to succeed, got ", r.LastErrorReason)
+		}
+	}
+
+	result = observatory.ProbeAll(context.Background(), []*observatory.ProbeConfig{
+		{Kind: observatory.ProbeConfig_DNS, Destination: conn.LocalAddr().String()},
+		{Kind: observatory.ProbeConfig_UDP, Destination: conn.LocalAddr().String()},
+	}, "proxy", time.Second)
+	if result.Alive || !result.ProbeResult[0].Alive || result.ProbeResult[1].Alive {
+		t.Error("expect only UDP probe without payload to fail, got ", result.String())
+	}
+}
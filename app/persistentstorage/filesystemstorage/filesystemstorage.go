@@ -0,0 +1,153 @@
+package filesystemstorage
+
+//go:generate go run github.com/v2fly/v2ray-core/v5/common/errors/errorgen
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/features/extension"
+)
+
+// Storage is an implementation of extension.PersistentStorageEngine. Each value is stored in its own file under the
+// configured directory, named after the SHA-256 hash of the key, as keys may be too long for file names. The file
+// holds the varint encoded length of the key, the key and then the value.
+type Storage struct {
+	path string
+
+	access sync.Mutex
+}
+
+// New creates a new Storage with config.
+func New(ctx context.Context, config *Config) (*Storage, error) {
+	if config.Path == "" {
+		return nil, newError("path of filesystem storage is not set")
+	}
+	if err := os.MkdirAll(config.Path, 0o700); err != nil {
+		return nil, newError("failed to create directory ", config.Path).Base(err)
+	}
+	return &Storage{path: config.Path}, nil
+}
+
+// Type implements common.HasType.
+func (s *Storage) Type() interface{} {
+	return extension.PersistentStorageEngineType()
+}
+
+// Start implements common.Runnable.
+func (s *Storage) Start() error {
+	return nil
+}
+
+// Close implements common.Closable.
+func (s *Storage) Close() error {
+	return nil
+}
+
+// PersistentStorageEngine implements extension.PersistentStorageEngine.
+func (s *Storage) PersistentStorageEngine() {}
+
+func (s *Storage) fileName(key []byte) string {
+	hash := sha256.Sum256(key)
+	return filepath.Join(s.path, hex.EncodeToString(hash[:]))
+}
+
+func (s *Storage) readFile(name string) (key []byte, value []byte, err error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyLen, n := binary.Uvarint(content)
+	if n <= 0 || keyLen > uint64(len(content)-n) {
+		return nil, nil, newError("malformed file ", name)
+	}
+	content = content[n:]
+	return content[:keyLen], content[keyLen:], nil
+}
+
+// Put implements extension.PersistentStorageEngine.
+func (s *Storage) Put(ctx context.Context, key []byte, value []byte) error {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	name := s.fileName(key)
+	if value == nil {
+		if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+			return newError("failed to remove ", name).Base(err)
+		}
+		return nil
+	}
+	// Write to a temporary file first, so that a crash does not leave a partial value behind.
+	temp, err := os.CreateTemp(s.path, ".tmp-")
+	if err != nil {
+		return newError("failed to create temporary file").Base(err)
+	}
+	header := make([]byte, binary.MaxVarintLen64)
+	header = header[:binary.PutUvarint(header, uint64(len(key)))]
+	_, err = temp.Write(append(append(header, key...), value...))
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temp.Name(), name)
+	}
+	if err != nil {
+		os.Remove(temp.Name())
+		return newError("failed to write ", name).Base(err)
+	}
+	return nil
+}
+
+// Get implements extension.PersistentStorageEngine.
+func (s *Storage) Get(ctx context.Context, key []byte) ([]byte, error) {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	storedKey, value, err := s.readFile(s.fileName(key))
+	if err != nil {
+		return nil, newError("failed to read value").Base(err)
+	}
+	if !bytes.Equal(storedKey, key) {
+		return nil, newError("key not found")
+	}
+	return value, nil
+}
+
+// List implements extension.PersistentStorageEngine.
+func (s *Storage) List(ctx context.Context, keyPrefix []byte) ([][]byte, error) {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	entries, err := os.ReadDir(s.path)
+	if err != nil {
+		return nil, newError("failed to list ", s.path).Base(err)
+	}
+	var keys [][]byte
+	for _, entry := range entries {
+		// Skip temporary files and those not written by the storage.
+		if entry.IsDir() || len(entry.Name()) != sha256.Size*2 {
+			continue
+		}
+		key, _, err := s.readFile(filepath.Join(s.path, entry.Name()))
+		if err != nil {
+			continue
+		}
+		if bytes.HasPrefix(key, keyPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return New(ctx, config.(*Config))
+	}))
+}
@@ -0,0 +1,93 @@
+package filesystemstorage_test
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v5/app/persistentstorage/filesystemstorage"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/environment/persistentstorageimpl"
+	"github.com/v2fly/v2ray-core/v5/features/extension"
+)
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+	engine, err := filesystemstorage.New(ctx, &filesystemstorage.Config{Path: path})
+	common.Must(err)
+
+	common.Must(engine.Put(ctx, []byte("a"), []byte("1")))
+	common.Must(engine.Put(ctx, []byte("ab"), []byte("2")))
+	common.Must(engine.Put(ctx, []byte("b"), []byte{}))
+
+	// Values survive a new engine on the same path.
+	engine, err = filesystemstorage.New(ctx, &filesystemstorage.Config{Path: path})
+	common.Must(err)
+	if value, err := engine.Get(ctx, []byte("ab")); err != nil || string(value) != "2" {
+		t.Error("unexpected value: ", string(value), err)
+	}
+	if value, err := engine.Get(ctx, []byte("b")); err != nil || len(value) != 0 {
+		t.Error("unexpected value: ", value, err)
+	}
+	keys, err := engine.List(ctx, []byte("a"))
+	common.Must(err)
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	if len(keys) != 2 || string(keys[0]) != "a" || string(keys[1]) != "ab" {
+		t.Error("unexpected keys: ", keys)
+	}
+
+	common.Must(engine.Put(ctx, []byte("a"), nil))
+	if _, err := engine.Get(ctx, []byte("a")); err == nil {
+		t.Error("expect removed key not to be found")
+	}
+}
+
+func TestScopedStorage(t *testing.T) {
+	ctx := context.Background()
+	engine, err := filesystemstorage.New(ctx, &filesystemstorage.Config{Path: t.TempDir()})
+	common.Must(err)
+	root := persistentstorageimpl.NewScopedPersistentStorageImpl(func() extension.PersistentStorageEngine {
+		return engine
+	})
+
+	app, err := root.NarrowScope(ctx, []byte("app"))
+	common.Must(err)
+	member, err := app.NarrowScope(ctx, []byte("member"))
+	common.Must(err)
+	common.Must(root.Put(ctx, []byte("key"), []byte("root")))
+	common.Must(app.Put(ctx, []byte("key"), []byte("app")))
+	common.Must(member.Put(ctx, []byte("key"), []byte("member")))
+
+	for _, c := range []struct {
+		scope interface {
+			Get(context.Context, []byte) ([]byte, error)
+		}
+		expected string
+	}{{root, "root"}, {app, "app"}, {member, "member"}} {
+		if value, err := c.scope.Get(ctx, []byte("key")); err != nil || string(value) != c.expected {
+			t.Error("expect ", c.expected, ", got ", string(value), err)
+		}
+	}
+	keys, err := app.List(ctx, nil)
+	common.Must(err)
+	if len(keys) != 1 || string(keys[0]) != "key" {
+		t.Error("expect keys of nested scopes not to be listed, got ", keys)
+	}
+
+	common.Must(root.DropScope(ctx, []byte("app")))
+	if _, err := member.Get(ctx, []byte("key")); err == nil {
+		t.Error("expect values of dropped scope to be removed")
+	}
+	if _, err := root.Get(ctx, []byte("key")); err != nil {
+		t.Error("expect values of root scope to be kept, got ", err)
+	}
+
+	unconfigured := persistentstorageimpl.NewScopedPersistentStorageImpl(func() extension.PersistentStorageEngine {
+		return nil
+	})
+	if err := unconfigured.Put(ctx, []byte("key"), []byte("value")); err == nil {
+		t.Error("expect error without engine")
+	}
+}
@@ -88,6 +88,10 @@ func (p *SystemPolicy) ToCorePolicy() policy.System {
 			InboundDownlink:  p.Stats.InboundDownlink,
 			OutboundUplink:   p.Stats.OutboundUplink,
 			OutboundDownlink: p.Stats.OutboundDownlink,
+			RuleHit:          p.Stats.RuleHit,
+			RuleUplink:       p.Stats.RuleUplink,
+			RuleDownlink:     p.Stats.RuleDownlink,
+			DNSServer:        p.Stats.DnsServer,
 		},
 	}
 }
@@ -37,3 +37,10 @@ func (c *ReceiverConfig) GetEffectiveSniffingSettings() *SniffingConfig {
 
 	return nil
 }
+
+func (c *DemuxConfig) GetPeekTimeoutValue() uint32 {
+	if c == nil || c.PeekTimeout == 0 {
+		return 300
+	}
+	return c.PeekTimeout
+}
@@ -123,6 +123,7 @@ func NewAlwaysOnInboundHandler(ctx context.Context, tag string, receiverConfig *
 					sniffingConfig:  receiverConfig.GetEffectiveSniffingSettings(),
 					uplinkCounter:   uplinkCounter,
 					downlinkCounter: downlinkCounter,
+					demux:           receiverConfig.Demux,
 					ctx:             ctx,
 				}
 				h.workers = append(h.workers, worker)
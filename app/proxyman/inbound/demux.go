@@ -0,0 +1,386 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/app/proxyman"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/protocol/http"
+	"github.com/v2fly/v2ray-core/v5/common/protocol/tls"
+	"github.com/v2fly/v2ray-core/v5/common/signal/done"
+	"github.com/v2fly/v2ray-core/v5/transport/internet"
+)
+
+const demuxPeekSize = 4096
+
+// demuxHeader is what a demux hub learns from the first bytes of a connection.
+type demuxHeader struct {
+	protocol   string
+	serverName string
+	alpn       []string
+	host       string
+	path       string
+}
+
+// sniffDemuxHeader inspects the first bytes of a connection. It returns common.ErrNoClue
+// if more bytes are needed to tell the protocol or its metadata.
+func sniffDemuxHeader(b []byte) (*demuxHeader, error) {
+	if len(b) == 0 {
+		return nil, common.ErrNoClue
+	}
+
+	switch b[0] {
+	case 0x16:
+		tlsHeader, err := tls.SniffTLS(b)
+		if err == common.ErrNoClue {
+			return nil, err
+		}
+		h := &demuxHeader{protocol: "tls"}
+		if err == nil {
+			h.serverName = tlsHeader.Domain()
+			h.alpn = tlsHeader.ALPN()
+		}
+		return h, nil
+	case 0x04, 0x05:
+		return &demuxHeader{protocol: "socks"}, nil
+	}
+
+	if len(b) < 4 && bytes.HasPrefix([]byte("SSH-"), b) {
+		return nil, common.ErrNoClue
+	}
+	if bytes.HasPrefix(b, []byte("SSH-")) {
+		return &demuxHeader{protocol: "ssh"}, nil
+	}
+
+	httpHeader, err := http.SniffHTTP(b)
+	switch {
+	case err == nil:
+		h := &demuxHeader{
+			protocol: "http",
+			host:     httpHeader.Domain(),
+		}
+		if i := bytes.IndexByte(b, '\n'); i > 0 {
+			if parts := strings.Fields(string(b[:i])); len(parts) >= 2 {
+				h.path = parts[1]
+			}
+		}
+		return h, nil
+	case err == common.ErrNoClue && !bytes.Contains(b, []byte("\r\n\r\n")):
+		return nil, err
+	case err == common.ErrNoClue:
+		// A complete request without Host header.
+		return &demuxHeader{protocol: "http"}, nil
+	}
+
+	return &demuxHeader{}, nil
+}
+
+func matchDemuxDomain(patterns []string, domain string) bool {
+	domain = strings.ToLower(domain)
+	for _, p := range patterns {
+		p = strings.ToLower(p)
+		if p == domain {
+			return true
+		}
+		if strings.HasPrefix(p, ".") && (domain == p[1:] || strings.HasSuffix(domain, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchDemuxString(patterns []string, values ...string) bool {
+	for _, p := range patterns {
+		for _, v := range values {
+			if strings.EqualFold(p, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// demuxMember is one inbound handler attached to a shared port. It acts as the
+// system listener of the handler's transport, so the transport receives the
+// connections routed to this handler as if it was listening on its own.
+type demuxMember struct {
+	tag    string
+	config *proxyman.DemuxConfig
+	hub    *demuxHub
+	conns  chan net.Conn
+	done   *done.Instance
+}
+
+func newDemuxMember(tag string, config *proxyman.DemuxConfig) *demuxMember {
+	return &demuxMember{
+		tag:    tag,
+		config: config,
+		conns:  make(chan net.Conn),
+		done:   done.New(),
+	}
+}
+
+// rules returns the number of conditions of the member. A member without conditions is a fallback.
+func (m *demuxMember) rules() int {
+	n := 0
+	for _, r := range [][]string{m.config.ServerName, m.config.Alpn, m.config.Host, m.config.Path, m.config.Protocol} {
+		if len(r) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func (m *demuxMember) match(h *demuxHeader) bool {
+	c := m.config
+	if len(c.Protocol) > 0 && !matchDemuxString(c.Protocol, h.protocol) {
+		return false
+	}
+	if len(c.ServerName) > 0 && (h.protocol != "tls" || !matchDemuxDomain(c.ServerName, h.serverName)) {
+		return false
+	}
+	if len(c.Alpn) > 0 && (h.protocol != "tls" || !matchDemuxString(c.Alpn, h.alpn...)) {
+		return false
+	}
+	if len(c.Host) > 0 && (h.protocol != "http" || !matchDemuxDomain(c.Host, h.host)) {
+		return false
+	}
+	if len(c.Path) > 0 {
+		if h.protocol != "http" {
+			return false
+		}
+		matched := false
+		for _, p := range c.Path {
+			if strings.HasPrefix(h.path, p) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// Listen implements internet.SystemListener.
+func (m *demuxMember) Listen(ctx context.Context, addr net.Addr, sockopt *internet.SocketConfig) (net.Listener, error) {
+	if _, ok := addr.(*net.TCPAddr); !ok {
+		return nil, newError("demux is only supported on TCP addresses: ", addr)
+	}
+	hub, err := joinDemuxHub(addr, sockopt, m)
+	if err != nil {
+		return nil, err
+	}
+	m.hub = hub
+	return m, nil
+}
+
+// ListenPacket implements internet.SystemListener.
+func (m *demuxMember) ListenPacket(ctx context.Context, addr net.Addr, sockopt *internet.SocketConfig) (net.PacketConn, error) {
+	return internet.ListenSystemPacket(context.Background(), addr, sockopt)
+}
+
+// Accept implements net.Listener.
+func (m *demuxMember) Accept() (net.Conn, error) {
+	select {
+	case conn := <-m.conns:
+		return conn, nil
+	case <-m.done.Wait():
+		return nil, newError("demux listener closed")
+	}
+}
+
+// Addr implements net.Listener.
+func (m *demuxMember) Addr() net.Addr {
+	return m.hub.listener.Addr()
+}
+
+// Close implements net.Listener.
+func (m *demuxMember) Close() error {
+	if m.done.Done() {
+		return nil
+	}
+	common.Must(m.done.Close())
+	return m.hub.leave(m)
+}
+
+func (m *demuxMember) deliver(conn net.Conn) bool {
+	select {
+	case m.conns <- conn:
+		return true
+	case <-m.done.Wait():
+		return false
+	}
+}
+
+// demuxHub owns a listening socket shared by several inbound handlers.
+type demuxHub struct {
+	sync.RWMutex
+	key      string
+	listener net.Listener
+	members  []*demuxMember
+}
+
+var (
+	demuxHubsAccess sync.Mutex
+	demuxHubs       = make(map[string]*demuxHub)
+)
+
+func joinDemuxHub(addr net.Addr, sockopt *internet.SocketConfig, m *demuxMember) (*demuxHub, error) {
+	demuxHubsAccess.Lock()
+	defer demuxHubsAccess.Unlock()
+
+	key := addr.String()
+	hub, found := demuxHubs[key]
+	if !found {
+		listener, err := internet.ListenSystem(context.Background(), addr, sockopt)
+		if err != nil {
+			return nil, err
+		}
+		hub = &demuxHub{
+			key:      key,
+			listener: listener,
+		}
+		demuxHubs[key] = hub
+		go hub.keepAccepting()
+		newError("listening demux TCP on ", key).WriteToLog()
+	}
+
+	hub.Lock()
+	defer hub.Unlock()
+	hub.members = append(hub.members, m)
+	// More specific members are tried first. Ties are broken by tag so that the result does not depend on start order.
+	sort.SliceStable(hub.members, func(i, j int) bool {
+		ri, rj := hub.members[i].rules(), hub.members[j].rules()
+		if ri != rj {
+			return ri > rj
+		}
+		return hub.members[i].tag < hub.members[j].tag
+	})
+	return hub, nil
+}
+
+func (h *demuxHub) leave(m *demuxMember) error {
+	demuxHubsAccess.Lock()
+	defer demuxHubsAccess.Unlock()
+
+	h.Lock()
+	defer h.Unlock()
+	for i, member := range h.members {
+		if member == m {
+			h.members = append(h.members[:i], h.members[i+1:]...)
+			break
+		}
+	}
+	if len(h.members) > 0 {
+		return nil
+	}
+	delete(demuxHubs, h.key)
+	return h.listener.Close()
+}
+
+func (h *demuxHub) keepAccepting() {
+	for {
+		conn, err := h.listener.Accept()
+		if err != nil {
+			errStr := err.Error()
+			if strings.Contains(errStr, "closed") {
+				break
+			}
+			newError("failed to accept raw connections").Base(err).AtWarning().WriteToLog()
+			if strings.Contains(errStr, "too many") {
+				time.Sleep(time.Millisecond * 500)
+			}
+			continue
+		}
+		go h.dispatch(conn)
+	}
+}
+
+func (h *demuxHub) peekTimeout() time.Duration {
+	h.RLock()
+	defer h.RUnlock()
+
+	var timeout uint32
+	for _, m := range h.members {
+		if t := m.config.GetPeekTimeoutValue(); t > timeout {
+			timeout = t
+		}
+	}
+	return time.Duration(timeout) * time.Millisecond
+}
+
+func (h *demuxHub) peek(conn net.Conn) ([]byte, *demuxHeader) {
+	payload := make([]byte, 0, demuxPeekSize)
+	if err := conn.SetReadDeadline(time.Now().Add(h.peekTimeout())); err != nil {
+		return payload, &demuxHeader{}
+	}
+	defer conn.SetReadDeadline(time.Time{})
+
+	for len(payload) < cap(payload) {
+		n, err := conn.Read(payload[len(payload):cap(payload)])
+		payload = payload[:len(payload)+n]
+		header, sniffErr := sniffDemuxHeader(payload)
+		if sniffErr == nil {
+			return payload, header
+		}
+		if err != nil {
+			break
+		}
+	}
+	if len(payload) > 0 && payload[0] == 0x16 {
+		return payload, &demuxHeader{protocol: "tls"}
+	}
+	return payload, &demuxHeader{}
+}
+
+func (h *demuxHub) pick(header *demuxHeader) *demuxMember {
+	h.RLock()
+	defer h.RUnlock()
+
+	var fallback *demuxMember
+	for _, m := range h.members {
+		if m.rules() == 0 {
+			if fallback == nil {
+				fallback = m
+			}
+			continue
+		}
+		if m.match(header) {
+			return m
+		}
+	}
+	return fallback
+}
+
+func (h *demuxHub) dispatch(conn net.Conn) {
+	payload, header := h.peek(conn)
+	member := h.pick(header)
+	if member == nil {
+		newError("no inbound accepts connection from ", conn.RemoteAddr(), " on ", h.key, " (protocol: ", header.protocol, ")").AtInfo().WriteToLog()
+		conn.Close()
+		return
+	}
+	newError("demux connection from ", conn.RemoteAddr(), " to [", member.tag, "]").AtDebug().WriteToLog()
+	if !member.deliver(&peekedConn{Conn: conn, reader: io.MultiReader(bytes.NewReader(payload), conn)}) {
+		conn.Close()
+	}
+}
+
+// peekedConn is a net.Conn that replays the peeked bytes before reading from the underlying connection.
+type peekedConn struct {
+	net.Conn
+	reader io.Reader
+}
+
+func (c *peekedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
@@ -0,0 +1,125 @@
+package inbound
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/app/proxyman"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/testing/servers/tcp"
+)
+
+func TestSniffDemuxHeader(t *testing.T) {
+	cases := []struct {
+		input    string
+		protocol string
+		host     string
+		path     string
+		noClue   bool
+	}{
+		{input: "SSH-2.0-OpenSSH_8.9\r\n", protocol: "ssh"},
+		{input: "SS", noClue: true},
+		{input: "\x05\x01\x00", protocol: "socks"},
+		{input: "GET /ws/chat HTTP/1.1\r\nHost: example.com\r\n\r\n", protocol: "http", host: "example.com", path: "/ws/chat"},
+		{input: "GET / HTTP/1.1\r\nUser-Agent: curl", noClue: true},
+		{input: "\x16\x03\x01\x00", noClue: true},
+		{input: "hello world", protocol: ""},
+	}
+
+	for _, c := range cases {
+		h, err := sniffDemuxHeader([]byte(c.input))
+		if c.noClue {
+			if err != common.ErrNoClue {
+				t.Error("expect no clue for ", c.input, " but got ", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.protocol != c.protocol || h.host != c.host || h.path != c.path {
+			t.Error("unexpected header for ", c.input, ": ", h.protocol, " ", h.host, " ", h.path)
+		}
+	}
+}
+
+func TestDemuxMemberMatch(t *testing.T) {
+	m := newDemuxMember("tls", &proxyman.DemuxConfig{
+		Enabled:    true,
+		ServerName: []string{".example.com"},
+		Alpn:       []string{"h2"},
+	})
+	if !m.match(&demuxHeader{protocol: "tls", serverName: "www.example.com", alpn: []string{"h2", "http/1.1"}}) {
+		t.Error("expect subdomain with h2 to match")
+	}
+	if !m.match(&demuxHeader{protocol: "tls", serverName: "example.com", alpn: []string{"h2"}}) {
+		t.Error("expect domain itself to match")
+	}
+	if m.match(&demuxHeader{protocol: "tls", serverName: "www.example.com", alpn: []string{"http/1.1"}}) {
+		t.Error("expect mismatched alpn not to match")
+	}
+	if m.match(&demuxHeader{protocol: "http", host: "www.example.com"}) {
+		t.Error("expect http not to match server name rule")
+	}
+}
+
+func TestDemuxListener(t *testing.T) {
+	port := tcp.PickPort()
+	addr := &net.TCPAddr{IP: net.LocalHostIP.IP(), Port: int(port)}
+
+	ssh := newDemuxMember("ssh", &proxyman.DemuxConfig{Enabled: true, Protocol: []string{"ssh"}})
+	fallback := newDemuxMember("fallback", &proxyman.DemuxConfig{Enabled: true, PeekTimeout: 100})
+
+	sshListener, err := ssh.Listen(context.Background(), addr, nil)
+	common.Must(err)
+	fallbackListener, err := fallback.Listen(context.Background(), addr, nil)
+	common.Must(err)
+
+	accept := func(l net.Listener) <-chan string {
+		ch := make(chan string, 1)
+		go func() {
+			conn, err := l.Accept()
+			if err != nil {
+				close(ch)
+				return
+			}
+			defer conn.Close()
+			b := make([]byte, 7)
+			if _, err := io.ReadFull(conn, b); err != nil {
+				close(ch)
+				return
+			}
+			ch <- string(b)
+		}()
+		return ch
+	}
+
+	dial := func(payload string) {
+		conn, err := net.Dial("tcp", addr.String())
+		common.Must(err)
+		defer conn.Close()
+		common.Must2(conn.Write([]byte(payload)))
+		time.Sleep(time.Millisecond * 200)
+	}
+
+	sshCh := accept(sshListener)
+	dial("SSH-2.0")
+	if r := <-sshCh; r != "SSH-2.0" {
+		t.Error("expect ssh connection on ssh listener, but got ", r)
+	}
+
+	fallbackCh := accept(fallbackListener)
+	dial("foo-bar")
+	if r := <-fallbackCh; r != "foo-bar" {
+		t.Error("expect unknown connection on fallback listener, but got ", r)
+	}
+
+	common.Must(sshListener.Close())
+	common.Must(fallbackListener.Close())
+	if _, found := demuxHubs[addr.String()]; found {
+		t.Error("expect hub to be released after all listeners closed")
+	}
+}
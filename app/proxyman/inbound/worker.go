@@ -42,6 +42,7 @@ type tcpWorker struct {
 	sniffingConfig  *proxyman.SniffingConfig
 	uplinkCounter   stats.Counter
 	downlinkCounter stats.Counter
+	demux           *proxyman.DemuxConfig
 
 	hub internet.Listener
 
@@ -119,6 +120,9 @@ func (w *tcpWorker) Start() error {
 	netNamespace := session.GetNetNamespaceFromContext(w.ctx)
 
 	ctx := context.Background()
+	if w.demux.GetEnabled() {
+		ctx = internet.ContextWithSystemListener(ctx, newDemuxMember(w.tag, w.demux))
+	}
 
 	if netNamespace == "" {
 		hub, err = internet.ListenTCP(ctx, w.address, w.port, w.stream, func(conn internet.Connection) {
@@ -0,0 +1,55 @@
+package outbound
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+
+	"github.com/v2fly/v2ray-core/v5/common/errors"
+	"github.com/v2fly/v2ray-core/v5/features/extension"
+	"github.com/v2fly/v2ray-core/v5/transport/internet"
+)
+
+// circuitReporter reports the result of a connection to the server of an outbound to the circuit breaker, at most
+// once.
+type circuitReporter struct {
+	ctx      context.Context
+	tag      string
+	breaker  extension.CircuitBreaker
+	reported int32
+}
+
+// report reports err, unless the connection was canceled by the inbound side.
+func (r *circuitReporter) report(err error) {
+	if err != nil && r.ctx.Err() != nil {
+		return
+	}
+	if atomic.CompareAndSwapInt32(&r.reported, 0, 1) {
+		r.breaker.ReportResult(r.tag, err)
+	}
+}
+
+// circuitConnection is a connection to the server of an outbound. It succeeds once the server sends back any data,
+// and fails if reading from it fails before that, such as in a failed handshake. An EOF is not a failure, as servers
+// close connections they can't reach the destination of.
+type circuitConnection struct {
+	internet.Connection
+	reporter *circuitReporter
+	closed   int32
+}
+
+func (c *circuitConnection) Read(b []byte) (int, error) {
+	n, err := c.Connection.Read(b)
+	switch {
+	case n > 0:
+		c.reporter.report(nil)
+	case err != nil && errors.Cause(err) != io.EOF && atomic.LoadInt32(&c.closed) == 0:
+		c.reporter.report(err)
+	}
+	return n, err
+}
+
+func (c *circuitConnection) Close() error {
+	atomic.StoreInt32(&c.closed, 1)
+	return c.Connection.Close()
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/v2fly/v2ray-core/v5/common/net/packetaddr"
 	"github.com/v2fly/v2ray-core/v5/common/serial"
 	"github.com/v2fly/v2ray-core/v5/common/session"
+	"github.com/v2fly/v2ray-core/v5/features/dns"
+	"github.com/v2fly/v2ray-core/v5/features/extension"
 	"github.com/v2fly/v2ray-core/v5/features/outbound"
 	"github.com/v2fly/v2ray-core/v5/features/policy"
 	"github.com/v2fly/v2ray-core/v5/features/stats"
@@ -50,12 +52,15 @@ func getStatCounter(v *core.Instance, tag string) (stats.Counter, stats.Counter)
 type Handler struct {
 	tag             string
 	senderSettings  *proxyman.SenderConfig
+	viaPrefix       *viaPrefix
 	streamSettings  *internet.MemoryStreamConfig
 	proxy           proxy.Outbound
 	outboundManager outbound.Manager
 	mux             *mux.ClientManager
 	uplinkCounter   stats.Counter
 	downlinkCounter stats.Counter
+	circuitBreaker  extension.CircuitBreaker
+	dns             dns.Client
 }
 
 // NewHandler create a new Handler based on the given configuration.
@@ -68,6 +73,15 @@ func NewHandler(ctx context.Context, config *core.OutboundHandlerConfig) (outbou
 		uplinkCounter:   uplinkCounter,
 		downlinkCounter: downlinkCounter,
 	}
+	if breaker, ok := v.GetFeature(extension.CircuitBreakerType()).(extension.CircuitBreaker); ok {
+		h.circuitBreaker = breaker
+	}
+	if err := core.RequireFeatures(ctx, func(d dns.Client) error {
+		h.dns = d
+		return nil
+	}); err != nil {
+		return nil, err
+	}
 
 	if config.SenderSettings != nil {
 		senderSettings, err := serial.GetInstanceOf(config.SenderSettings)
@@ -82,6 +96,18 @@ func NewHandler(ctx context.Context, config *core.OutboundHandlerConfig) (outbou
 				return nil, newError("failed to parse stream settings").Base(err).AtWarning()
 			}
 			h.streamSettings = mss
+			if len(s.ViaCidr) > 0 {
+				prefix, err := newViaPrefix(s.ViaCidr, s.ViaStrategy)
+				if err != nil {
+					return nil, err
+				}
+				h.viaPrefix = prefix
+				// Addresses in a routed prefix are usually not assigned to any interface.
+				if mss.SocketSettings == nil {
+					mss.SocketSettings = &internet.SocketConfig{}
+				}
+				mss.SocketSettings.FreeBind = true
+			}
 		default:
 			return nil, newError("settings is not SenderConfig")
 		}
@@ -124,6 +150,10 @@ func NewHandler(ctx context.Context, config *core.OutboundHandlerConfig) (outbou
 	}
 
 	h.proxy = proxyHandler
+	if d, ok := proxyHandler.(proxy.DestinationDialer); ok && d.DialsDestination() {
+		// Failures to connect to destinations are not failures of the outbound.
+		h.circuitBreaker = nil
+	}
 	return h, nil
 }
 
@@ -157,6 +187,9 @@ func (h *Handler) Dispatch(ctx context.Context, link *transport.Link) {
 
 // Address implements internet.Dialer.
 func (h *Handler) Address() net.Address {
+	if h.viaPrefix != nil {
+		return h.viaPrefix.Address()
+	}
 	if h.senderSettings == nil || h.senderSettings.Via == nil {
 		return nil
 	}
@@ -193,13 +226,18 @@ func (h *Handler) Dial(ctx context.Context, dest net.Destination) (internet.Conn
 			newError("failed to get outbound handler with tag: ", tag).AtWarning().WriteToLog(session.ExportIDToError(ctx))
 		}
 
-		if h.senderSettings.Via != nil {
+		if h.viaPrefix != nil || h.senderSettings.Via != nil {
 			outbound := session.OutboundFromContext(ctx)
 			if outbound == nil {
 				outbound = new(session.Outbound)
 				ctx = session.ContextWithOutbound(ctx, outbound)
 			}
-			outbound.Gateway = h.senderSettings.Via.AsAddress()
+			if h.viaPrefix != nil {
+				outbound.Gateway = h.viaPrefix.Pick(ctx, dest)
+				newError("sending through ", outbound.Gateway, " for dest ", dest).AtDebug().WriteToLog(session.ExportIDToError(ctx))
+			} else {
+				outbound.Gateway = h.senderSettings.Via.AsAddress()
+			}
 		}
 	}
 	enablePacketAddrCapture := true
@@ -219,7 +257,18 @@ func (h *Handler) Dial(ctx context.Context, dest net.Destination) (internet.Conn
 		return h.getStatCouterConnection(conn), nil
 	}
 
+	if h.dns != nil {
+		ctx = internet.ContextWithDNSClient(ctx, h.dns)
+	}
 	conn, err := internet.Dial(ctx, dest, h.streamSettings)
+	if h.circuitBreaker != nil {
+		reporter := &circuitReporter{ctx: ctx, tag: h.tag, breaker: h.circuitBreaker}
+		if err != nil {
+			reporter.report(err)
+		} else {
+			conn = &circuitConnection{Connection: conn, reporter: reporter}
+		}
+	}
 	return h.getStatCouterConnection(conn), err
 }
 
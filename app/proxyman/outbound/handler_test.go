@@ -2,9 +2,12 @@ package outbound_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	_ "unsafe"
 
+	"github.com/golang/protobuf/proto"
+
 	"google.golang.org/protobuf/types/known/anypb"
 
 	core "github.com/v2fly/v2ray-core/v5"
@@ -13,7 +16,9 @@ import (
 	"github.com/v2fly/v2ray-core/v5/app/stats"
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/common/serial"
+	"github.com/v2fly/v2ray-core/v5/features/extension"
 	"github.com/v2fly/v2ray-core/v5/features/outbound"
+	"github.com/v2fly/v2ray-core/v5/proxy/blackhole"
 	"github.com/v2fly/v2ray-core/v5/proxy/freedom"
 	"github.com/v2fly/v2ray-core/v5/transport/internet"
 )
@@ -82,3 +87,66 @@ func TestOutboundWithStatCounter(t *testing.T) {
 		t.Errorf("Expected conn to be StatCouterConnection")
 	}
 }
+
+type recordingCircuitBreaker struct {
+	results []error
+}
+
+func (*recordingCircuitBreaker) Type() interface{} { return extension.CircuitBreakerType() }
+func (*recordingCircuitBreaker) Start() error      { return nil }
+func (*recordingCircuitBreaker) Close() error      { return nil }
+func (*recordingCircuitBreaker) Allow(string) bool { return true }
+func (*recordingCircuitBreaker) Admit(string) bool { return true }
+
+func (b *recordingCircuitBreaker) ReportResult(tag string, err error) {
+	b.results = append(b.results, err)
+}
+
+func (*recordingCircuitBreaker) GetCircuitStatus(context.Context) (proto.Message, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestOutboundCircuitReport(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedDest := net.DestinationFromAddr(listener.Addr())
+	listener.Close()
+
+	v, _ := core.New(&core.Config{})
+	v.AddFeature((outbound.Manager)(new(Manager)))
+	breaker := new(recordingCircuitBreaker)
+	v.AddFeature(breaker)
+	ctx := toContext(context.Background(), v)
+
+	// Failures to reach destinations directly are not reported.
+	direct, err := NewHandler(ctx, &core.OutboundHandlerConfig{
+		Tag:           "direct",
+		ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := direct.(*Handler).Dial(ctx, closedDest); err == nil {
+		t.Fatal("expect dial to fail")
+	}
+	if len(breaker.results) != 0 {
+		t.Error("expect no result reported for direct outbound, got ", breaker.results)
+	}
+
+	// Failures to reach servers are reported.
+	proxy, err := NewHandler(ctx, &core.OutboundHandlerConfig{
+		Tag:           "proxy",
+		ProxySettings: serial.ToTypedMessage(&blackhole.Config{}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := proxy.(*Handler).Dial(ctx, closedDest); err == nil {
+		t.Fatal("expect dial to fail")
+	}
+	if len(breaker.results) != 1 || breaker.results[0] == nil {
+		t.Error("expect failure reported for proxy outbound, got ", breaker.results)
+	}
+}
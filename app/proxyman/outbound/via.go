@@ -0,0 +1,61 @@
+package outbound
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+
+	"github.com/v2fly/v2ray-core/v5/app/proxyman"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/session"
+)
+
+// viaPrefix picks the source address of each connection from a CIDR.
+type viaPrefix struct {
+	network  *net.IPNet
+	strategy proxyman.SenderConfig_ViaStrategy
+}
+
+func newViaPrefix(cidr string, strategy proxyman.SenderConfig_ViaStrategy) (*viaPrefix, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, newError("invalid CIDR to send through: ", cidr).Base(err)
+	}
+	return &viaPrefix{
+		network:  network,
+		strategy: strategy,
+	}, nil
+}
+
+// Address returns the network address of the prefix. It tells the address family of picked addresses.
+func (p *viaPrefix) Address() net.Address {
+	return net.IPAddress(p.network.IP)
+}
+
+// Pick returns an address in the prefix for a connection to dest.
+func (p *viaPrefix) Pick(ctx context.Context, dest net.Destination) net.Address {
+	var seed string
+	switch p.strategy {
+	case proxyman.SenderConfig_UserHash:
+		if inbound := session.InboundFromContext(ctx); inbound != nil && inbound.User != nil {
+			seed = inbound.User.Email
+		}
+	case proxyman.SenderConfig_DestinationHash:
+		seed = dest.Address.String()
+	}
+
+	host := make([]byte, len(p.network.IP))
+	if len(seed) > 0 {
+		sum := sha256.Sum256([]byte(seed))
+		copy(host, sum[:])
+	} else {
+		common.Must2(rand.Read(host))
+	}
+
+	ip := make(net.IP, len(p.network.IP))
+	for i := range ip {
+		ip[i] = p.network.IP[i] | host[i]&^p.network.Mask[i]
+	}
+	return net.IPAddress(ip)
+}
@@ -0,0 +1,56 @@
+package outbound
+
+import (
+	"context"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v5/app/proxyman"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/protocol"
+	"github.com/v2fly/v2ray-core/v5/common/session"
+)
+
+func TestViaPrefixPick(t *testing.T) {
+	_, network, err := net.ParseCIDR("2001:db8:1:2::/64")
+	common.Must(err)
+	dest := net.TCPDestination(net.DomainAddress("example.com"), 443)
+
+	random, err := newViaPrefix("2001:db8:1:2::/64", proxyman.SenderConfig_Random)
+	common.Must(err)
+	a1 := random.Pick(context.Background(), dest)
+	a2 := random.Pick(context.Background(), dest)
+	if !network.Contains(a1.IP()) || !network.Contains(a2.IP()) {
+		t.Error("expect addresses in prefix, but got ", a1, " ", a2)
+	}
+	if a1 == a2 {
+		t.Error("expect random addresses to differ, but got ", a1)
+	}
+	if !random.Address().Family().IsIPv6() {
+		t.Error("expect IPv6 address family")
+	}
+
+	byDest, err := newViaPrefix("2001:db8:1:2::/64", proxyman.SenderConfig_DestinationHash)
+	common.Must(err)
+	if byDest.Pick(context.Background(), dest) != byDest.Pick(context.Background(), dest) {
+		t.Error("expect the same address for the same destination")
+	}
+	if byDest.Pick(context.Background(), dest) == byDest.Pick(context.Background(), net.TCPDestination(net.DomainAddress("example.org"), 443)) {
+		t.Error("expect different addresses for different destinations")
+	}
+
+	byUser, err := newViaPrefix("192.0.2.0/24", proxyman.SenderConfig_UserHash)
+	common.Must(err)
+	ctx := session.ContextWithInbound(context.Background(), &session.Inbound{
+		User: &protocol.MemoryUser{Email: "love@v2fly.org"},
+	})
+	u1 := byUser.Pick(ctx, dest)
+	u2 := byUser.Pick(ctx, net.UDPDestination(net.LocalHostIP, 53))
+	if u1 != u2 || !u1.Family().IsIPv4() || u1.IP()[2] != 2 {
+		t.Error("expect the same IPv4 address for the same user, but got ", u1, " ", u2)
+	}
+
+	if _, err := newViaPrefix("2001:db8::", proxyman.SenderConfig_Random); err == nil {
+		t.Error("expect error for invalid CIDR")
+	}
+}
@@ -6,14 +6,21 @@ package router
 import (
 	"context"
 
+	core "github.com/v2fly/v2ray-core/v5"
 	"github.com/v2fly/v2ray-core/v5/features/extension"
 	"github.com/v2fly/v2ray-core/v5/features/outbound"
+	"github.com/v2fly/v2ray-core/v5/features/routing"
 )
 
 type BalancingStrategy interface {
 	PickOutbound([]string) string
 }
 
+// ContextualBalancingStrategy is a BalancingStrategy that picks outbounds according to the connection being routed.
+type ContextualBalancingStrategy interface {
+	PickOutboundWithContext(routing.Context, []string) string
+}
+
 type BalancingPrincipleTarget interface {
 	GetPrincipleTarget([]string) []string
 }
@@ -25,10 +32,19 @@ type Balancer struct {
 	fallbackTag string
 
 	override override
+	config   *BalancingRule
+
+	ctx            context.Context
+	circuitBreaker extension.CircuitBreaker
 }
 
 // PickOutbound picks the tag of an outbound
 func (b *Balancer) PickOutbound() (string, error) {
+	return b.PickOutboundWithContext(nil)
+}
+
+// PickOutboundWithContext picks the tag of an outbound for the connection of ctx, which may be nil.
+func (b *Balancer) PickOutboundWithContext(ctx routing.Context) (string, error) {
 	candidates, err := b.SelectOutbounds()
 	if err != nil {
 		if b.fallbackTag != "" {
@@ -40,9 +56,15 @@ func (b *Balancer) PickOutbound() (string, error) {
 	var tag string
 	if o := b.override.Get(); o != "" {
 		tag = o
+	} else if s, ok := b.strategy.(ContextualBalancingStrategy); ok && ctx != nil {
+		tag = s.PickOutboundWithContext(ctx, candidates)
 	} else {
 		tag = b.strategy.PickOutbound(candidates)
 	}
+	if tag != "" && b.circuitBreaker != nil && !b.circuitBreaker.Admit(tag) {
+		newError("skipping [", tag, "] with a trial connection of half-open circuit in flight").AtDebug().WriteToLog()
+		tag = ""
+	}
 	if tag == "" {
 		if b.fallbackTag != "" {
 			newError("fallback to [", b.fallbackTag, "], due to empty tag returned").AtInfo().WriteToLog()
@@ -55,6 +77,7 @@ func (b *Balancer) PickOutbound() (string, error) {
 }
 
 func (b *Balancer) InjectContext(ctx context.Context) {
+	b.ctx = ctx
 	if contextReceiver, ok := b.strategy.(extension.ContextReceiver); ok {
 		contextReceiver.InjectContext(ctx)
 	}
@@ -67,12 +90,39 @@ func (b *Balancer) SelectOutbounds() ([]string, error) {
 		return nil, newError("outbound.Manager is not a HandlerSelector")
 	}
 	tags := hs.Select(b.selectors)
-	return tags, nil
+	return b.skipOpenCircuits(tags), nil
+}
+
+// skipOpenCircuits removes outbounds whose circuit is open from tags.
+func (b *Balancer) skipOpenCircuits(tags []string) []string {
+	if b.circuitBreaker == nil {
+		if b.ctx == nil {
+			return tags
+		}
+		instance := core.FromContext(b.ctx)
+		if instance == nil {
+			return tags
+		}
+		breaker, ok := instance.GetFeature(extension.CircuitBreakerType()).(extension.CircuitBreaker)
+		if !ok {
+			return tags
+		}
+		b.circuitBreaker = breaker
+	}
+	allowed := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if b.circuitBreaker.Allow(tag) {
+			allowed = append(allowed, tag)
+		} else {
+			newError("skipping [", tag, "] with open circuit").AtDebug().WriteToLog()
+		}
+	}
+	return allowed
 }
 
 // GetPrincipleTarget implements routing.BalancerPrincipleTarget
 func (r *Router) GetPrincipleTarget(tag string) ([]string, error) {
-	if b, ok := r.balancers[tag]; ok {
+	if b, ok := r.getTable().balancers[tag]; ok {
 		if s, ok := b.strategy.(BalancingPrincipleTarget); ok {
 			candidates, err := b.SelectOutbounds()
 			if err != nil {
@@ -85,9 +135,22 @@ func (r *Router) GetPrincipleTarget(tag string) ([]string, error) {
 	return nil, newError("cannot find tag")
 }
 
+// GetBalancerOutbounds returns the outbounds selected by the balancer, including those skipped for open circuits.
+func (r *Router) GetBalancerOutbounds(tag string) ([]string, error) {
+	b, ok := r.getTable().balancers[tag]
+	if !ok {
+		return nil, newError("cannot find tag")
+	}
+	hs, ok := b.ohm.(outbound.HandlerSelector)
+	if !ok {
+		return nil, newError("outbound.Manager is not a HandlerSelector")
+	}
+	return hs.Select(b.selectors), nil
+}
+
 // SetOverrideTarget implements routing.BalancerOverrider
 func (r *Router) SetOverrideTarget(tag, target string) error {
-	if b, ok := r.balancers[tag]; ok {
+	if b, ok := r.getTable().balancers[tag]; ok {
 		b.override.Put(target)
 		return nil
 	}
@@ -96,7 +159,7 @@ func (r *Router) SetOverrideTarget(tag, target string) error {
 
 // GetOverrideTarget implements routing.BalancerOverrider
 func (r *Router) GetOverrideTarget(tag string) (string, error) {
-	if b, ok := r.balancers[tag]; ok {
+	if b, ok := r.getTable().balancers[tag]; ok {
 		return b.override.Get(), nil
 	}
 	return "", newError("cannot find tag")
@@ -4,12 +4,16 @@ package command
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"google.golang.org/grpc"
 
 	core "github.com/v2fly/v2ray-core/v5"
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
+	"github.com/v2fly/v2ray-core/v5/app/router"
 	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/features/extension"
 	"github.com/v2fly/v2ray-core/v5/features/routing"
 	"github.com/v2fly/v2ray-core/v5/features/stats"
 )
@@ -18,6 +22,14 @@ import (
 type routingServer struct {
 	router       routing.Router
 	routingStats stats.Channel
+	statsManager stats.Manager
+
+	circuitBreaker extension.CircuitBreaker
+}
+
+// balancerOutbounds is a router that lists the outbounds of balancers.
+type balancerOutbounds interface {
+	GetBalancerOutbounds(tag string) ([]string, error)
 }
 
 func (s *routingServer) GetBalancerInfo(ctx context.Context, request *GetBalancerInfoRequest) (*GetBalancerInfoResponse, error) {
@@ -45,9 +57,39 @@ func (s *routingServer) GetBalancerInfo(ctx context.Context, request *GetBalance
 			}
 		}
 	}
+
+	if bo, ok := s.router.(balancerOutbounds); ok && s.circuitBreaker != nil {
+		circuits, err := s.getCircuits(ctx, bo, request.GetTag())
+		if err != nil {
+			newError("unable to obtain circuit status").Base(err).AtInfo().WriteToLog()
+		} else {
+			ret.Balancer.Circuit = circuits
+		}
+	}
 	return &ret, nil
 }
 
+func (s *routingServer) getCircuits(ctx context.Context, bo balancerOutbounds, tag string) ([]*observatory.CircuitStatus, error) {
+	outbounds, err := bo.GetBalancerOutbounds(tag)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.circuitBreaker.GetCircuitStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var circuits []*observatory.CircuitStatus
+	for _, circuit := range result.(*observatory.CircuitBreakerResult).Circuit {
+		for _, outbound := range outbounds {
+			if circuit.OutboundTag == outbound {
+				circuits = append(circuits, circuit)
+				break
+			}
+		}
+	}
+	return circuits, nil
+}
+
 func (s *routingServer) OverrideBalancerTarget(ctx context.Context, request *OverrideBalancerTargetRequest) (*OverrideBalancerTargetResponse, error) {
 	if bo, ok := s.router.(routing.BalancerOverrider); ok {
 		return &OverrideBalancerTargetResponse{}, bo.SetOverrideTarget(request.BalancerTag, request.Target)
@@ -55,11 +97,159 @@ func (s *routingServer) OverrideBalancerTarget(ctx context.Context, request *Ove
 	return nil, newError("unsupported router implementation")
 }
 
+// ruleManager is a router whose rules can be changed at runtime.
+type ruleManager interface {
+	ListRules() ([]*router.RoutingRule, []*router.BalancingRule)
+	ListRuleIDs() ([]string, []uint32)
+	AddRule(config *router.RoutingRule, index int) error
+	ReplaceRule(tag string, config *router.RoutingRule) error
+	RemoveRule(tag string) error
+	AddBalancingRule(config *router.BalancingRule) error
+	ReplaceBalancingRule(config *router.BalancingRule) error
+	RemoveBalancingRule(tag string) error
+}
+
+func (s *routingServer) getRuleManager() (ruleManager, error) {
+	if rm, ok := s.router.(ruleManager); ok {
+		return rm, nil
+	}
+	return nil, newError("unsupported router implementation")
+}
+
+func (s *routingServer) ListRules(ctx context.Context, request *ListRulesRequest) (*ListRulesResponse, error) {
+	rm, err := s.getRuleManager()
+	if err != nil {
+		return nil, err
+	}
+	rules, balancingRules := rm.ListRules()
+	return &ListRulesResponse{Rule: rules, BalancingRule: balancingRules}, nil
+}
+
+func (s *routingServer) AddRule(ctx context.Context, request *AddRuleRequest) (*AddRuleResponse, error) {
+	rm, err := s.getRuleManager()
+	if err != nil {
+		return nil, err
+	}
+	if request.Rule == nil {
+		return nil, newError("rule is not specified")
+	}
+	return &AddRuleResponse{}, rm.AddRule(request.Rule, int(request.Position)-1)
+}
+
+func (s *routingServer) ReplaceRule(ctx context.Context, request *ReplaceRuleRequest) (*ReplaceRuleResponse, error) {
+	rm, err := s.getRuleManager()
+	if err != nil {
+		return nil, err
+	}
+	if request.Rule == nil {
+		return nil, newError("rule is not specified")
+	}
+	return &ReplaceRuleResponse{}, rm.ReplaceRule(request.RuleTag, request.Rule)
+}
+
+func (s *routingServer) RemoveRule(ctx context.Context, request *RemoveRuleRequest) (*RemoveRuleResponse, error) {
+	rm, err := s.getRuleManager()
+	if err != nil {
+		return nil, err
+	}
+	return &RemoveRuleResponse{}, rm.RemoveRule(request.RuleTag)
+}
+
+func (s *routingServer) AddBalancingRule(ctx context.Context, request *AddBalancingRuleRequest) (*AddBalancingRuleResponse, error) {
+	rm, err := s.getRuleManager()
+	if err != nil {
+		return nil, err
+	}
+	if request.BalancingRule == nil {
+		return nil, newError("balancing rule is not specified")
+	}
+	return &AddBalancingRuleResponse{}, rm.AddBalancingRule(request.BalancingRule)
+}
+
+func (s *routingServer) ReplaceBalancingRule(ctx context.Context, request *ReplaceBalancingRuleRequest) (*ReplaceBalancingRuleResponse, error) {
+	rm, err := s.getRuleManager()
+	if err != nil {
+		return nil, err
+	}
+	if request.BalancingRule == nil {
+		return nil, newError("balancing rule is not specified")
+	}
+	return &ReplaceBalancingRuleResponse{}, rm.ReplaceBalancingRule(request.BalancingRule)
+}
+
+func (s *routingServer) RemoveBalancingRule(ctx context.Context, request *RemoveBalancingRuleRequest) (*RemoveBalancingRuleResponse, error) {
+	rm, err := s.getRuleManager()
+	if err != nil {
+		return nil, err
+	}
+	return &RemoveBalancingRuleResponse{}, rm.RemoveBalancingRule(request.Tag)
+}
+
+func (s *routingServer) GetRuleStats(ctx context.Context, request *GetRuleStatsRequest) (*GetRuleStatsResponse, error) {
+	if s.statsManager == nil {
+		return nil, newError("stats manager not enabled")
+	}
+	rm, err := s.getRuleManager()
+	if err != nil {
+		return nil, err
+	}
+	tags, ids := rm.ListRuleIDs()
+	if len(request.RuleTag) > 0 {
+		var selectedTags []string
+		var selectedIDs []uint32
+		for _, tag := range request.RuleTag {
+			found := false
+			for i := range tags {
+				if tag != "" && tags[i] == tag {
+					selectedTags = append(selectedTags, tags[i])
+					selectedIDs = append(selectedIDs, ids[i])
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, newError("rule not found: ", tag)
+			}
+		}
+		tags, ids = selectedTags, selectedIDs
+	}
+
+	counterValue := func(name string, reset bool) int64 {
+		c := s.statsManager.GetCounter(name)
+		if c == nil {
+			return 0
+		}
+		if reset {
+			return c.Set(0)
+		}
+		return c.Value()
+	}
+	response := new(GetRuleStatsResponse)
+	for i, id := range ids {
+		name := "rule>>>" + strconv.FormatUint(uint64(id), 10)
+		response.Stats = append(response.Stats, &RuleStats{
+			RuleTag:  tags[i],
+			RuleId:   id,
+			Hits:     counterValue(name+">>>hits", request.Reset_),
+			LastHit:  counterValue(name+">>>last_hit", false),
+			Uplink:   counterValue(name+">>>traffic>>>uplink", request.Reset_),
+			Downlink: counterValue(name+">>>traffic>>>downlink", request.Reset_),
+		})
+	}
+	return response, nil
+}
+
 // NewRoutingServer creates a statistics service with statistics manager.
 func NewRoutingServer(router routing.Router, routingStats stats.Channel) RoutingServiceServer {
+	return NewRoutingServerWithStats(router, routingStats, nil)
+}
+
+// NewRoutingServerWithStats creates a routing service, which reads statistics of routing rules from statsManager.
+func NewRoutingServerWithStats(router routing.Router, routingStats stats.Channel, statsManager stats.Manager) RoutingServiceServer {
 	return &routingServer{
 		router:       router,
 		routingStats: routingStats,
+		statsManager: statsManager,
 	}
 }
 
@@ -67,7 +257,11 @@ func (s *routingServer) TestRoute(ctx context.Context, request *TestRouteRequest
 	if request.RoutingContext == nil {
 		return nil, newError("Invalid routing request.")
 	}
-	route, err := s.router.PickRoute(AsRoutingContext(request.RoutingContext))
+	routingCtx := AsRoutingContext(request.RoutingContext)
+	if request.Timestamp != 0 {
+		routingCtx = AsRoutingContextAt(request.RoutingContext, time.Unix(request.Timestamp, 0))
+	}
+	route, err := s.router.PickRoute(routingCtx)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +310,9 @@ type service struct {
 
 func (s *service) Register(server *grpc.Server) {
 	common.Must(s.v.RequireFeatures(func(router routing.Router, stats stats.Manager) {
-		RegisterRoutingServiceServer(server, NewRoutingServer(router, nil))
+		rs := NewRoutingServerWithStats(router, nil, stats).(*routingServer)
+		rs.circuitBreaker, _ = s.v.GetFeature(extension.CircuitBreakerType()).(extension.CircuitBreaker)
+		RegisterRoutingServiceServer(server, rs)
 	}))
 }
 
@@ -220,6 +220,19 @@ func TestSerivceTestRoute(t *testing.T) {
 				InboundTag: []string{"in"},
 				TargetTag:  &router.RoutingRule_Tag{Tag: "out"},
 			},
+			{
+				InboundTag: []string{"office"},
+				Schedule: &router.Schedule{
+					Weekday:  []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+					Time:     []*router.Schedule_Range{{From: "09:00", To: "18:00"}},
+					TimeZone: "UTC",
+				},
+				TargetTag: &router.RoutingRule_Tag{Tag: "cheap"},
+			},
+			{
+				InboundTag: []string{"office"},
+				TargetTag:  &router.RoutingRule_Tag{Tag: "out"},
+			},
 			{
 				Protocol:  []string{"bittorrent"},
 				TargetTag: &router.RoutingRule_Tag{Tag: "blocked"},
@@ -276,13 +289,13 @@ func TestSerivceTestRoute(t *testing.T) {
 		client := NewRoutingServiceClient(conn)
 
 		testCases := []*RoutingContext{
-			{InboundTag: "in", OutboundTag: "out"},
-			{TargetIPs: [][]byte{{1, 2, 3, 4}}, TargetPort: 8080, OutboundTag: "out"},
-			{TargetDomain: "example.com", TargetPort: 443, OutboundTag: "out"},
-			{SourcePort: 9999, TargetPort: 9999, OutboundTag: "out"},
-			{Network: net.Network_UDP, Protocol: "bittorrent", OutboundTag: "blocked"},
-			{User: "example@v2fly.org", OutboundTag: "out"},
-			{SourceIPs: [][]byte{{127, 0, 0, 1}}, Attributes: map[string]string{"attr": "value"}, OutboundTag: "out"},
+			{InboundTag: "in", OutboundTag: "out", RuleId: 1},
+			{TargetIPs: [][]byte{{1, 2, 3, 4}}, TargetPort: 8080, OutboundTag: "out", RuleId: 5},
+			{TargetDomain: "example.com", TargetPort: 443, OutboundTag: "out", RuleId: 7},
+			{SourcePort: 9999, TargetPort: 9999, OutboundTag: "out", RuleId: 6},
+			{Network: net.Network_UDP, Protocol: "bittorrent", OutboundTag: "blocked", RuleId: 4},
+			{User: "example@v2fly.org", OutboundTag: "out", RuleId: 9},
+			{SourceIPs: [][]byte{{127, 0, 0, 1}}, Attributes: map[string]string{"attr": "value"}, OutboundTag: "out", RuleId: 8},
 		}
 
 		// Test simple TestRoute
@@ -342,9 +355,38 @@ func TestSerivceTestRoute(t *testing.T) {
 			return nil
 		}
 
+		// Test TestRoute at given time
+		testTimestamp := func() error {
+			timestampCases := []struct {
+				timestamp time.Time
+				outbound  string
+			}{
+				{timestamp: time.Date(2022, 10, 10, 10, 0, 0, 0, time.UTC), outbound: "cheap"}, // Monday
+				{timestamp: time.Date(2022, 10, 10, 20, 0, 0, 0, time.UTC), outbound: "out"},
+				{timestamp: time.Date(2022, 10, 9, 10, 0, 0, 0, time.UTC), outbound: "out"}, // Sunday
+			}
+			for _, tc := range timestampCases {
+				route, err := client.TestRoute(context.Background(), &TestRouteRequest{
+					RoutingContext: &RoutingContext{InboundTag: "office"},
+					FieldSelectors: []string{"outbound"},
+					Timestamp:      tc.timestamp.Unix(),
+				})
+				if err != nil {
+					return err
+				}
+				if route.OutboundTag != tc.outbound {
+					t.Error("expect outbound ", tc.outbound, " at ", tc.timestamp, " but got ", route.OutboundTag)
+				}
+			}
+			return nil
+		}
+
 		if err := testSimple(); err != nil {
 			errCh <- err
 		}
+		if err := testTimestamp(); err != nil {
+			errCh <- err
+		}
 		if err := testOptions(); err != nil {
 			errCh <- err
 		}
@@ -361,3 +403,156 @@ func TestSerivceTestRoute(t *testing.T) {
 		}
 	}
 }
+
+func TestServiceManageRules(t *testing.T) {
+	r := new(router.Router)
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	common.Must(r.Init(context.TODO(), &router.Config{
+		Rule: []*router.RoutingRule{
+			{
+				RuleTag:    "default",
+				InboundTag: []string{"in"},
+				TargetTag:  &router.RoutingRule_Tag{Tag: "out"},
+			},
+		},
+	}, mocks.NewDNSClient(mockCtl), mocks.NewOutboundManager(mockCtl), nil))
+
+	lis := bufconn.Listen(1024 * 1024)
+	bufDialer := func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}
+	statsManager, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+	hits, err := statsManager.RegisterCounter("rule>>>1>>>hits")
+	common.Must(err)
+	hits.Set(3)
+	lastHit, err := statsManager.RegisterCounter("rule>>>1>>>last_hit")
+	common.Must(err)
+	lastHit.Set(1600000000)
+
+	server := grpc.NewServer()
+	RegisterRoutingServiceServer(server, NewRoutingServerWithStats(r, nil, statsManager))
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	common.Must(err)
+	defer conn.Close()
+	client := NewRoutingServiceClient(conn)
+	ctx := context.Background()
+
+	route := func() string {
+		result, err := client.TestRoute(ctx, &TestRouteRequest{RoutingContext: &RoutingContext{InboundTag: "in"}})
+		common.Must(err)
+		return result.OutboundTag
+	}
+	ruleTags := func() []string {
+		resp, err := client.ListRules(ctx, &ListRulesRequest{})
+		common.Must(err)
+		var tags []string
+		for _, rule := range resp.Rule {
+			tags = append(tags, rule.RuleTag)
+		}
+		return tags
+	}
+
+	_, err = client.AddRule(ctx, &AddRuleRequest{
+		Rule: &router.RoutingRule{
+			RuleTag:    "customer",
+			InboundTag: []string{"in"},
+			TargetTag:  &router.RoutingRule_Tag{Tag: "first"},
+		},
+		Position: 1,
+	})
+	common.Must(err)
+	if tag := route(); tag != "first" {
+		t.Error("expect route to first, but got ", tag)
+	}
+	if r := cmp.Diff(ruleTags(), []string{"customer", "default"}); r != "" {
+		t.Error(r)
+	}
+
+	if _, err := client.AddRule(ctx, &AddRuleRequest{
+		Rule: &router.RoutingRule{
+			RuleTag:    "customer",
+			InboundTag: []string{"in"},
+			TargetTag:  &router.RoutingRule_Tag{Tag: "first"},
+		},
+	}); err == nil {
+		t.Error("expect error for duplicated rule tag")
+	}
+
+	_, err = client.AddBalancingRule(ctx, &AddBalancingRuleRequest{
+		BalancingRule: &router.BalancingRule{
+			Tag:              "balancer",
+			OutboundSelector: []string{"out"},
+			Strategy:         "random",
+		},
+	})
+	common.Must(err)
+	_, err = client.ReplaceRule(ctx, &ReplaceRuleRequest{
+		RuleTag: "customer",
+		Rule: &router.RoutingRule{
+			InboundTag: []string{"in"},
+			TargetTag:  &router.RoutingRule_BalancingTag{BalancingTag: "balancer"},
+		},
+	})
+	common.Must(err)
+	if r := cmp.Diff(ruleTags(), []string{"customer", "default"}); r != "" {
+		t.Error(r)
+	}
+	if _, err := client.RemoveBalancingRule(ctx, &RemoveBalancingRuleRequest{Tag: "balancer"}); err == nil {
+		t.Error("expect error for removing balancer in use")
+	}
+	_, err = client.ReplaceBalancingRule(ctx, &ReplaceBalancingRuleRequest{
+		BalancingRule: &router.BalancingRule{
+			Tag:              "balancer",
+			OutboundSelector: []string{"out", "proxy"},
+			Strategy:         "random",
+		},
+	})
+	common.Must(err)
+	resp, err := client.ListRules(ctx, &ListRulesRequest{})
+	common.Must(err)
+	if len(resp.BalancingRule) != 1 || len(resp.BalancingRule[0].OutboundSelector) != 2 {
+		t.Error("unexpected balancing rules: ", resp.BalancingRule)
+	}
+
+	_, err = client.RemoveRule(ctx, &RemoveRuleRequest{RuleTag: "customer"})
+	common.Must(err)
+	_, err = client.RemoveBalancingRule(ctx, &RemoveBalancingRuleRequest{Tag: "balancer"})
+	common.Must(err)
+	if tag := route(); tag != "out" {
+		t.Error("expect route to out, but got ", tag)
+	}
+	if _, err := client.RemoveRule(ctx, &RemoveRuleRequest{RuleTag: "customer"}); err == nil {
+		t.Error("expect error for removing unknown rule")
+	}
+
+	_, err = client.AddRule(ctx, &AddRuleRequest{
+		Rule: &router.RoutingRule{
+			RuleTag:    "last",
+			InboundTag: []string{"in"},
+			TargetTag:  &router.RoutingRule_Tag{Tag: "first"},
+		},
+	})
+	common.Must(err)
+	if r := cmp.Diff(ruleTags(), []string{"default", "last"}); r != "" {
+		t.Error(r)
+	}
+	_, err = client.RemoveRule(ctx, &RemoveRuleRequest{RuleTag: "last"})
+	common.Must(err)
+
+	ruleStats, err := client.GetRuleStats(ctx, &GetRuleStatsRequest{Reset_: true})
+	common.Must(err)
+	if r := cmp.Diff(ruleStats.Stats, []*RuleStats{{RuleTag: "default", RuleId: 1, Hits: 3, LastHit: 1600000000}}, cmpopts.IgnoreUnexported(RuleStats{})); r != "" {
+		t.Error(r)
+	}
+	if hits.Value() != 0 {
+		t.Error("expect counter to be reset, but got ", hits.Value())
+	}
+	if lastHit.Value() != 1600000000 {
+		t.Error("expect last hit to be kept, but got ", lastHit.Value())
+	}
+}
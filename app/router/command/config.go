@@ -2,6 +2,7 @@ package command
 
 import (
 	"strings"
+	"time"
 
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/features/routing"
@@ -10,6 +11,7 @@ import (
 // routingContext is an wrapper of protobuf RoutingContext as implementation of routing.Context and routing.Route.
 type routingContext struct {
 	*RoutingContext
+	time time.Time
 }
 
 func (c routingContext) GetSourceIPs() []net.IP {
@@ -28,6 +30,11 @@ func (c routingContext) GetTargetPort() net.Port {
 	return net.Port(c.RoutingContext.GetTargetPort())
 }
 
+// GetRuleID implements routing.RuleRoute.
+func (c routingContext) GetRuleID() uint32 {
+	return c.RoutingContext.GetRuleId()
+}
+
 // GetSkipDNSResolve is a mock implementation here to match the interface,
 // SkipDNSResolve is set from dns module, no use if coming from a protobuf object?
 // TODO: please confirm @Vigilans
@@ -35,14 +42,28 @@ func (c routingContext) GetSkipDNSResolve() bool {
 	return false
 }
 
+// GetTime returns the time set in the context, or the current time if not set.
+func (c routingContext) GetTime() time.Time {
+	if c.time.IsZero() {
+		return time.Now()
+	}
+	return c.time
+}
+
 // AsRoutingContext converts a protobuf RoutingContext into an implementation of routing.Context.
 func AsRoutingContext(r *RoutingContext) routing.Context {
-	return routingContext{r}
+	return routingContext{RoutingContext: r}
+}
+
+// AsRoutingContextAt converts a protobuf RoutingContext into an implementation of routing.Context, which is routed
+// at the given time.
+func AsRoutingContextAt(r *RoutingContext, t time.Time) routing.Context {
+	return routingContext{RoutingContext: r, time: t}
 }
 
 // AsRoutingRoute converts a protobuf RoutingContext into an implementation of routing.Route.
 func AsRoutingRoute(r *RoutingContext) routing.Route {
-	return routingContext{r}
+	return routingContext{RoutingContext: r}
 }
 
 var fieldMap = map[string]func(*RoutingContext, routing.Route){
@@ -58,6 +79,12 @@ var fieldMap = map[string]func(*RoutingContext, routing.Route){
 	"attributes":     func(s *RoutingContext, r routing.Route) { s.Attributes = r.GetAttributes() },
 	"outbound_group": func(s *RoutingContext, r routing.Route) { s.OutboundGroupTags = r.GetOutboundGroupTags() },
 	"outbound":       func(s *RoutingContext, r routing.Route) { s.OutboundTag = r.GetOutboundTag() },
+	"rule": func(s *RoutingContext, r routing.Route) {
+		if rr, ok := r.(routing.RuleRoute); ok {
+			s.RuleTag = rr.GetRuleTag()
+			s.RuleId = rr.GetRuleID()
+		}
+	},
 }
 
 // AsProtobufMessage takes selectors of fields and returns a function to convert routing.Route to protobuf RoutingContext.
@@ -42,6 +42,29 @@ func (v *ConditionChan) Len() int {
 	return len(*v)
 }
 
+// AnyCondition matches if any of its conditions matches.
+type AnyCondition []Condition
+
+// Apply implements Condition.
+func (v AnyCondition) Apply(ctx routing.Context) bool {
+	for _, cond := range v {
+		if cond.Apply(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+// NotCondition matches if its condition does not match.
+type NotCondition struct {
+	Condition Condition
+}
+
+// Apply implements Condition.
+func (v *NotCondition) Apply(ctx routing.Context) bool {
+	return !v.Condition.Apply(ctx)
+}
+
 var matcherTypeMap = map[routercommon.Domain_Type]strmatcher.Type{
 	routercommon.Domain_Plain:      strmatcher.Substr,
 	routercommon.Domain_Regex:      strmatcher.Regex,
@@ -0,0 +1,163 @@
+package router
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/features/routing"
+)
+
+const (
+	processOwnerCacheTTL  = time.Second * 5
+	processOwnerCacheSize = 1024
+)
+
+// processOwner is the local program that opened a connection.
+type processOwner struct {
+	UID  uint32
+	GID  uint32
+	PID  int
+	Name string
+	Path string
+}
+
+type processOwnerCacheEntry struct {
+	owner  *processOwner
+	expire time.Time
+}
+
+// processOwnerCache caches lookups of connection owners, as a connection is usually matched by several rules.
+type processOwnerCache struct {
+	sync.Mutex
+	entries map[string]processOwnerCacheEntry
+}
+
+var ownerCache = &processOwnerCache{
+	entries: make(map[string]processOwnerCacheEntry),
+}
+
+func (c *processOwnerCache) lookup(network net.Network, ip net.IP, port net.Port, withProcess bool) *processOwner {
+	key := net.Destination{Network: network, Address: net.IPAddress(ip), Port: port}.String()
+	now := time.Now()
+
+	c.Lock()
+	entry, found := c.entries[key]
+	if found && !entry.expire.After(now) {
+		delete(c.entries, key)
+		found = false
+	}
+	c.Unlock()
+	if found && (entry.owner == nil || !withProcess || entry.owner.PID > 0) {
+		return entry.owner
+	}
+
+	owner, err := findProcessOwner(network, ip, port, withProcess)
+	if err != nil {
+		newError("failed to find owner of ", key).Base(err).AtDebug().WriteToLog()
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if _, found := c.entries[key]; !found && len(c.entries) >= processOwnerCacheSize {
+		c.evict(now)
+	}
+	c.entries[key] = processOwnerCacheEntry{
+		owner:  owner,
+		expire: now.Add(processOwnerCacheTTL),
+	}
+	return owner
+}
+
+// evict removes the expired entries, or the oldest entry if none has expired, to keep the cache bounded.
+func (c *processOwnerCache) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for k, e := range c.entries {
+		if !e.expire.After(now) {
+			delete(c.entries, k)
+			continue
+		}
+		if oldestKey == "" || e.expire.Before(oldest) {
+			oldestKey, oldest = k, e.expire
+		}
+	}
+	if len(c.entries) >= processOwnerCacheSize {
+		delete(c.entries, oldestKey)
+	}
+}
+
+// ProcessMatcher matches the local program that opened the connection.
+type ProcessMatcher struct {
+	names []string
+	paths []string
+	uids  []uint32
+	gids  []uint32
+}
+
+func NewProcessMatcher(names []string, paths []string, uids []uint32, gids []uint32) *ProcessMatcher {
+	m := &ProcessMatcher{
+		uids: uids,
+		gids: gids,
+	}
+	for _, name := range names {
+		if len(name) > 0 {
+			m.names = append(m.names, name)
+		}
+	}
+	for _, path := range paths {
+		if len(path) > 0 {
+			m.paths = append(m.paths, filepath.Clean(path))
+		}
+	}
+	return m
+}
+
+// Apply implements Condition.
+func (m *ProcessMatcher) Apply(ctx routing.Context) bool {
+	ips := ctx.GetSourceIPs()
+	if len(ips) == 0 {
+		return false
+	}
+	withProcess := len(m.names) > 0 || len(m.paths) > 0 || len(m.gids) > 0
+	owner := ownerCache.lookup(ctx.GetNetwork(), ips[0], ctx.GetSourcePort(), withProcess)
+	if owner == nil {
+		return false
+	}
+	return m.match(owner)
+}
+
+func (m *ProcessMatcher) match(owner *processOwner) bool {
+	if len(m.uids) > 0 && !containsUint32(m.uids, owner.UID) {
+		return false
+	}
+	if len(m.gids) > 0 && (owner.PID == 0 || !containsUint32(m.gids, owner.GID)) {
+		return false
+	}
+	if len(m.names) > 0 && (owner.PID == 0 || !containsString(m.names, owner.Name)) {
+		return false
+	}
+	if len(m.paths) > 0 && (owner.PID == 0 || !containsString(m.paths, owner.Path)) {
+		return false
+	}
+	return true
+}
+
+func containsUint32(list []uint32, v uint32) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
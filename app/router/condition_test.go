@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
@@ -337,6 +338,79 @@ func TestRoutingRule(t *testing.T) {
 				},
 			},
 		},
+		{
+			rule: &router.RoutingRule{
+				Logical: &router.LogicalRule{
+					Operator: router.LogicalRule_Or,
+					Rule: []*router.RoutingRule{
+						{
+							Domain: []*routercommon.Domain{
+								{
+									Value: "v2fly.org",
+									Type:  routercommon.Domain_RootDomain,
+								},
+							},
+						},
+						{
+							Logical: &router.LogicalRule{
+								Operator: router.LogicalRule_Not,
+								Rule: []*router.RoutingRule{
+									{
+										PortList: &net.PortList{
+											Range: []*net.PortRange{{From: 443, To: 443}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			test: []ruleTest{
+				{
+					input:  withOutbound(&session.Outbound{Target: net.TCPDestination(net.DomainAddress("www.v2fly.org"), 443)}),
+					output: true,
+				},
+				{
+					input:  withOutbound(&session.Outbound{Target: net.TCPDestination(net.DomainAddress("www.github.com"), 80)}),
+					output: true,
+				},
+				{
+					input:  withOutbound(&session.Outbound{Target: net.TCPDestination(net.DomainAddress("www.github.com"), 443)}),
+					output: false,
+				},
+			},
+		},
+		{
+			rule: &router.RoutingRule{
+				InboundTag: []string{"in"},
+				Logical: &router.LogicalRule{
+					Operator: router.LogicalRule_And,
+					Rule: []*router.RoutingRule{
+						{Networks: []net.Network{net.Network_UDP}},
+						{
+							PortList: &net.PortList{
+								Range: []*net.PortRange{{From: 53, To: 53}},
+							},
+						},
+					},
+				},
+			},
+			test: []ruleTest{
+				{
+					input:  &routing_session.Context{Inbound: &session.Inbound{Tag: "in"}, Outbound: &session.Outbound{Target: net.UDPDestination(net.LocalHostIP, 53)}},
+					output: true,
+				},
+				{
+					input:  &routing_session.Context{Inbound: &session.Inbound{Tag: "in"}, Outbound: &session.Outbound{Target: net.TCPDestination(net.LocalHostIP, 53)}},
+					output: false,
+				},
+				{
+					input:  &routing_session.Context{Inbound: &session.Inbound{Tag: "out"}, Outbound: &session.Outbound{Target: net.UDPDestination(net.LocalHostIP, 53)}},
+					output: false,
+				},
+			},
+		},
 	}
 
 	for _, test := range cases {
@@ -352,6 +426,69 @@ func TestRoutingRule(t *testing.T) {
 	}
 }
 
+func TestScheduleMatcher(t *testing.T) {
+	matcher, err := router.NewScheduleMatcher(&router.Schedule{
+		Weekday: []string{"Sat", "sunday"},
+		Time: []*router.Schedule_Range{
+			{From: "22:00", To: "02:00"},
+			{From: "12:00", To: "13:30"},
+		},
+		Date:     []*router.Schedule_Range{{From: "2022-10-01", To: "2022-10-31"}},
+		TimeZone: "Asia/Shanghai",
+	})
+	common.Must(err)
+
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	common.Must(err)
+	cases := []struct {
+		time   time.Time
+		output bool
+	}{
+		{time: time.Date(2022, 10, 8, 23, 0, 0, 0, shanghai), output: true},
+		{time: time.Date(2022, 10, 9, 1, 59, 0, 0, shanghai), output: true},
+		{time: time.Date(2022, 10, 9, 2, 0, 0, 0, shanghai), output: false},
+		{time: time.Date(2022, 10, 9, 13, 0, 0, 0, shanghai), output: true},
+		{time: time.Date(2022, 10, 9, 5, 0, 0, 0, time.UTC), output: true},    // 13:00 in Shanghai
+		{time: time.Date(2022, 10, 10, 13, 0, 0, 0, shanghai), output: false}, // Monday
+		{time: time.Date(2022, 11, 5, 13, 0, 0, 0, shanghai), output: false},  // Out of date range
+	}
+	for _, c := range cases {
+		if actual := matcher.Match(c.time); actual != c.output {
+			t.Error("expect ", c.output, " at ", c.time, " but got ", actual)
+		}
+	}
+
+	invalid := []*router.Schedule{
+		{Weekday: []string{"Someday"}},
+		{Time: []*router.Schedule_Range{{From: "9am", To: "10:00"}}},
+		{Date: []*router.Schedule_Range{{From: "2022/10/01"}}},
+		{TimeZone: "Mars/Olympus"},
+	}
+	for _, schedule := range invalid {
+		if _, err := router.NewScheduleMatcher(schedule); err == nil {
+			t.Error("expect error for ", schedule)
+		}
+	}
+}
+
+func TestLogicalRuleBuild(t *testing.T) {
+	portRule := &router.RoutingRule{
+		PortList: &net.PortList{
+			Range: []*net.PortRange{{From: 443, To: 443}},
+		},
+	}
+	cases := []*router.LogicalRule{
+		{Operator: router.LogicalRule_And},
+		{Operator: router.LogicalRule_Not, Rule: []*router.RoutingRule{portRule, portRule}},
+		{Operator: router.LogicalRule_Or, Rule: []*router.RoutingRule{{}}},
+	}
+	for _, c := range cases {
+		if _, err := c.BuildCondition(); err == nil {
+			t.Error("expect error for ", c)
+		}
+	}
+}
+
 func loadGeoSite(country string) ([]*routercommon.Domain, error) {
 	geositeBytes, err := filesystem.ReadAsset("geosite.dat")
 	if err != nil {
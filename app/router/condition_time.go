@@ -0,0 +1,153 @@
+package router
+
+import (
+	"strings"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/features/routing"
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// minuteRange is a range of minutes of day. The end is exclusive, and a range ending before it starts spans midnight.
+type minuteRange struct {
+	from, to int
+}
+
+func (r minuteRange) contains(minute int) bool {
+	if r.from <= r.to {
+		return minute >= r.from && minute < r.to
+	}
+	return minute >= r.from || minute < r.to
+}
+
+// parseMinuteOfDay parses time of day in "15:04" format. "24:00" is accepted as the end of day.
+func parseMinuteOfDay(s string) (int, error) {
+	if s == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, newError("invalid time of day: ", s).Base(err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// dateRange is a range of dates in "2006-01-02" format, which compares in lexical order. Empty ends are open.
+type dateRange struct {
+	from, to string
+}
+
+func (r dateRange) contains(date string) bool {
+	return (r.from == "" || date >= r.from) && (r.to == "" || date <= r.to)
+}
+
+// ScheduleMatcher matches the local time at which the connection is routed.
+type ScheduleMatcher struct {
+	location *time.Location
+	weekdays map[time.Weekday]bool
+	times    []minuteRange
+	dates    []dateRange
+}
+
+func NewScheduleMatcher(schedule *Schedule) (*ScheduleMatcher, error) {
+	m := &ScheduleMatcher{
+		location: time.Local,
+	}
+	if schedule.TimeZone != "" {
+		location, err := time.LoadLocation(schedule.TimeZone)
+		if err != nil {
+			return nil, newError("unknown time zone: ", schedule.TimeZone).Base(err)
+		}
+		m.location = location
+	}
+
+	for _, name := range schedule.Weekday {
+		weekday, found := weekdayNames[strings.ToLower(name)]
+		if !found {
+			return nil, newError("unknown weekday: ", name)
+		}
+		if m.weekdays == nil {
+			m.weekdays = make(map[time.Weekday]bool)
+		}
+		m.weekdays[weekday] = true
+	}
+
+	for _, r := range schedule.Time {
+		from, err := parseMinuteOfDay(r.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseMinuteOfDay(r.To)
+		if err != nil {
+			return nil, err
+		}
+		m.times = append(m.times, minuteRange{from: from, to: to})
+	}
+
+	for _, r := range schedule.Date {
+		for _, date := range []string{r.From, r.To} {
+			if date == "" {
+				continue
+			}
+			if _, err := time.Parse("2006-01-02", date); err != nil {
+				return nil, newError("invalid date: ", date).Base(err)
+			}
+		}
+		m.dates = append(m.dates, dateRange{from: r.From, to: r.To})
+	}
+
+	return m, nil
+}
+
+// Apply implements Condition.
+func (m *ScheduleMatcher) Apply(ctx routing.Context) bool {
+	return m.Match(ctx.GetTime())
+}
+
+// Match returns true if t is in the schedule.
+func (m *ScheduleMatcher) Match(t time.Time) bool {
+	t = t.In(m.location)
+
+	if m.weekdays != nil && !m.weekdays[t.Weekday()] {
+		return false
+	}
+
+	if len(m.times) > 0 {
+		minute := t.Hour()*60 + t.Minute()
+		matched := false
+		for _, r := range m.times {
+			if r.contains(minute) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(m.dates) > 0 {
+		date := t.Format("2006-01-02")
+		matched := false
+		for _, r := range m.dates {
+			if r.contains(date) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
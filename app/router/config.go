@@ -19,13 +19,22 @@ import (
 
 type Rule struct {
 	Tag       string
+	RuleTag   string
+	ID        uint32 // unique in the Router, assigned when the rule is built
 	Balancer  *Balancer
 	Condition Condition
+
+	config *RoutingRule
 }
 
 func (r *Rule) GetTag() (string, error) {
+	return r.GetTagWithContext(nil)
+}
+
+// GetTagWithContext returns the outbound tag of the rule for the connection of ctx, which may be nil.
+func (r *Rule) GetTagWithContext(ctx routing.Context) (string, error) {
 	if r.Balancer != nil {
-		return r.Balancer.PickOutbound()
+		return r.Balancer.PickOutboundWithContext(ctx)
 	}
 	return r.Tag, nil
 }
@@ -36,6 +45,11 @@ func (r *Rule) Apply(ctx routing.Context) bool {
 }
 
 func (rr *RoutingRule) BuildCondition() (Condition, error) {
+	return rr.buildCondition(nil)
+}
+
+// buildCondition builds the condition, looking up referenced rule sets in ruleSets.
+func (rr *RoutingRule) buildCondition(ruleSets map[string]*RuleSetProvider) (Condition, error) {
 	conds := NewConditionChan()
 
 	if len(rr.Domain) > 0 {
@@ -122,6 +136,38 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 		conds.Add(cond)
 	}
 
+	if len(rr.ProcessName) > 0 || len(rr.ProcessPath) > 0 || len(rr.Uid) > 0 || len(rr.Gid) > 0 {
+		conds.Add(NewProcessMatcher(rr.ProcessName, rr.ProcessPath, rr.Uid, rr.Gid))
+	}
+
+	if rr.Schedule != nil {
+		cond, err := NewScheduleMatcher(rr.Schedule)
+		if err != nil {
+			return nil, newError("failed to build schedule condition").Base(err)
+		}
+		conds.Add(cond)
+	}
+
+	if len(rr.RuleSet) > 0 {
+		sets := make([]*RuleSetProvider, 0, len(rr.RuleSet))
+		for _, tag := range rr.RuleSet {
+			set, found := ruleSets[tag]
+			if !found {
+				return nil, newError("rule set not found: ", tag)
+			}
+			sets = append(sets, set)
+		}
+		conds.Add(NewRuleSetMatcher(sets))
+	}
+
+	if rr.Logical != nil {
+		cond, err := rr.Logical.buildCondition(ruleSets)
+		if err != nil {
+			return nil, err
+		}
+		conds.Add(cond)
+	}
+
 	if conds.Len() == 0 {
 		return nil, newError("this rule has no effective fields").AtWarning()
 	}
@@ -129,6 +175,40 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 	return conds, nil
 }
 
+// BuildCondition builds the condition combining the sub-rules.
+func (lr *LogicalRule) BuildCondition() (Condition, error) {
+	return lr.buildCondition(nil)
+}
+
+func (lr *LogicalRule) buildCondition(ruleSets map[string]*RuleSetProvider) (Condition, error) {
+	if len(lr.Rule) == 0 {
+		return nil, newError("logical rule has no sub-rules")
+	}
+	conds := make([]Condition, 0, len(lr.Rule))
+	for i, rule := range lr.Rule {
+		cond, err := rule.buildCondition(ruleSets)
+		if err != nil {
+			return nil, newError("failed to build sub-rule ", i).Base(err)
+		}
+		conds = append(conds, cond)
+	}
+
+	switch lr.Operator {
+	case LogicalRule_And:
+		condChan := ConditionChan(conds)
+		return &condChan, nil
+	case LogicalRule_Or:
+		return AnyCondition(conds), nil
+	case LogicalRule_Not:
+		if len(conds) != 1 {
+			return nil, newError("logical NOT rule takes exactly one sub-rule, but got ", len(conds))
+		}
+		return &NotCondition{Condition: conds[0]}, nil
+	default:
+		return nil, newError("unknown logical operator: ", lr.Operator)
+	}
+}
+
 // Build builds the balancing rule
 func (br *BalancingRule) Build(ohm outbound.Manager, dispatcher routing.Dispatcher) (*Balancer, error) {
 	switch br.Strategy {
@@ -161,6 +241,48 @@ func (br *BalancingRule) Build(ohm outbound.Manager, dispatcher routing.Dispatch
 			ohm:       ohm, fallbackTag: br.FallbackTag,
 			strategy: leastLoadStrategy,
 		}, nil
+	case "consistenthash":
+		i, err := serial.GetInstanceOf(br.StrategySettings)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := i.(*StrategyConsistentHashConfig)
+		if !ok {
+			return nil, newError("not a StrategyConsistentHashConfig").AtError()
+		}
+		return &Balancer{
+			selectors: br.OutboundSelector,
+			ohm:       ohm, fallbackTag: br.FallbackTag,
+			strategy: NewConsistentHashStrategy(s),
+		}, nil
+	case "weightedroundrobin":
+		i, err := serial.GetInstanceOf(br.StrategySettings)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := i.(*StrategyWeightedRoundRobinConfig)
+		if !ok {
+			return nil, newError("not a StrategyWeightedRoundRobinConfig").AtError()
+		}
+		return &Balancer{
+			selectors: br.OutboundSelector,
+			ohm:       ohm, fallbackTag: br.FallbackTag,
+			strategy: NewWeightedRoundRobinStrategy(s),
+		}, nil
+	case "failover":
+		i, err := serial.GetInstanceOf(br.StrategySettings)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := i.(*StrategyFailoverConfig)
+		if !ok {
+			return nil, newError("not a StrategyFailoverConfig").AtError()
+		}
+		return &Balancer{
+			selectors: br.OutboundSelector,
+			ohm:       ohm, fallbackTag: br.FallbackTag,
+			strategy: NewFailoverStrategy(s, br.OutboundSelector),
+		}, nil
 	case "random":
 		fallthrough
 	case "":
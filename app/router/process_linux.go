@@ -0,0 +1,234 @@
+//go:build linux
+// +build linux
+
+package router
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/v2fly/v2ray-core/v5/common/net"
+)
+
+// nativeEndian is the byte order the kernel uses to print addresses in /proc/net.
+var nativeEndian binary.ByteOrder = func() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}()
+
+// findProcessOwner looks up the local socket bound to ip:port in /proc/net, and the process holding it if
+// withProcess is set. It returns nil if no such socket exists.
+func findProcessOwner(network net.Network, ip net.IP, port net.Port, withProcess bool) (*processOwner, error) {
+	var tables []string
+	switch network {
+	case net.Network_TCP:
+		tables = []string{"/proc/net/tcp", "/proc/net/tcp6"}
+	case net.Network_UDP:
+		tables = []string{"/proc/net/udp", "/proc/net/udp6"}
+	default:
+		return nil, nil
+	}
+
+	for _, table := range tables {
+		uid, inode, found, err := findSocket(table, ip, port, network == net.Network_UDP)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		owner := &processOwner{UID: uid}
+		if withProcess {
+			if err := findProcess(inode, uid, owner); err != nil {
+				return owner, err
+			}
+		}
+		return owner, nil
+	}
+	return nil, nil
+}
+
+// findSocket scans a /proc/net table for the socket with the given local address. Unbound UDP sockets also
+// match by port.
+func findSocket(table string, ip net.IP, port net.Port, matchUnspecified bool) (uint32, string, bool, error) {
+	f, err := os.Open(table)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, "", false, nil
+		}
+		return 0, "", false, err
+	}
+	defer f.Close()
+
+	var (
+		fallbackUID   uint32
+		fallbackInode string
+		fallbackFound bool
+	)
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // Header line.
+	for scanner.Scan() {
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		localIP, localPort, err := parseProcNetAddress(fields[1])
+		if err != nil || localPort != port {
+			continue
+		}
+		uid, err := strconv.ParseUint(fields[7], 10, 32)
+		if err != nil {
+			continue
+		}
+		switch {
+		case localIP.Equal(ip):
+			return uint32(uid), fields[9], true, nil
+		case matchUnspecified && localIP.IsUnspecified() && !fallbackFound:
+			fallbackUID, fallbackInode, fallbackFound = uint32(uid), fields[9], true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, "", false, err
+	}
+	return fallbackUID, fallbackInode, fallbackFound, nil
+}
+
+// parseProcNetAddress parses an address like "0100007F:0050", where the IP is printed as 32-bit words in host
+// byte order.
+func parseProcNetAddress(s string) (net.IP, net.Port, error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return nil, 0, newError("invalid address: ", s)
+	}
+	raw, err := hex.DecodeString(s[:i])
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return nil, 0, newError("invalid address: ", s)
+	}
+	port, err := strconv.ParseUint(s[i+1:], 16, 16)
+	if err != nil {
+		return nil, 0, newError("invalid port: ", s).Base(err)
+	}
+	ip := make(net.IP, len(raw))
+	for j := 0; j < len(raw); j += 4 {
+		nativeEndian.PutUint32(ip[j:], binary.BigEndian.Uint32(raw[j:]))
+	}
+	return ip, net.Port(port), nil
+}
+
+// maxRecentProcesses is the number of processes remembered to have opened connections recently.
+const maxRecentProcesses = 32
+
+// recentProcesses are the processes that opened connections recently, most recent first. They are checked before
+// scanning all processes, as a program usually opens many connections.
+var recentProcesses struct {
+	sync.Mutex
+	pids []int
+}
+
+func rememberProcess(pid int) {
+	recentProcesses.Lock()
+	defer recentProcesses.Unlock()
+	pids := make([]int, 0, maxRecentProcesses)
+	pids = append(pids, pid)
+	for _, p := range recentProcesses.pids {
+		if p != pid && len(pids) < maxRecentProcesses {
+			pids = append(pids, p)
+		}
+	}
+	recentProcesses.pids = pids
+}
+
+// findProcess finds the process holding the socket inode, and fills its information into owner. Only processes of
+// the socket owner uid are scanned, as the owner is the user of the process that created the socket.
+func findProcess(inode string, uid uint32, owner *processOwner) error {
+	target := "socket:[" + inode + "]"
+
+	recentProcesses.Lock()
+	recent := recentProcesses.pids
+	recentProcesses.Unlock()
+	for _, pid := range recent {
+		if holdsSocket(pid, target) {
+			owner.PID = pid
+			readProcessInfo(pid, owner)
+			rememberProcess(pid)
+			return nil
+		}
+	}
+
+	procs, err := os.ReadDir("/proc")
+	if err != nil {
+		return err
+	}
+	for _, proc := range procs {
+		pid, err := strconv.Atoi(proc.Name())
+		if err != nil || !proc.IsDir() {
+			continue
+		}
+		if info, err := proc.Info(); err != nil {
+			continue
+		} else if stat, ok := info.Sys().(*syscall.Stat_t); ok && stat.Uid != uid {
+			continue
+		}
+		if holdsSocket(pid, target) {
+			owner.PID = pid
+			readProcessInfo(pid, owner)
+			rememberProcess(pid)
+			return nil
+		}
+	}
+	return newError("no process holds socket ", inode)
+}
+
+// holdsSocket tells whether the process pid has an open file descriptor of target, like "socket:[1234]".
+func holdsSocket(pid int, target string) bool {
+	fdDir := filepath.Join("/proc", strconv.Itoa(pid), "fd")
+	fds, err := os.ReadDir(fdDir)
+	if err != nil {
+		return false
+	}
+	for _, fd := range fds {
+		if link, err := os.Readlink(filepath.Join(fdDir, fd.Name())); err == nil && link == target {
+			return true
+		}
+	}
+	return false
+}
+
+func readProcessInfo(pid int, owner *processOwner) {
+	dir := filepath.Join("/proc", strconv.Itoa(pid))
+	if path, err := os.Readlink(filepath.Join(dir, "exe")); err == nil {
+		owner.Path = strings.TrimSuffix(path, " (deleted)")
+		owner.Name = filepath.Base(owner.Path)
+	}
+	if owner.Name == "" {
+		// comm is truncated to 15 bytes, so it is only used when the executable is not readable.
+		if comm, err := os.ReadFile(filepath.Join(dir, "comm")); err == nil {
+			owner.Name = strings.TrimSpace(string(comm))
+		}
+	}
+	if status, err := os.ReadFile(filepath.Join(dir, "status")); err == nil {
+		for _, line := range strings.Split(string(status), "\n") {
+			if !strings.HasPrefix(line, "Gid:") {
+				continue
+			}
+			if fields := strings.Fields(line); len(fields) > 1 {
+				if gid, err := strconv.ParseUint(fields[1], 10, 32); err == nil {
+					owner.GID = uint32(gid)
+				}
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,107 @@
+//go:build linux
+// +build linux
+
+package router
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/session"
+	routing_session "github.com/v2fly/v2ray-core/v5/features/routing/session"
+)
+
+func TestParseProcNetAddress(t *testing.T) {
+	ip, port, err := parseProcNetAddress("0100007F:0050")
+	common.Must(err)
+	if !ip.Equal(net.ParseIP("127.0.0.1")) || port != 80 {
+		t.Error("unexpected address ", ip, ":", port)
+	}
+
+	ip, port, err = parseProcNetAddress("0000000000000000FFFF00000100007F:1F90")
+	common.Must(err)
+	if !ip.Equal(net.ParseIP("127.0.0.1")) || port != 8080 {
+		t.Error("unexpected address ", ip, ":", port)
+	}
+
+	if _, _, err := parseProcNetAddress("0100007F"); err == nil {
+		t.Error("expect error for address without port")
+	}
+}
+
+func TestProcessMatcher(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			conn.Read(make([]byte, 1))
+		}
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	defer conn.Close()
+
+	local := conn.LocalAddr().(*net.TCPAddr)
+	ctx := routing_session.AsRoutingContext(session.ContextWithOutbound(session.ContextWithInbound(context.Background(), &session.Inbound{
+		Source: net.DestinationFromAddr(local),
+	}), &session.Outbound{
+		Target: net.DestinationFromAddr(listener.Addr()),
+	}))
+
+	exe, err := os.Executable()
+	common.Must(err)
+	exe, err = filepath.EvalSymlinks(exe)
+	common.Must(err)
+	uid := uint32(os.Getuid())
+
+	cases := []struct {
+		matcher *ProcessMatcher
+		output  bool
+	}{
+		{matcher: NewProcessMatcher(nil, nil, []uint32{uid}, nil), output: true},
+		{matcher: NewProcessMatcher(nil, nil, []uint32{uid + 1}, nil), output: false},
+		{matcher: NewProcessMatcher([]string{filepath.Base(exe)}, nil, nil, nil), output: true},
+		{matcher: NewProcessMatcher(nil, []string{exe}, nil, []uint32{uint32(os.Getgid())}), output: true},
+		{matcher: NewProcessMatcher([]string{"curl"}, nil, []uint32{uid}, nil), output: false},
+	}
+	for _, c := range cases {
+		if actual := c.matcher.Apply(ctx); actual != c.output {
+			t.Error("expect ", c.output, " for ", c.matcher, " but got ", actual)
+		}
+	}
+
+	remote := NewProcessMatcher(nil, nil, []uint32{uid}, nil)
+	remoteCtx := routing_session.AsRoutingContext(session.ContextWithOutbound(session.ContextWithInbound(context.Background(), &session.Inbound{
+		Source: net.TCPDestination(net.ParseAddress("192.0.2.1"), 12345),
+	}), &session.Outbound{
+		Target: net.DestinationFromAddr(listener.Addr()),
+	}))
+	if remote.Apply(remoteCtx) {
+		t.Error("expect connection from another host not to match")
+	}
+}
+
+func TestProcessOwnerCacheBound(t *testing.T) {
+	c := &processOwnerCache{entries: make(map[string]processOwnerCacheEntry)}
+	now := time.Now()
+	for i := 0; i < processOwnerCacheSize; i++ {
+		c.entries[strconv.Itoa(i)] = processOwnerCacheEntry{expire: now.Add(processOwnerCacheTTL + time.Duration(i)*time.Millisecond)}
+	}
+	c.lookup(net.Network_Unknown, net.ParseIP("127.0.0.1"), 80, false)
+	if len(c.entries) != processOwnerCacheSize {
+		t.Error("expect cache size bounded to ", processOwnerCacheSize, ", but got ", len(c.entries))
+	}
+	if _, found := c.entries["0"]; found {
+		t.Error("expect the oldest entry evicted")
+	}
+}
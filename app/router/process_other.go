@@ -0,0 +1,12 @@
+//go:build !linux
+// +build !linux
+
+package router
+
+import (
+	"github.com/v2fly/v2ray-core/v5/common/net"
+)
+
+func findProcessOwner(network net.Network, ip net.IP, port net.Port, withProcess bool) (*processOwner, error) {
+	return nil, newError("process lookup is not supported on this platform")
+}
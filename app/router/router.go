@@ -4,9 +4,12 @@ package router
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 
 	core "github.com/v2fly/v2ray-core/v5"
 	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/errors"
 	"github.com/v2fly/v2ray-core/v5/common/platform"
 	"github.com/v2fly/v2ray-core/v5/features/dns"
 	"github.com/v2fly/v2ray-core/v5/features/outbound"
@@ -18,10 +21,21 @@ import (
 
 // Router is an implementation of routing.Router.
 type Router struct {
+	ctx            context.Context
 	domainStrategy DomainStrategy
-	rules          []*Rule
-	balancers      map[string]*Balancer
+	table          atomic.Value // *routingTable
+	access         sync.Mutex   // serializes updates of the routing table
+	ruleSets       map[string]*RuleSetProvider
+	lastRuleID     uint32
 	dns            dns.Client
+	ohm            outbound.Manager
+	dispatcher     routing.Dispatcher
+}
+
+// routingTable is the compiled routing rules and balancers. It is immutable once stored in the Router.
+type routingTable struct {
+	rules     []*Rule
+	balancers map[string]*Balancer
 }
 
 // Route is an implementation of routing.Route.
@@ -29,58 +43,116 @@ type Route struct {
 	routing.Context
 	outboundGroupTags []string
 	outboundTag       string
+	ruleTag           string
+	ruleID            uint32
 }
 
 // Init initializes the Router.
 func (r *Router) Init(ctx context.Context, config *Config, d dns.Client, ohm outbound.Manager, dispatcher routing.Dispatcher) error {
+	r.ctx = ctx
 	r.domainStrategy = config.DomainStrategy
 	r.dns = d
+	r.ohm = ohm
+	r.dispatcher = dispatcher
 
-	r.balancers = make(map[string]*Balancer, len(config.BalancingRule))
+	table := &routingTable{
+		balancers: make(map[string]*Balancer, len(config.BalancingRule)),
+	}
 	for _, rule := range config.BalancingRule {
-		balancer, err := rule.Build(ohm, dispatcher)
+		balancer, err := r.buildBalancer(rule)
 		if err != nil {
 			return err
 		}
-		balancer.InjectContext(ctx)
-		r.balancers[rule.Tag] = balancer
+		table.balancers[rule.Tag] = balancer
 	}
 
-	r.rules = make([]*Rule, 0, len(config.Rule))
-	for _, rule := range config.Rule {
-		cond, err := rule.BuildCondition()
+	r.ruleSets = make(map[string]*RuleSetProvider, len(config.RuleSet))
+	for _, set := range config.RuleSet {
+		if _, found := r.ruleSets[set.Tag]; found {
+			return newError("duplicated rule set tag: ", set.Tag)
+		}
+		provider, err := NewRuleSetProvider(ctx, set)
 		if err != nil {
 			return err
 		}
-		rr := &Rule{
-			Condition: cond,
-			Tag:       rule.GetTag(),
+		r.ruleSets[set.Tag] = provider
+	}
+
+	table.rules = make([]*Rule, 0, len(config.Rule))
+	for _, rule := range config.Rule {
+		if rule.RuleTag != "" && table.findRule(rule.RuleTag) >= 0 {
+			return newError("duplicated rule tag: ", rule.RuleTag)
 		}
-		btag := rule.GetBalancingTag()
-		if len(btag) > 0 {
-			brule, found := r.balancers[btag]
-			if !found {
-				return newError("balancer ", btag, " not found")
-			}
-			rr.Balancer = brule
+		rr, err := r.buildRule(rule, table.balancers)
+		if err != nil {
+			return err
 		}
-		r.rules = append(r.rules, rr)
+		table.rules = append(table.rules, rr)
 	}
+	r.table.Store(table)
 
 	return nil
 }
 
+func (r *Router) buildRule(config *RoutingRule, balancers map[string]*Balancer) (*Rule, error) {
+	cond, err := config.buildCondition(r.ruleSets)
+	if err != nil {
+		return nil, err
+	}
+	rr := &Rule{
+		Condition: cond,
+		Tag:       config.GetTag(),
+		RuleTag:   config.RuleTag,
+		ID:        atomic.AddUint32(&r.lastRuleID, 1),
+		config:    config,
+	}
+	btag := config.GetBalancingTag()
+	if len(btag) > 0 {
+		brule, found := balancers[btag]
+		if !found {
+			return nil, newError("balancer ", btag, " not found")
+		}
+		rr.Balancer = brule
+	}
+	return rr, nil
+}
+
+func (r *Router) buildBalancer(config *BalancingRule) (*Balancer, error) {
+	balancer, err := config.Build(r.ohm, r.dispatcher)
+	if err != nil {
+		return nil, err
+	}
+	balancer.InjectContext(r.ctx)
+	balancer.config = config
+	return balancer, nil
+}
+
+// getTable returns the routing table currently in effect.
+func (r *Router) getTable() *routingTable {
+	return r.table.Load().(*routingTable)
+}
+
+// findRule returns the index of the rule with the given rule tag, or -1 if not found.
+func (t *routingTable) findRule(tag string) int {
+	for i, rule := range t.rules {
+		if rule.RuleTag == tag {
+			return i
+		}
+	}
+	return -1
+}
+
 // PickRoute implements routing.Router.
 func (r *Router) PickRoute(ctx routing.Context) (routing.Route, error) {
 	rule, ctx, err := r.pickRouteInternal(ctx)
 	if err != nil {
 		return nil, err
 	}
-	tag, err := rule.GetTag()
+	tag, err := rule.GetTagWithContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &Route{Context: ctx, outboundTag: tag}, nil
+	return &Route{Context: ctx, outboundTag: tag, ruleTag: rule.RuleTag, ruleID: rule.ID}, nil
 }
 
 func (r *Router) pickRouteInternal(ctx routing.Context) (*Rule, routing.Context, error) {
@@ -93,7 +165,8 @@ func (r *Router) pickRouteInternal(ctx routing.Context) (*Rule, routing.Context,
 		ctx = routing_dns.ContextWithDNSClient(ctx, r.dns)
 	}
 
-	for _, rule := range r.rules {
+	table := r.getTable()
+	for _, rule := range table.rules {
 		if rule.Apply(ctx) {
 			return rule, ctx, nil
 		}
@@ -106,7 +179,7 @@ func (r *Router) pickRouteInternal(ctx routing.Context) (*Rule, routing.Context,
 	ctx = routing_dns.ContextWithDNSClient(ctx, r.dns)
 
 	// Try applying rules again if we have IPs.
-	for _, rule := range r.rules {
+	for _, rule := range table.rules {
 		if rule.Apply(ctx) {
 			return rule, ctx, nil
 		}
@@ -117,12 +190,21 @@ func (r *Router) pickRouteInternal(ctx routing.Context) (*Rule, routing.Context,
 
 // Start implements common.Runnable.
 func (r *Router) Start() error {
+	for _, set := range r.ruleSets {
+		if err := set.Start(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // Close implements common.Closable.
 func (r *Router) Close() error {
-	return nil
+	var errs []error
+	for _, set := range r.ruleSets {
+		errs = append(errs, set.Close())
+	}
+	return errors.Combine(errs...)
 }
 
 // Type implements common.HasType.
@@ -140,6 +222,16 @@ func (r *Route) GetOutboundTag() string {
 	return r.outboundTag
 }
 
+// GetRuleTag implements routing.RuleRoute.
+func (r *Route) GetRuleTag() string {
+	return r.ruleTag
+}
+
+// GetRuleID implements routing.RuleRoute.
+func (r *Route) GetRuleID() uint32 {
+	return r.ruleID
+}
+
 func init() {
 	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
 		r := new(Router)
@@ -172,84 +264,9 @@ func init() {
 		var routingRules []*RoutingRule
 
 		for _, v := range simplifiedConfig.Rule {
-			rule := new(RoutingRule)
-
-			for _, geo := range v.Geoip {
-				if geo.Code != "" {
-					filepath := "geoip.dat"
-					if geo.FilePath != "" {
-						filepath = geo.FilePath
-					} else {
-						geo.CountryCode = geo.Code
-					}
-					var err error
-					geo.Cidr, err = geoLoader.LoadIP(filepath, geo.Code)
-					if err != nil {
-						return nil, newError("unable to load geoip").Base(err)
-					}
-				}
-			}
-			rule.Geoip = v.Geoip
-
-			for _, geo := range v.SourceGeoip {
-				if geo.Code != "" {
-					filepath := "geoip.dat"
-					if geo.FilePath != "" {
-						filepath = geo.FilePath
-					} else {
-						geo.CountryCode = geo.Code
-					}
-					var err error
-					geo.Cidr, err = geoLoader.LoadIP(filepath, geo.Code)
-					if err != nil {
-						return nil, newError("unable to load geoip").Base(err)
-					}
-				}
-			}
-			rule.SourceGeoip = v.SourceGeoip
-
-			for _, geo := range v.GeoDomain {
-				if geo.Code != "" {
-					filepath := "geosite.dat"
-					if geo.FilePath != "" {
-						filepath = geo.FilePath
-					}
-					var err error
-					geo.Domain, err = geoLoader.LoadGeoSiteWithAttr(filepath, geo.Code)
-					if err != nil {
-						return nil, newError("unable to load geodomain").Base(err)
-					}
-				}
-			}
-			if v.PortList != "" {
-				portList := &cfgcommon.PortList{}
-				err := portList.UnmarshalText(v.PortList)
-				if err != nil {
-					return nil, err
-				}
-				rule.PortList = portList.Build()
-			}
-			if v.SourcePortList != "" {
-				portList := &cfgcommon.PortList{}
-				err := portList.UnmarshalText(v.SourcePortList)
-				if err != nil {
-					return nil, err
-				}
-				rule.SourcePortList = portList.Build()
-			}
-			rule.Domain = v.Domain
-			rule.GeoDomain = v.GeoDomain
-			rule.Networks = v.Networks.GetNetwork()
-			rule.Protocol = v.Protocol
-			rule.Attributes = v.Attributes
-			rule.UserEmail = v.UserEmail
-			rule.InboundTag = v.InboundTag
-			rule.DomainMatcher = v.DomainMatcher
-			switch s := v.TargetTag.(type) {
-			case *SimplifiedRoutingRule_Tag:
-				rule.TargetTag = &RoutingRule_Tag{s.Tag}
-			case *SimplifiedRoutingRule_BalancingTag:
-				rule.TargetTag = &RoutingRule_BalancingTag{s.BalancingTag}
+			rule, err := buildSimplifiedRoutingRule(v, geoLoader)
+			if err != nil {
+				return nil, err
 			}
 			routingRules = append(routingRules, rule)
 		}
@@ -258,7 +275,110 @@ func init() {
 			DomainStrategy: simplifiedConfig.DomainStrategy,
 			Rule:           routingRules,
 			BalancingRule:  simplifiedConfig.BalancingRule,
+			RuleSet:        simplifiedConfig.RuleSet,
 		}
 		return common.CreateObject(ctx, fullConfig)
 	}))
 }
+
+func buildSimplifiedRoutingRule(v *SimplifiedRoutingRule, geoLoader geodata.Loader) (*RoutingRule, error) {
+	rule := new(RoutingRule)
+
+	for _, geo := range v.Geoip {
+		if geo.Code != "" {
+			filepath := "geoip.dat"
+			if geo.FilePath != "" {
+				filepath = geo.FilePath
+			} else {
+				geo.CountryCode = geo.Code
+			}
+			var err error
+			geo.Cidr, err = geoLoader.LoadIP(filepath, geo.Code)
+			if err != nil {
+				return nil, newError("unable to load geoip").Base(err)
+			}
+		}
+	}
+	rule.Geoip = v.Geoip
+
+	for _, geo := range v.SourceGeoip {
+		if geo.Code != "" {
+			filepath := "geoip.dat"
+			if geo.FilePath != "" {
+				filepath = geo.FilePath
+			} else {
+				geo.CountryCode = geo.Code
+			}
+			var err error
+			geo.Cidr, err = geoLoader.LoadIP(filepath, geo.Code)
+			if err != nil {
+				return nil, newError("unable to load geoip").Base(err)
+			}
+		}
+	}
+	rule.SourceGeoip = v.SourceGeoip
+
+	for _, geo := range v.GeoDomain {
+		if geo.Code != "" {
+			filepath := "geosite.dat"
+			if geo.FilePath != "" {
+				filepath = geo.FilePath
+			}
+			var err error
+			geo.Domain, err = geoLoader.LoadGeoSiteWithAttr(filepath, geo.Code)
+			if err != nil {
+				return nil, newError("unable to load geodomain").Base(err)
+			}
+		}
+	}
+	if v.PortList != "" {
+		portList := &cfgcommon.PortList{}
+		err := portList.UnmarshalText(v.PortList)
+		if err != nil {
+			return nil, err
+		}
+		rule.PortList = portList.Build()
+	}
+	if v.SourcePortList != "" {
+		portList := &cfgcommon.PortList{}
+		err := portList.UnmarshalText(v.SourcePortList)
+		if err != nil {
+			return nil, err
+		}
+		rule.SourcePortList = portList.Build()
+	}
+	rule.Domain = v.Domain
+	rule.GeoDomain = v.GeoDomain
+	rule.Networks = v.Networks.GetNetwork()
+	rule.Protocol = v.Protocol
+	rule.Attributes = v.Attributes
+	rule.UserEmail = v.UserEmail
+	rule.InboundTag = v.InboundTag
+	rule.DomainMatcher = v.DomainMatcher
+	rule.ProcessName = v.ProcessName
+	rule.ProcessPath = v.ProcessPath
+	rule.Uid = v.Uid
+	rule.Gid = v.Gid
+	rule.Schedule = v.Schedule
+	rule.RuleSet = v.RuleSet
+	rule.RuleTag = v.RuleTag
+	if v.Logical != nil {
+		rule.Logical = &LogicalRule{
+			Operator: v.Logical.Operator,
+		}
+		for _, sub := range v.Logical.Rule {
+			subRule, err := buildSimplifiedRoutingRule(sub, geoLoader)
+			if err != nil {
+				return nil, err
+			}
+			rule.Logical.Rule = append(rule.Logical.Rule, subRule)
+		}
+	}
+	switch s := v.TargetTag.(type) {
+	case *SimplifiedRoutingRule_Tag:
+		rule.TargetTag = &RoutingRule_Tag{s.Tag}
+	case *SimplifiedRoutingRule_BalancingTag:
+		rule.TargetTag = &RoutingRule_BalancingTag{s.BalancingTag}
+	}
+	return rule, nil
+}
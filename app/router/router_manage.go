@@ -0,0 +1,188 @@
+package router
+
+import (
+	"sort"
+)
+
+// ListRules returns the routing rules in the order they are matched, and the balancing rules ordered by tag.
+func (r *Router) ListRules() ([]*RoutingRule, []*BalancingRule) {
+	table := r.getTable()
+	rules := make([]*RoutingRule, 0, len(table.rules))
+	for _, rule := range table.rules {
+		rules = append(rules, rule.config)
+	}
+	balancingRules := make([]*BalancingRule, 0, len(table.balancers))
+	for _, balancer := range table.balancers {
+		balancingRules = append(balancingRules, balancer.config)
+	}
+	sort.Slice(balancingRules, func(i, j int) bool {
+		return balancingRules[i].Tag < balancingRules[j].Tag
+	})
+	return rules, balancingRules
+}
+
+// ListRuleIDs returns the tags and identities of the routing rules in the order they are matched.
+func (r *Router) ListRuleIDs() ([]string, []uint32) {
+	table := r.getTable()
+	tags := make([]string, 0, len(table.rules))
+	ids := make([]uint32, 0, len(table.rules))
+	for _, rule := range table.rules {
+		tags = append(tags, rule.RuleTag)
+		ids = append(ids, rule.ID)
+	}
+	return tags, ids
+}
+
+// AddRule inserts a routing rule before the rule at index. The rule is appended if index is negative or beyond the
+// last rule.
+func (r *Router) AddRule(config *RoutingRule, index int) error {
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	table := r.getTable()
+	if config.RuleTag != "" && table.findRule(config.RuleTag) >= 0 {
+		return newError("duplicated rule tag: ", config.RuleTag)
+	}
+	rule, err := r.buildRule(config, table.balancers)
+	if err != nil {
+		return newError("failed to build rule").Base(err)
+	}
+	if index < 0 || index > len(table.rules) {
+		index = len(table.rules)
+	}
+
+	rules := make([]*Rule, 0, len(table.rules)+1)
+	rules = append(rules, table.rules[:index]...)
+	rules = append(rules, rule)
+	rules = append(rules, table.rules[index:]...)
+	r.table.Store(&routingTable{rules: rules, balancers: table.balancers})
+	return nil
+}
+
+// ReplaceRule replaces the routing rule with the given rule tag. The new rule inherits the tag if it has none, and
+// the identity of the replaced rule.
+func (r *Router) ReplaceRule(tag string, config *RoutingRule) error {
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	table := r.getTable()
+	index := table.findRule(tag)
+	if tag == "" || index < 0 {
+		return newError("rule not found: ", tag)
+	}
+	if config.RuleTag == "" {
+		config.RuleTag = tag
+	} else if config.RuleTag != tag && table.findRule(config.RuleTag) >= 0 {
+		return newError("duplicated rule tag: ", config.RuleTag)
+	}
+	rule, err := r.buildRule(config, table.balancers)
+	if err != nil {
+		return newError("failed to build rule").Base(err)
+	}
+
+	rule.ID = table.rules[index].ID
+
+	rules := make([]*Rule, len(table.rules))
+	copy(rules, table.rules)
+	rules[index] = rule
+	r.table.Store(&routingTable{rules: rules, balancers: table.balancers})
+	return nil
+}
+
+// RemoveRule removes the routing rule with the given rule tag.
+func (r *Router) RemoveRule(tag string) error {
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	table := r.getTable()
+	index := table.findRule(tag)
+	if tag == "" || index < 0 {
+		return newError("rule not found: ", tag)
+	}
+
+	rules := make([]*Rule, 0, len(table.rules)-1)
+	rules = append(rules, table.rules[:index]...)
+	rules = append(rules, table.rules[index+1:]...)
+	r.table.Store(&routingTable{rules: rules, balancers: table.balancers})
+	return nil
+}
+
+// AddBalancingRule adds a balancing rule with a new tag.
+func (r *Router) AddBalancingRule(config *BalancingRule) error {
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	table := r.getTable()
+	if _, found := table.balancers[config.Tag]; found {
+		return newError("duplicated balancer tag: ", config.Tag)
+	}
+	balancer, err := r.buildBalancer(config)
+	if err != nil {
+		return newError("failed to build balancer").Base(err)
+	}
+
+	balancers := table.copyBalancers()
+	balancers[config.Tag] = balancer
+	r.table.Store(&routingTable{rules: table.rules, balancers: balancers})
+	return nil
+}
+
+// ReplaceBalancingRule replaces the balancing rule with the same tag. Routing rules pointing to the balancer are
+// switched to the new one.
+func (r *Router) ReplaceBalancingRule(config *BalancingRule) error {
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	table := r.getTable()
+	if _, found := table.balancers[config.Tag]; !found {
+		return newError("balancer not found: ", config.Tag)
+	}
+	balancer, err := r.buildBalancer(config)
+	if err != nil {
+		return newError("failed to build balancer").Base(err)
+	}
+
+	balancers := table.copyBalancers()
+	balancers[config.Tag] = balancer
+	rules := make([]*Rule, len(table.rules))
+	for i, rule := range table.rules {
+		if rule.config.GetBalancingTag() == config.Tag {
+			relinked := *rule
+			relinked.Balancer = balancer
+			rule = &relinked
+		}
+		rules[i] = rule
+	}
+	r.table.Store(&routingTable{rules: rules, balancers: balancers})
+	return nil
+}
+
+// RemoveBalancingRule removes the balancing rule with the given tag. It fails if any routing rule points to the
+// balancer.
+func (r *Router) RemoveBalancingRule(tag string) error {
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	table := r.getTable()
+	if _, found := table.balancers[tag]; !found {
+		return newError("balancer not found: ", tag)
+	}
+	for _, rule := range table.rules {
+		if rule.config.GetBalancingTag() == tag {
+			return newError("balancer ", tag, " is in use by rule ", rule.RuleTag)
+		}
+	}
+
+	balancers := table.copyBalancers()
+	delete(balancers, tag)
+	r.table.Store(&routingTable{rules: table.rules, balancers: balancers})
+	return nil
+}
+
+func (t *routingTable) copyBalancers() map[string]*Balancer {
+	balancers := make(map[string]*Balancer, len(t.balancers)+1)
+	for tag, balancer := range t.balancers {
+		balancers[tag] = balancer
+	}
+	return balancers
+}
@@ -0,0 +1,223 @@
+package router
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/signal/done"
+	"github.com/v2fly/v2ray-core/v5/features/routing"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tagged"
+)
+
+const (
+	ruleSetFileRefreshInterval = time.Second * 10
+	ruleSetURLRefreshInterval  = time.Hour * 24
+	ruleSetRetryInterval       = time.Minute
+	ruleSetMaxSize             = 64 * 1024 * 1024
+)
+
+// ruleSetMatcher is a compiled rule set.
+type ruleSetMatcher struct {
+	domain *DomainMatcher
+	ip     *GeoIPMatcher
+}
+
+func newRuleSetMatcher(content *ruleSetContent) (*ruleSetMatcher, error) {
+	m := new(ruleSetMatcher)
+	if len(content.domains) > 0 {
+		domain, err := NewDomainMatcher("mph", content.domains)
+		if err != nil {
+			return nil, err
+		}
+		m.domain = domain
+	}
+	if len(content.cidrs) > 0 {
+		m.ip = new(GeoIPMatcher)
+		if err := m.ip.Init(content.cidrs); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// RuleSetProvider provides a rule set loaded from a file or URL. It refreshes in background, and swaps the
+// compiled matcher atomically when the content changes.
+type RuleSetProvider struct {
+	ctx     context.Context
+	config  *RuleSet
+	matcher atomic.Value // *ruleSetMatcher
+	modTime time.Time
+	done    *done.Instance
+}
+
+// NewRuleSetProvider creates a rule set provider. Rule sets from files are loaded immediately.
+func NewRuleSetProvider(ctx context.Context, config *RuleSet) (*RuleSetProvider, error) {
+	if config.Tag == "" {
+		return nil, newError("rule set tag is empty")
+	}
+	s := &RuleSetProvider{
+		ctx:    ctx,
+		config: config,
+		done:   done.New(),
+	}
+	s.matcher.Store(&ruleSetMatcher{})
+	if config.GetPath() != "" {
+		if _, err := s.refresh(); err != nil {
+			return nil, newError("failed to load rule set ", config.Tag).Base(err)
+		}
+	} else if config.GetUrl() == "" {
+		return nil, newError("neither path nor url is specified in rule set ", config.Tag)
+	}
+	return s, nil
+}
+
+func (s *RuleSetProvider) refreshInterval() time.Duration {
+	if s.config.RefreshInterval > 0 {
+		return time.Duration(s.config.RefreshInterval) * time.Second
+	}
+	if s.config.GetPath() != "" {
+		return ruleSetFileRefreshInterval
+	}
+	return ruleSetURLRefreshInterval
+}
+
+// refresh loads the rule set again. It returns false if the file is not changed since the last load.
+func (s *RuleSetProvider) refresh() (bool, error) {
+	var data []byte
+	if path := s.config.GetPath(); path != "" {
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, err
+		}
+		if info.ModTime().Equal(s.modTime) {
+			return false, nil
+		}
+		if data, err = os.ReadFile(path); err != nil {
+			return false, err
+		}
+		s.modTime = info.ModTime()
+	} else {
+		var err error
+		if data, err = s.download(); err != nil {
+			return false, err
+		}
+	}
+
+	content, err := parseRuleSet(s.config.Format, s.config.Code, data)
+	if err != nil {
+		return false, err
+	}
+	matcher, err := newRuleSetMatcher(content)
+	if err != nil {
+		return false, err
+	}
+	s.matcher.Store(matcher)
+	newError("rule set ", s.config.Tag, " loaded with ", len(content.domains), " domains and ", len(content.cidrs), " CIDRs").AtInfo().WriteToLog()
+	return true, nil
+}
+
+func (s *RuleSetProvider) download() ([]byte, error) {
+	if tagged.Dialer == nil {
+		return nil, newError("tagged dialer is not available to download rule set ", s.config.Tag)
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy: func(*http.Request) (*url.URL, error) {
+				return nil, nil
+			},
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				dest, err := net.ParseDestination(network + ":" + addr)
+				if err != nil {
+					return nil, newError("cannot understand address").Base(err)
+				}
+				return tagged.Dialer(s.ctx, dest, s.config.OutboundTag)
+			},
+		},
+		Timeout: time.Minute,
+	}
+	resp, err := client.Get(s.config.GetUrl())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, newError("unexpected HTTP status ", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, ruleSetMaxSize))
+}
+
+// Start implements common.Runnable.
+func (s *RuleSetProvider) Start() error {
+	go s.run()
+	return nil
+}
+
+func (s *RuleSetProvider) run() {
+	loaded := s.config.GetPath() != ""
+	wait := s.refreshInterval()
+	if !loaded {
+		wait = 0
+	}
+	for {
+		select {
+		case <-s.done.Wait():
+			return
+		case <-time.After(wait):
+		}
+		wait = s.refreshInterval()
+		if _, err := s.refresh(); err != nil {
+			newError("failed to refresh rule set ", s.config.Tag).Base(err).AtWarning().WriteToLog()
+			if !loaded && wait > ruleSetRetryInterval {
+				wait = ruleSetRetryInterval
+			}
+			continue
+		}
+		loaded = true
+	}
+}
+
+// Close implements common.Closable.
+func (s *RuleSetProvider) Close() error {
+	return s.done.Close()
+}
+
+func (s *RuleSetProvider) load() *ruleSetMatcher {
+	return s.matcher.Load().(*ruleSetMatcher)
+}
+
+// RuleSetMatcher matches the target domain or IPs against rule sets.
+type RuleSetMatcher struct {
+	sets []*RuleSetProvider
+}
+
+func NewRuleSetMatcher(sets []*RuleSetProvider) *RuleSetMatcher {
+	return &RuleSetMatcher{sets: sets}
+}
+
+// Apply implements Condition.
+func (m *RuleSetMatcher) Apply(ctx routing.Context) bool {
+	domain := ctx.GetTargetDomain()
+	for _, s := range m.sets {
+		if matcher := s.load(); matcher.domain != nil && len(domain) > 0 && matcher.domain.Match(domain) {
+			return true
+		}
+	}
+	for _, s := range m.sets {
+		matcher := s.load()
+		if matcher.ip == nil {
+			continue
+		}
+		for _, ip := range ctx.GetTargetIPs() {
+			if matcher.ip.Match(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
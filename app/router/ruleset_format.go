@@ -0,0 +1,265 @@
+package router
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/v2fly/v2ray-core/v5/app/router/routercommon"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+)
+
+// ruleSetContent is the domains and CIDRs parsed from a rule set.
+type ruleSetContent struct {
+	domains []*routercommon.Domain
+	cidrs   []*routercommon.CIDR
+}
+
+func (c *ruleSetContent) addDomain(domainType routercommon.Domain_Type, value string) {
+	if domainType != routercommon.Domain_Regex {
+		value = strings.ToLower(value)
+	}
+	c.domains = append(c.domains, &routercommon.Domain{
+		Type:  domainType,
+		Value: value,
+	})
+}
+
+func (c *ruleSetContent) addCIDR(s string) error {
+	cidr, err := parseRuleSetCIDR(s)
+	if err != nil {
+		return err
+	}
+	c.cidrs = append(c.cidrs, cidr)
+	return nil
+}
+
+// parseRuleSetCIDR parses a CIDR or a single IP.
+func parseRuleSetCIDR(s string) (*routercommon.CIDR, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, newError("invalid IP: ", s)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return &routercommon.CIDR{Ip: ip4, Prefix: 32}, nil
+		}
+		return &routercommon.CIDR{Ip: ip, Prefix: 128}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, newError("invalid CIDR: ", s).Base(err)
+	}
+	ones, _ := ipNet.Mask.Size()
+	return &routercommon.CIDR{Ip: ipNet.IP, Prefix: uint32(ones)}, nil
+}
+
+func isRuleSetCIDR(s string) bool {
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		s = s[:i]
+	}
+	return net.ParseIP(s) != nil
+}
+
+func parseRuleSet(format RuleSet_Format, code string, data []byte) (*ruleSetContent, error) {
+	switch format {
+	case RuleSet_Text:
+		return parseTextRuleSet(data)
+	case RuleSet_GeoSite:
+		return parseGeoSiteRuleSet(code, data)
+	case RuleSet_GeoIP:
+		return parseGeoIPRuleSet(code, data)
+	case RuleSet_Clash:
+		return parseClashRuleSet(data)
+	case RuleSet_SingBox:
+		return parseSingBoxRuleSet(data)
+	default:
+		return nil, newError("unknown rule set format: ", format)
+	}
+}
+
+func parseTextRuleSet(data []byte) (*ruleSetContent, error) {
+	content := new(ruleSetContent)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "domain:"):
+			content.addDomain(routercommon.Domain_RootDomain, line[7:])
+		case strings.HasPrefix(line, "full:"):
+			content.addDomain(routercommon.Domain_Full, line[5:])
+		case strings.HasPrefix(line, "keyword:"):
+			content.addDomain(routercommon.Domain_Plain, line[8:])
+		case strings.HasPrefix(line, "regexp:"):
+			content.addDomain(routercommon.Domain_Regex, line[7:])
+		case isRuleSetCIDR(line):
+			if err := content.addCIDR(line); err != nil {
+				return nil, err
+			}
+		default:
+			content.addDomain(routercommon.Domain_RootDomain, strings.TrimLeft(line, "+."))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func parseGeoSiteRuleSet(code string, data []byte) (*ruleSetContent, error) {
+	var list routercommon.GeoSiteList
+	if err := proto.Unmarshal(data, &list); err != nil {
+		return nil, newError("invalid geosite data").Base(err)
+	}
+	for _, entry := range list.Entry {
+		if strings.EqualFold(entry.CountryCode, code) {
+			return &ruleSetContent{domains: entry.Domain}, nil
+		}
+	}
+	return nil, newError("code not found in geosite data: ", code)
+}
+
+func parseGeoIPRuleSet(code string, data []byte) (*ruleSetContent, error) {
+	var list routercommon.GeoIPList
+	if err := proto.Unmarshal(data, &list); err != nil {
+		return nil, newError("invalid geoip data").Base(err)
+	}
+	for _, entry := range list.Entry {
+		if strings.EqualFold(entry.CountryCode, code) {
+			return &ruleSetContent{cidrs: entry.Cidr}, nil
+		}
+	}
+	return nil, newError("code not found in geoip data: ", code)
+}
+
+// parseClashRuleSet parses the payload of a Clash rule provider. Behaviors are told apart by each entry.
+func parseClashRuleSet(data []byte) (*ruleSetContent, error) {
+	var provider struct {
+		Payload []string `yaml:"payload"`
+	}
+	if err := yaml.Unmarshal(data, &provider); err != nil {
+		return nil, newError("invalid clash rule provider").Base(err)
+	}
+
+	content := new(ruleSetContent)
+	for _, entry := range provider.Payload {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, ",") {
+			// Classical behavior, such as "DOMAIN-SUFFIX,v2fly.org" or "IP-CIDR,10.0.0.0/8,no-resolve".
+			parts := strings.Split(entry, ",")
+			value := strings.TrimSpace(parts[1])
+			switch strings.ToUpper(strings.TrimSpace(parts[0])) {
+			case "DOMAIN":
+				content.addDomain(routercommon.Domain_Full, value)
+			case "DOMAIN-SUFFIX":
+				content.addDomain(routercommon.Domain_RootDomain, value)
+			case "DOMAIN-KEYWORD":
+				content.addDomain(routercommon.Domain_Plain, value)
+			case "DOMAIN-REGEX":
+				content.addDomain(routercommon.Domain_Regex, value)
+			case "IP-CIDR", "IP-CIDR6":
+				if err := content.addCIDR(value); err != nil {
+					return nil, err
+				}
+			default:
+				newError("unsupported clash rule ignored: ", entry).AtDebug().WriteToLog()
+			}
+			continue
+		}
+		switch {
+		case isRuleSetCIDR(entry):
+			if err := content.addCIDR(entry); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(entry, "+."):
+			content.addDomain(routercommon.Domain_RootDomain, entry[2:])
+		case strings.HasPrefix(entry, "*."):
+			// A wildcard matches exactly one level of subdomain.
+			content.addDomain(routercommon.Domain_Regex, `^[^.]+\.`+regexp.QuoteMeta(strings.ToLower(entry[2:]))+`$`)
+		case strings.HasPrefix(entry, "."):
+			// Subdomains of any level, but not the domain itself.
+			content.addDomain(routercommon.Domain_Regex, `\.`+regexp.QuoteMeta(strings.ToLower(entry[1:]))+`$`)
+		default:
+			content.addDomain(routercommon.Domain_Full, entry)
+		}
+	}
+	return content, nil
+}
+
+// ruleSetStrings is a string or a list of strings in JSON.
+type ruleSetStrings []string
+
+func (v *ruleSetStrings) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*v = list
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*v = []string{s}
+	return nil
+}
+
+// parseSingBoxRuleSet parses a sing-box rule set in source format. Only domain and IP CIDR fields of headless
+// rules are loaded.
+func parseSingBoxRuleSet(data []byte) (*ruleSetContent, error) {
+	var ruleSet struct {
+		Version int `json:"version"`
+		Rules   []struct {
+			Type          string         `json:"type"`
+			Invert        bool           `json:"invert"`
+			Domain        ruleSetStrings `json:"domain"`
+			DomainSuffix  ruleSetStrings `json:"domain_suffix"`
+			DomainKeyword ruleSetStrings `json:"domain_keyword"`
+			DomainRegex   ruleSetStrings `json:"domain_regex"`
+			IPCIDR        ruleSetStrings `json:"ip_cidr"`
+		} `json:"rules"`
+	}
+	if err := json.Unmarshal(data, &ruleSet); err != nil {
+		return nil, newError("invalid sing-box rule set").Base(err)
+	}
+
+	content := new(ruleSetContent)
+	for _, rule := range ruleSet.Rules {
+		if rule.Type == "logical" || rule.Invert {
+			newError("unsupported sing-box rule ignored").AtDebug().WriteToLog()
+			continue
+		}
+		for _, d := range rule.Domain {
+			content.addDomain(routercommon.Domain_Full, d)
+		}
+		for _, d := range rule.DomainSuffix {
+			if strings.HasPrefix(d, ".") {
+				content.addDomain(routercommon.Domain_Regex, regexp.QuoteMeta(strings.ToLower(d))+`$`)
+			} else {
+				content.addDomain(routercommon.Domain_RootDomain, d)
+			}
+		}
+		for _, d := range rule.DomainKeyword {
+			content.addDomain(routercommon.Domain_Plain, d)
+		}
+		for _, d := range rule.DomainRegex {
+			content.addDomain(routercommon.Domain_Regex, d)
+		}
+		for _, c := range rule.IPCIDR {
+			if err := content.addCIDR(c); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return content, nil
+}
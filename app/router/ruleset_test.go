@@ -0,0 +1,166 @@
+package router
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/v2fly/v2ray-core/v5/app/router/routercommon"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/session"
+	routing_session "github.com/v2fly/v2ray-core/v5/features/routing/session"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tagged"
+)
+
+func TestParseRuleSet(t *testing.T) {
+	geoSite, err := proto.Marshal(&routercommon.GeoSiteList{
+		Entry: []*routercommon.GeoSite{
+			{CountryCode: "TEST", Domain: []*routercommon.Domain{{Type: routercommon.Domain_RootDomain, Value: "v2fly.org"}}},
+		},
+	})
+	common.Must(err)
+
+	cases := []struct {
+		format  RuleSet_Format
+		code    string
+		data    string
+		match   []string
+		noMatch []string
+	}{
+		{
+			format:  RuleSet_Text,
+			data:    "# comment\ndomain:v2fly.org\nfull:www.example.com\nkeyword:google\nregexp:^ads\\.\n+.Github.com\n10.0.0.0/8\n2001:db8::1\n",
+			match:   []string{"www.v2fly.org", "www.example.com", "google.cn", "ads.test", "api.github.com", "10.1.2.3", "2001:db8::1"},
+			noMatch: []string{"example.com", "github.io", "11.0.0.1", "2001:db8::2"},
+		},
+		{
+			format:  RuleSet_GeoSite,
+			code:    "test",
+			data:    string(geoSite),
+			match:   []string{"v2fly.org"},
+			noMatch: []string{"example.com"},
+		},
+		{
+			format: RuleSet_Clash,
+			data: `payload:
+  - DOMAIN-SUFFIX,v2fly.org
+  - DOMAIN,www.example.com
+  - IP-CIDR,192.168.0.0/16,no-resolve
+  - '+.github.com'
+  - '*.google.com'
+  - '.apple.com'
+  - 172.16.0.0/12
+`,
+			match:   []string{"v2fly.org", "www.example.com", "192.168.1.1", "github.com", "www.google.com", "a.b.apple.com", "172.16.0.1"},
+			noMatch: []string{"example.com", "google.com", "a.b.google.com", "apple.com", "10.0.0.1"},
+		},
+		{
+			format: RuleSet_SingBox,
+			data: `{
+				"version": 1,
+				"rules": [
+					{"domain": "www.example.com", "domain_suffix": ["v2fly.org", ".apple.com"], "domain_keyword": "google"},
+					{"ip_cidr": ["10.0.0.0/8"]},
+					{"domain": "invert.com", "invert": true}
+				]
+			}`,
+			match:   []string{"www.example.com", "v2fly.org", "www.apple.com", "google.cn", "10.0.0.1"},
+			noMatch: []string{"example.com", "apple.com", "invert.com", "11.0.0.1"},
+		},
+	}
+
+	for _, tc := range cases {
+		content, err := parseRuleSet(tc.format, tc.code, []byte(tc.data))
+		common.Must(err)
+		matcher, err := newRuleSetMatcher(content)
+		common.Must(err)
+		for _, s := range tc.match {
+			if !matchRuleSet(matcher, s) {
+				t.Error(tc.format, ": expect ", s, " to match")
+			}
+		}
+		for _, s := range tc.noMatch {
+			if matchRuleSet(matcher, s) {
+				t.Error(tc.format, ": expect ", s, " not to match")
+			}
+		}
+	}
+}
+
+func matchRuleSet(matcher *ruleSetMatcher, s string) bool {
+	if ip := net.ParseIP(s); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		return matcher.ip != nil && matcher.ip.Match(ip)
+	}
+	return matcher.domain != nil && matcher.domain.Match(s)
+}
+
+func TestRuleSetProviderReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.txt")
+	common.Must(os.WriteFile(path, []byte("v2fly.org\n"), 0o644))
+
+	provider, err := NewRuleSetProvider(context.Background(), &RuleSet{
+		Tag:             "test",
+		Source:          &RuleSet_Path{Path: path},
+		RefreshInterval: 1,
+	})
+	common.Must(err)
+	common.Must(provider.Start())
+	defer provider.Close()
+
+	cond, err := (&RoutingRule{RuleSet: []string{"test"}}).buildCondition(map[string]*RuleSetProvider{"test": provider})
+	common.Must(err)
+	routingContext := func(domain string) *routing_session.Context {
+		return &routing_session.Context{
+			Outbound: &session.Outbound{Target: net.TCPDestination(net.DomainAddress(domain), 443)},
+		}
+	}
+
+	if !cond.Apply(routingContext("www.v2fly.org")) {
+		t.Error("expect www.v2fly.org to match")
+	}
+	if cond.Apply(routingContext("example.com")) {
+		t.Error("expect example.com not to match before reload")
+	}
+
+	common.Must(os.WriteFile(path, []byte("example.com\n"), 0o644))
+	// Make sure the modification time changes on file systems with coarse timestamps.
+	common.Must(os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+
+	deadline := time.Now().Add(time.Second * 5)
+	for !cond.Apply(routingContext("example.com")) {
+		if time.Now().After(deadline) {
+			t.Fatal("rule set is not reloaded")
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	if cond.Apply(routingContext("www.v2fly.org")) {
+		t.Error("expect www.v2fly.org not to match after reload")
+	}
+
+	if _, err := (&RoutingRule{RuleSet: []string{"unknown"}}).BuildCondition(); err == nil {
+		t.Error("expect error for unknown rule set")
+	}
+}
+
+func TestRuleSetProviderDownloadWithoutDialer(t *testing.T) {
+	dialer := tagged.Dialer
+	tagged.Dialer = nil
+	defer func() { tagged.Dialer = dialer }()
+
+	provider, err := NewRuleSetProvider(context.Background(), &RuleSet{
+		Tag:    "test",
+		Source: &RuleSet_Url{Url: "https://127.0.0.1/rules.txt"},
+	})
+	common.Must(err)
+	if _, err := provider.refresh(); err == nil {
+		t.Error("expect error downloading without tagged dialer")
+	}
+}
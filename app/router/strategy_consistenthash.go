@@ -0,0 +1,114 @@
+//go:build !confonly
+// +build !confonly
+
+package router
+
+import (
+	"context"
+	"hash/fnv"
+
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/dice"
+	"github.com/v2fly/v2ray-core/v5/features/routing"
+)
+
+// ConsistentHashStrategy is a sticky balancing strategy. It picks the alive candidate with the highest rendezvous
+// hash of the connection key and the outbound tag, so that connections with the same key keep going to the same
+// outbound, and only keys of a failed outbound move to other outbounds.
+type ConsistentHashStrategy struct {
+	observationReader
+
+	config *StrategyConsistentHashConfig
+}
+
+// NewConsistentHashStrategy creates a new ConsistentHashStrategy with settings
+func NewConsistentHashStrategy(settings *StrategyConsistentHashConfig) *ConsistentHashStrategy {
+	return &ConsistentHashStrategy{
+		observationReader: observationReader{observerTag: settings.ObserverTag},
+		config:            settings,
+	}
+}
+
+func (s *ConsistentHashStrategy) InjectContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// GetPrincipleTarget implements BalancingPrincipleTarget. It returns the alive candidates, as the target of a
+// connection depends on its key.
+func (s *ConsistentHashStrategy) GetPrincipleTarget(candidates []string) []string {
+	return s.aliveCandidates(candidates)
+}
+
+// PickOutbound implements BalancingStrategy. It picks a random alive candidate, as there is no key to hash.
+func (s *ConsistentHashStrategy) PickOutbound(candidates []string) string {
+	alive := s.aliveCandidates(candidates)
+	if len(alive) == 0 {
+		// goes to fallbackTag
+		return ""
+	}
+	return alive[dice.Roll(len(alive))]
+}
+
+// PickOutboundWithContext implements ContextualBalancingStrategy.
+func (s *ConsistentHashStrategy) PickOutboundWithContext(ctx routing.Context, candidates []string) string {
+	key := s.hashKey(ctx)
+	if key == "" {
+		newError("consistent hash: empty key, picking a random outbound").AtDebug().WriteToLog()
+		return s.PickOutbound(candidates)
+	}
+
+	selected := ""
+	var highest uint64
+	for _, tag := range s.aliveCandidates(candidates) {
+		if score := rendezvousHash(key, tag); selected == "" || score > highest {
+			selected = tag
+			highest = score
+		}
+	}
+	return selected
+}
+
+func (s *ConsistentHashStrategy) hashKey(ctx routing.Context) string {
+	switch s.config.Key {
+	case StrategyConsistentHashConfig_SourceIp:
+		if ips := ctx.GetSourceIPs(); len(ips) > 0 {
+			return ips[0].String()
+		}
+	case StrategyConsistentHashConfig_UserEmail:
+		return ctx.GetUser()
+	case StrategyConsistentHashConfig_TargetDomain:
+		domain := ctx.GetTargetDomain()
+		if domain == "" {
+			return ""
+		}
+		if registrable, err := publicsuffix.EffectiveTLDPlusOne(domain); err == nil {
+			return registrable
+		}
+		return domain
+	case StrategyConsistentHashConfig_Attribute:
+		return ctx.GetAttributes()[s.config.Attribute]
+	}
+	return ""
+}
+
+// rendezvousHash returns the weight of tag for key.
+func rendezvousHash(key, tag string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(tag))
+	// Finalizer of splitmix64, to spread similar keys and tags over the whole range.
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*StrategyConsistentHashConfig)(nil), nil))
+}
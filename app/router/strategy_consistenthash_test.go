@@ -0,0 +1,74 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/session"
+	routing_session "github.com/v2fly/v2ray-core/v5/features/routing/session"
+)
+
+type staticObservatory struct {
+	result *observatory.ObservationResult
+}
+
+func (o *staticObservatory) Type() interface{} { return nil }
+func (o *staticObservatory) Start() error      { return nil }
+func (o *staticObservatory) Close() error      { return nil }
+func (o *staticObservatory) GetObservation(ctx context.Context) (proto.Message, error) {
+	return o.result, nil
+}
+
+func TestConsistentHashStrategy(t *testing.T) {
+	observer := &staticObservatory{result: &observatory.ObservationResult{}}
+	strategy := NewConsistentHashStrategy(&StrategyConsistentHashConfig{Key: StrategyConsistentHashConfig_TargetDomain})
+	strategy.observatory = observer
+	candidates := []string{"a", "b", "c", "d"}
+
+	pick := func(domain string) string {
+		ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{
+			Target: net.TCPDestination(net.ParseAddress(domain), 443),
+		})
+		return strategy.PickOutboundWithContext(routing_session.AsRoutingContext(ctx), candidates)
+	}
+
+	picked := make(map[string]string)
+	used := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		domain := fmt.Sprint("site", i, ".org")
+		picked[domain] = pick(domain)
+		used[picked[domain]] = true
+	}
+	if len(used) != len(candidates) {
+		t.Error("expect keys spread over all candidates, got ", used)
+	}
+
+	if a, b := pick("www.example.com"), pick("api.example.com"); a != b {
+		t.Error("expect subdomains of a registrable domain to stick together, got ", a, " and ", b)
+	}
+
+	for domain, tag := range picked {
+		if got := pick(domain); got != tag {
+			t.Error("expect ", domain, " to stick to ", tag, ", got ", got)
+		}
+	}
+
+	observer.result.Status = []*observatory.OutboundStatus{
+		{OutboundTag: "a", Alive: true},
+		{OutboundTag: "b", Alive: false},
+	}
+	for domain, tag := range picked {
+		got := pick(domain)
+		switch {
+		case got == "b":
+			t.Error("expect dead outbound not to be picked for ", domain)
+		case tag != "b" && got != tag:
+			t.Error("expect ", domain, " of alive outbound ", tag, " not to move, got ", got)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+//go:build !confonly
+// +build !confonly
+
+package router
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/common"
+)
+
+const defaultFailoverRecoveryHold = time.Minute
+
+// FailoverStrategy always uses the first alive outbound, in the order of the selectors and then of the tags. An
+// outbound that failed is only preferred again after it stays alive for the recovery hold time, so that a flapping
+// outbound does not take traffic back and forth.
+type FailoverStrategy struct {
+	observationReader
+
+	selectors    []string
+	recoveryHold time.Duration
+
+	access sync.Mutex
+	// recovering records the time outbounds that failed are seen alive again.
+	recovering map[string]time.Time
+	failed     map[string]bool
+	current    string
+
+	now func() time.Time
+}
+
+// NewFailoverStrategy creates a new FailoverStrategy with settings, for the outbounds matched by selectors.
+func NewFailoverStrategy(settings *StrategyFailoverConfig, selectors []string) *FailoverStrategy {
+	recoveryHold := time.Duration(settings.RecoveryHold)
+	if recoveryHold <= 0 {
+		recoveryHold = defaultFailoverRecoveryHold
+	}
+	return &FailoverStrategy{
+		observationReader: observationReader{observerTag: settings.ObserverTag},
+		selectors:         selectors,
+		recoveryHold:      recoveryHold,
+		recovering:        make(map[string]time.Time),
+		failed:            make(map[string]bool),
+		now:               time.Now,
+	}
+}
+
+func (s *FailoverStrategy) InjectContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// GetPrincipleTarget implements BalancingPrincipleTarget. It returns the outbound that would be picked now, without
+// changing the state of the strategy.
+func (s *FailoverStrategy) GetPrincipleTarget(candidates []string) []string {
+	if tag := s.pick(candidates, false); tag != "" {
+		return []string{tag}
+	}
+	return nil
+}
+
+func (s *FailoverStrategy) PickOutbound(candidates []string) string {
+	return s.pick(candidates, true)
+}
+
+// pick selects the outbound to use from candidates. The failures and recoveries of outbounds, and the outbound in
+// use are only recorded if update is set.
+func (s *FailoverStrategy) pick(candidates []string, update bool) string {
+	ordered := s.order(candidates)
+	status := s.getStatus()
+
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	now := s.now()
+	var firstAlive, firstStable string
+	for _, tag := range ordered {
+		alive := true
+		if st, found := status[tag]; found {
+			alive = st.Alive
+		}
+		if !alive {
+			if update {
+				s.failed[tag] = true
+				delete(s.recovering, tag)
+			}
+			continue
+		}
+		if firstAlive == "" {
+			firstAlive = tag
+		}
+		if s.failed[tag] {
+			since, found := s.recovering[tag]
+			if !found {
+				if update {
+					s.recovering[tag] = now
+				}
+				continue
+			}
+			if now.Sub(since) < s.recoveryHold {
+				continue
+			}
+			if update {
+				delete(s.failed, tag)
+				delete(s.recovering, tag)
+			}
+		}
+		if firstStable == "" {
+			firstStable = tag
+		}
+	}
+
+	selected := firstStable
+	if selected == "" {
+		// Every alive outbound is still recovering, keep using the current one if possible.
+		selected = firstAlive
+		for _, tag := range ordered {
+			if st, found := status[tag]; tag == s.current && (!found || st.Alive) {
+				selected = tag
+				break
+			}
+		}
+	}
+	if update && selected != s.current {
+		newError("failover: switching from [", s.current, "] to [", selected, "]").AtInfo().WriteToLog()
+		s.current = selected
+	}
+	return selected
+}
+
+// order sorts candidates by the first selector they match, and then by tag.
+func (s *FailoverStrategy) order(candidates []string) []string {
+	rank := func(tag string) int {
+		for i, selector := range s.selectors {
+			if strings.HasPrefix(tag, selector) {
+				return i
+			}
+		}
+		return len(s.selectors)
+	}
+	ordered := append([]string(nil), candidates...)
+	sort.Slice(ordered, func(i, j int) bool {
+		ri, rj := rank(ordered[i]), rank(ordered[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return ordered[i] < ordered[j]
+	})
+	return ordered
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*StrategyFailoverConfig)(nil), nil))
+}
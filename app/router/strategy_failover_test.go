@@ -0,0 +1,95 @@
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
+)
+
+func TestFailoverStrategy(t *testing.T) {
+	strategy := NewFailoverStrategy(&StrategyFailoverConfig{
+		RecoveryHold: int64(time.Minute),
+	}, []string{"primary", "backup"})
+	observer := &staticObservatory{result: &observatory.ObservationResult{}}
+	strategy.observatory = observer
+	now := time.Unix(0, 0)
+	strategy.now = func() time.Time { return now }
+
+	candidates := []string{"backup-2", "backup-1", "primary"}
+	setAlive := func(alive map[string]bool) {
+		observer.result.Status = nil
+		for tag, a := range alive {
+			observer.result.Status = append(observer.result.Status, &observatory.OutboundStatus{OutboundTag: tag, Alive: a})
+		}
+	}
+	expect := func(expected string) {
+		t.Helper()
+		if tag := strategy.PickOutbound(candidates); tag != expected {
+			t.Error("expect ", expected, ", got ", tag)
+		}
+	}
+
+	expect("primary")
+
+	setAlive(map[string]bool{"primary": false})
+	expect("backup-1")
+
+	// primary comes back, but is not used until it stays alive for the recovery hold time.
+	setAlive(map[string]bool{"primary": true})
+	expect("backup-1")
+	now = now.Add(30 * time.Second)
+	expect("backup-1")
+
+	// primary flaps, which restarts the recovery hold time.
+	setAlive(map[string]bool{"primary": false})
+	expect("backup-1")
+	setAlive(map[string]bool{"primary": true})
+	now = now.Add(30 * time.Second)
+	expect("backup-1")
+	now = now.Add(time.Minute)
+	expect("primary")
+	if target := strategy.GetPrincipleTarget(candidates); len(target) != 1 || target[0] != "primary" {
+		t.Error("expect principle target to be primary, got ", target)
+	}
+
+	// Recovering outbounds are used when nothing else is alive.
+	setAlive(map[string]bool{"primary": false, "backup-1": false, "backup-2": false})
+	expect("")
+	setAlive(map[string]bool{"primary": false, "backup-1": false, "backup-2": true})
+	expect("backup-2")
+}
+
+func TestFailoverStrategyPrincipleTarget(t *testing.T) {
+	strategy := NewFailoverStrategy(&StrategyFailoverConfig{
+		RecoveryHold: int64(time.Minute),
+	}, []string{"primary", "backup"})
+	observer := &staticObservatory{result: &observatory.ObservationResult{
+		Status: []*observatory.OutboundStatus{{OutboundTag: "primary", Alive: false}},
+	}}
+	strategy.observatory = observer
+	now := time.Unix(0, 0)
+	strategy.now = func() time.Time { return now }
+
+	candidates := []string{"backup", "primary"}
+	if tag := strategy.PickOutbound(candidates); tag != "backup" {
+		t.Fatal("expect backup, got ", tag)
+	}
+
+	// Querying the principle target does not start the recovery hold time of primary.
+	observer.result.Status[0].Alive = true
+	if target := strategy.GetPrincipleTarget(candidates); len(target) != 1 || target[0] != "backup" {
+		t.Error("expect principle target to be backup, got ", target)
+	}
+	now = now.Add(2 * time.Minute)
+	if tag := strategy.PickOutbound(candidates); tag != "backup" {
+		t.Error("expect backup, got ", tag)
+	}
+	now = now.Add(2 * time.Minute)
+	if target := strategy.GetPrincipleTarget(candidates); len(target) != 1 || target[0] != "primary" {
+		t.Error("expect principle target to be primary, got ", target)
+	}
+	if strategy.current != "backup" {
+		t.Error("expect current outbound to stay backup, got ", strategy.current)
+	}
+}
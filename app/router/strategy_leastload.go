@@ -58,6 +58,7 @@ type node struct {
 	RTTAverage       time.Duration
 	RTTDeviation     time.Duration
 	RTTDeviationCost time.Duration
+	Bandwidth        int64
 }
 
 func (l *LeastLoadStrategy) InjectContext(ctx context.Context) {
@@ -169,6 +170,9 @@ func (l *LeastLoadStrategy) getNodes(candidates []string, maxRTT time.Duration)
 
 	for _, v := range results.Status {
 		if v.Alive && (v.Delay < maxRTT.Milliseconds() || maxRTT == 0) && outboundlist.contains(v.OutboundTag) {
+			if v.Bandwidth > 0 && v.Bandwidth < l.settings.MinBandwidth {
+				continue
+			}
 			record := &node{
 				Tag:              v.OutboundTag,
 				CountAll:         1,
@@ -185,6 +189,14 @@ func (l *LeastLoadStrategy) getNodes(candidates []string, maxRTT time.Duration)
 				record.CountAll = int(v.HealthPing.All)
 				record.CountFail = int(v.HealthPing.Fail)
 			}
+			if v.Bandwidth > 0 {
+				record.Bandwidth = v.Bandwidth
+				if l.settings.BandwidthReference > 0 {
+					// slower outbounds cost more, in proportion to the bandwidth
+					scale := float64(l.settings.BandwidthReference) / float64(v.Bandwidth)
+					record.RTTDeviationCost = time.Duration(float64(record.RTTDeviationCost) * scale)
+				}
+			}
 			ret = append(ret, record)
 		}
 	}
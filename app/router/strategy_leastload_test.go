@@ -2,6 +2,8 @@ package router
 
 import (
 	"testing"
+
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
 )
 
 /*
@@ -182,3 +184,30 @@ func TestSelectLeastLoadBaselinesNoQualified(t *testing.T) {
 		t.Errorf("expected: %v, actual: %v", expected, len(ns))
 	}
 }
+
+func TestLeastLoadBandwidth(t *testing.T) {
+	strategy := NewLeastLoadStrategy(&StrategyLeastLoadConfig{
+		BandwidthReference: 10000000,
+		MinBandwidth:       100000,
+	})
+	strategy.observer = &staticObservatory{result: &observatory.ObservationResult{
+		Status: []*observatory.OutboundStatus{
+			// fastest, but far below the reference bandwidth
+			{OutboundTag: "a", Alive: true, Delay: 50, Bandwidth: 1000000},
+			{OutboundTag: "b", Alive: true, Delay: 100, Bandwidth: 10000000},
+			// below the min bandwidth
+			{OutboundTag: "c", Alive: true, Delay: 10, Bandwidth: 10000},
+			// not measured
+			{OutboundTag: "d", Alive: true, Delay: 300},
+		},
+	}}
+
+	nodes := strategy.getNodes([]string{"a", "b", "c", "d"}, 0)
+	var tags []string
+	for _, n := range nodes {
+		tags = append(tags, n.Tag)
+	}
+	if len(tags) != 3 || tags[0] != "b" || tags[1] != "d" || tags[2] != "a" {
+		t.Error("unexpected nodes: ", tags)
+	}
+}
@@ -0,0 +1,83 @@
+//go:build !confonly
+// +build !confonly
+
+package router
+
+import (
+	"context"
+
+	core "github.com/v2fly/v2ray-core/v5"
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
+	"github.com/v2fly/v2ray-core/v5/features"
+	"github.com/v2fly/v2ray-core/v5/features/extension"
+)
+
+// observationReader reads outbound liveness for strategies that work without an observatory as well. The
+// observatory is looked up on first use.
+type observationReader struct {
+	ctx         context.Context
+	observatory extension.Observatory
+	observerTag string
+}
+
+// getStatus returns the status of outbounds by tag, or nil if no observation is available.
+func (o *observationReader) getStatus() map[string]*observatory.OutboundStatus {
+	if o.observatory == nil {
+		if o.ctx == nil {
+			return nil
+		}
+		instance := core.FromContext(o.ctx)
+		if instance == nil {
+			return nil
+		}
+		observer, ok := instance.GetFeature(extension.ObservatoryType()).(extension.Observatory)
+		if !ok {
+			return nil
+		}
+		if o.observerTag != "" {
+			tagged, ok := observer.(features.TaggedFeatures)
+			if !ok {
+				newError("observatory does not support tagged observers").AtWarning().WriteToLog()
+				return nil
+			}
+			feature, err := tagged.GetFeaturesByTag(o.observerTag)
+			if err != nil {
+				newError("cannot find observer ", o.observerTag).Base(err).WriteToLog()
+				return nil
+			}
+			observer = feature.(extension.Observatory)
+		}
+		o.observatory = observer
+	}
+
+	observeReport, err := o.observatory.GetObservation(o.ctx)
+	if err != nil {
+		newError("cannot get observe report").Base(err).WriteToLog()
+		return nil
+	}
+	result, ok := observeReport.(*observatory.ObservationResult)
+	if !ok {
+		return nil
+	}
+	status := make(map[string]*observatory.OutboundStatus, len(result.Status))
+	for _, v := range result.Status {
+		status[v.OutboundTag] = v
+	}
+	return status
+}
+
+// aliveCandidates returns the candidates not reported dead. All candidates are considered alive if there is no
+// observation.
+func (o *observationReader) aliveCandidates(candidates []string) []string {
+	status := o.getStatus()
+	if status == nil {
+		return candidates
+	}
+	alive := make([]string, 0, len(candidates))
+	for _, tag := range candidates {
+		if s, found := status[tag]; !found || s.Alive {
+			alive = append(alive, tag)
+		}
+	}
+	return alive
+}
@@ -0,0 +1,97 @@
+//go:build !confonly
+// +build !confonly
+
+package router
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/v2fly/v2ray-core/v5/common"
+)
+
+// WeightedRoundRobinStrategy picks alive candidates in turn, each in proportion to its weight. Picks are spread
+// smoothly, so that an outbound with a weight of 2 is not picked twice in a row next to one with a weight of 1.
+type WeightedRoundRobinStrategy struct {
+	observationReader
+
+	weights *WeightManager
+
+	access  sync.Mutex
+	current map[string]float64
+	last    string
+}
+
+// NewWeightedRoundRobinStrategy creates a new WeightedRoundRobinStrategy with settings
+func NewWeightedRoundRobinStrategy(settings *StrategyWeightedRoundRobinConfig) *WeightedRoundRobinStrategy {
+	return &WeightedRoundRobinStrategy{
+		observationReader: observationReader{observerTag: settings.ObserverTag},
+		weights: NewWeightManager(
+			settings.Weights, 1,
+			func(value, weight float64) float64 {
+				return value * weight
+			},
+		),
+		current: make(map[string]float64),
+	}
+}
+
+func (s *WeightedRoundRobinStrategy) InjectContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// GetPrincipleTarget implements BalancingPrincipleTarget. It returns the last picked outbound if it is still a
+// candidate, or all alive candidates otherwise.
+func (s *WeightedRoundRobinStrategy) GetPrincipleTarget(candidates []string) []string {
+	s.access.Lock()
+	last := s.last
+	s.access.Unlock()
+	alive := s.aliveCandidates(candidates)
+	if outboundList(alive).contains(last) {
+		return []string{last}
+	}
+	return alive
+}
+
+func (s *WeightedRoundRobinStrategy) PickOutbound(candidates []string) string {
+	alive := append([]string(nil), s.aliveCandidates(candidates)...)
+	if len(alive) == 0 {
+		// goes to fallbackTag
+		return ""
+	}
+	sort.Strings(alive)
+
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	// Smooth weighted round-robin: every candidate gains its weight, and the one with the highest current weight is
+	// picked and loses the total weight.
+	selected := ""
+	total := 0.0
+	for _, tag := range alive {
+		weight := s.weights.Get(tag)
+		total += weight
+		s.current[tag] += weight
+		if selected == "" || s.current[tag] > s.current[selected] {
+			selected = tag
+		}
+	}
+	s.current[selected] -= total
+
+	// Forget outbounds that are gone, so they start over when they come back.
+	if len(s.current) > len(alive) {
+		for tag := range s.current {
+			if !outboundList(alive).contains(tag) {
+				delete(s.current, tag)
+			}
+		}
+	}
+
+	s.last = selected
+	return selected
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*StrategyWeightedRoundRobinConfig)(nil), nil))
+}
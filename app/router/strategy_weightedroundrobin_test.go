@@ -0,0 +1,47 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
+)
+
+func TestWeightedRoundRobinStrategy(t *testing.T) {
+	strategy := NewWeightedRoundRobinStrategy(&StrategyWeightedRoundRobinConfig{
+		Weights: []*StrategyWeight{
+			{Match: "a", Value: 3},
+			{Regexp: true, Match: `x(\d+)`},
+		},
+	})
+	observer := &staticObservatory{result: &observatory.ObservationResult{}}
+	strategy.observatory = observer
+	candidates := []string{"c", "a", "bx2"}
+
+	counts := make(map[string]int)
+	var previous string
+	for i := 0; i < 60; i++ {
+		tag := strategy.PickOutbound(candidates)
+		counts[tag]++
+		if tag == "c" && previous == "c" {
+			t.Error("expect picks to be spread, got c twice in a row")
+		}
+		previous = tag
+	}
+	if counts["a"] != 30 || counts["bx2"] != 20 || counts["c"] != 10 {
+		t.Error("unexpected picks: ", counts)
+	}
+	if target := strategy.GetPrincipleTarget(candidates); len(target) != 1 || target[0] != previous {
+		t.Error("expect principle target to be the last pick ", previous, ", got ", target)
+	}
+
+	observer.result.Status = []*observatory.OutboundStatus{{OutboundTag: "a", Alive: false}}
+	for i := 0; i < 10; i++ {
+		if tag := strategy.PickOutbound(candidates); tag == "a" {
+			t.Fatal("expect dead outbound not to be picked")
+		}
+	}
+
+	if tag := strategy.PickOutbound([]string{"a"}); tag != "" {
+		t.Error("expect empty tag when no candidate is alive, got ", tag)
+	}
+}
@@ -0,0 +1,126 @@
+package persistentstorageimpl
+
+//go:generate go run github.com/v2fly/v2ray-core/v5/common/errors/errorgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+
+	"github.com/v2fly/v2ray-core/v5/features/extension"
+	"github.com/v2fly/v2ray-core/v5/features/extension/storage"
+)
+
+// EngineResolver returns the persistent storage engine, or nil if there is none.
+type EngineResolver func() extension.PersistentStorageEngine
+
+// NewScopedPersistentStorageImpl returns the root scope of the persistent storage backed by the engine from
+// resolver. The engine is resolved on each access, so that it may be created after the storage.
+//
+// Values of a scope are stored in the engine under the path of the scope, which is the hex encoded key of each scope
+// narrowed from the root followed by "/", and a ":" separating the path from the key.
+func NewScopedPersistentStorageImpl(resolver EngineResolver) storage.ScopedPersistentStorage {
+	return &scopedPersistentStorageImpl{resolver: resolver}
+}
+
+type scopedPersistentStorageImpl struct {
+	resolver EngineResolver
+	path     []byte
+}
+
+func (s *scopedPersistentStorageImpl) ScopedPersistentStorageEngine() {
+	panic("implement me")
+}
+
+func (s *scopedPersistentStorageImpl) engine() (extension.PersistentStorageEngine, error) {
+	var engine extension.PersistentStorageEngine
+	if s.resolver != nil {
+		engine = s.resolver()
+	}
+	if engine == nil {
+		return nil, newError("persistent storage is not configured")
+	}
+	return engine, nil
+}
+
+func (s *scopedPersistentStorageImpl) valueKey(key []byte) []byte {
+	valueKey := make([]byte, 0, len(s.path)+1+len(key))
+	valueKey = append(valueKey, s.path...)
+	valueKey = append(valueKey, ':')
+	return append(valueKey, key...)
+}
+
+func (s *scopedPersistentStorageImpl) scopePath(key []byte) []byte {
+	path := make([]byte, 0, len(s.path)+hex.EncodedLen(len(key))+1)
+	path = append(path, s.path...)
+	path = append(path, hex.EncodeToString(key)...)
+	return append(path, '/')
+}
+
+func (s *scopedPersistentStorageImpl) Put(ctx context.Context, key []byte, value []byte) error {
+	engine, err := s.engine()
+	if err != nil {
+		return err
+	}
+	return engine.Put(ctx, s.valueKey(key), value)
+}
+
+func (s *scopedPersistentStorageImpl) Get(ctx context.Context, key []byte) ([]byte, error) {
+	engine, err := s.engine()
+	if err != nil {
+		return nil, err
+	}
+	return engine.Get(ctx, s.valueKey(key))
+}
+
+func (s *scopedPersistentStorageImpl) List(ctx context.Context, keyPrefix []byte) ([][]byte, error) {
+	engine, err := s.engine()
+	if err != nil {
+		return nil, err
+	}
+	valueKeyPrefix := s.valueKey(keyPrefix)
+	keys, err := engine.List(ctx, valueKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	var ret [][]byte
+	for _, key := range keys {
+		if bytes.HasPrefix(key, valueKeyPrefix) {
+			ret = append(ret, key[len(s.path)+1:])
+		}
+	}
+	return ret, nil
+}
+
+func (s *scopedPersistentStorageImpl) Clear(ctx context.Context) {
+	engine, err := s.engine()
+	if err != nil {
+		return
+	}
+	removeAll(ctx, engine, s.valueKey(nil))
+}
+
+func (s *scopedPersistentStorageImpl) NarrowScope(ctx context.Context, key []byte) (storage.ScopedPersistentStorage, error) {
+	return &scopedPersistentStorageImpl{resolver: s.resolver, path: s.scopePath(key)}, nil
+}
+
+func (s *scopedPersistentStorageImpl) DropScope(ctx context.Context, key []byte) error {
+	engine, err := s.engine()
+	if err != nil {
+		return err
+	}
+	return removeAll(ctx, engine, s.scopePath(key))
+}
+
+func removeAll(ctx context.Context, engine extension.PersistentStorageEngine, prefix []byte) error {
+	keys, err := engine.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := engine.Put(ctx, key, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -9,12 +9,13 @@ import (
 	"github.com/v2fly/v2ray-core/v5/transport/internet/tagged"
 )
 
-func NewRootEnvImpl(ctx context.Context, transientStorage storage.ScopedTransientStorage) RootEnvironment {
-	return &rootEnvImpl{transientStorage: transientStorage, ctx: ctx}
+func NewRootEnvImpl(ctx context.Context, transientStorage storage.ScopedTransientStorage, persistentStorage storage.ScopedPersistentStorage) RootEnvironment {
+	return &rootEnvImpl{transientStorage: transientStorage, persistentStorage: persistentStorage, ctx: ctx}
 }
 
 type rootEnvImpl struct {
-	transientStorage storage.ScopedTransientStorage
+	transientStorage  storage.ScopedTransientStorage
+	persistentStorage storage.ScopedPersistentStorage
 
 	ctx context.Context
 }
@@ -28,9 +29,14 @@ func (r *rootEnvImpl) AppEnvironment(tag string) AppEnvironment {
 	if err != nil {
 		return nil
 	}
+	persistentStorage, err := r.persistentStorage.NarrowScope(r.ctx, []byte(tag))
+	if err != nil {
+		return nil
+	}
 	return &appEnvImpl{
-		transientStorage: transientStorage,
-		ctx:              r.ctx,
+		transientStorage:  transientStorage,
+		persistentStorage: persistentStorage,
+		ctx:               r.ctx,
 	}
 }
 
@@ -46,7 +52,8 @@ func (r *rootEnvImpl) ProxyEnvironment(tag string) ProxyEnvironment {
 }
 
 type appEnvImpl struct {
-	transientStorage storage.ScopedTransientStorage
+	transientStorage  storage.ScopedTransientStorage
+	persistentStorage storage.ScopedPersistentStorage
 
 	ctx context.Context
 }
@@ -84,7 +91,7 @@ func (a *appEnvImpl) OpenFileForWrite() fsifce.FileWriterFunc {
 }
 
 func (a *appEnvImpl) PersistentStorage() storage.ScopedPersistentStorage {
-	panic("implement me")
+	return a.persistentStorage
 }
 
 func (a *appEnvImpl) TransientStorage() storage.ScopedTransientStorage {
@@ -96,9 +103,14 @@ func (a *appEnvImpl) NarrowScope(key string) (AppEnvironment, error) {
 	if err != nil {
 		return nil, err
 	}
+	persistentStorage, err := a.persistentStorage.NarrowScope(a.ctx, []byte(key))
+	if err != nil {
+		return nil, err
+	}
 	return &appEnvImpl{
-		transientStorage: transientStorage,
-		ctx:              a.ctx,
+		transientStorage:  transientStorage,
+		persistentStorage: persistentStorage,
+		ctx:               a.ctx,
 	}, nil
 }
 
@@ -9,6 +9,7 @@ const (
 
 var (
 	CIDRMask        = net.CIDRMask
+	DefaultResolver = net.DefaultResolver
 	Dial            = net.Dial
 	DialTCP         = net.DialTCP
 	DialUDP         = net.DialUDP
@@ -19,6 +20,7 @@ var (
 	ListenUDP       = net.ListenUDP
 	ListenUnix      = net.ListenUnix
 	LookupIP        = net.LookupIP
+	ParseCIDR       = net.ParseCIDR
 	ParseIP         = net.ParseIP
 	ResolveUDPAddr  = net.ResolveUDPAddr
 	ResolveUnixAddr = net.ResolveUnixAddr
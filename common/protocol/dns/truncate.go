@@ -0,0 +1,35 @@
+package dns
+
+import (
+	"github.com/miekg/dns"
+
+	"github.com/v2fly/v2ray-core/v5/common/buf"
+)
+
+// TruncateMessage truncates the packed DNS response resp to query, so that it fits in a buffer, and in the UDP
+// payload size advertised by query if the response is sent over UDP. The TC bit is set if records are dropped.
+func TruncateMessage(query, resp []byte, udp bool) ([]byte, error) {
+	size := buf.Size
+	if udp {
+		size = dns.MinMsgSize
+		req := new(dns.Msg)
+		if err := req.Unpack(query); err == nil {
+			if opt := req.IsEdns0(); opt != nil && int(opt.UDPSize()) > size {
+				size = int(opt.UDPSize())
+			}
+		}
+		if size > buf.Size {
+			size = buf.Size
+		}
+	}
+	if len(resp) <= size {
+		return resp, nil
+	}
+
+	msg := new(dns.Msg)
+	if err := msg.Unpack(resp); err != nil {
+		return nil, newError("failed to parse response").Base(err)
+	}
+	msg.Truncate(size)
+	return msg.Pack()
+}
@@ -10,6 +10,7 @@ import (
 
 type SniffHeader struct {
 	domain string
+	alpn   []string
 }
 
 func (h *SniffHeader) Protocol() string {
@@ -20,6 +21,11 @@ func (h *SniffHeader) Domain() string {
 	return h.domain
 }
 
+// ALPN returns the application protocols offered in the client hello.
+func (h *SniffHeader) ALPN() []string {
+	return h.alpn
+}
+
 var (
 	errNotTLS         = errors.New("not TLS header")
 	errNotClientHello = errors.New("not client hello")
@@ -29,7 +35,7 @@ func IsValidTLSVersion(major, minor byte) bool {
 	return major == 3
 }
 
-// ReadClientHello returns server name (if any) from TLS client hello message.
+// ReadClientHello returns server name (if any) and offered application protocols from TLS client hello message.
 // https://github.com/golang/go/blob/master/src/crypto/tls/handshake_messages.go#L300
 func ReadClientHello(data []byte, h *SniffHeader) error {
 	if len(data) < 42 {
@@ -74,13 +80,17 @@ func ReadClientHello(data []byte, h *SniffHeader) error {
 
 	for len(data) != 0 {
 		if len(data) < 4 {
-			return errNotClientHello
+			return h.malformedExtensions()
 		}
 		extension := uint16(data[0])<<8 | uint16(data[1])
 		length := int(data[2])<<8 | int(data[3])
 		data = data[4:]
 		if len(data) < length {
-			return errNotClientHello
+			return h.malformedExtensions()
+		}
+
+		if extension == 0x10 { /* extensionALPN */
+			h.alpn = readALPN(data[:length])
 		}
 
 		if extension == 0x00 { /* extensionServerName */
@@ -112,7 +122,7 @@ func ReadClientHello(data []byte, h *SniffHeader) error {
 						return errNotClientHello
 					}
 					h.domain = serverName
-					return nil
+					break
 				}
 				d = d[nameLen:]
 			}
@@ -120,7 +130,40 @@ func ReadClientHello(data []byte, h *SniffHeader) error {
 		data = data[length:]
 	}
 
-	return errNotTLS
+	if h.domain == "" {
+		return errNotTLS
+	}
+	return nil
+}
+
+// malformedExtensions returns the error of malformed extensions, which are ignored once the server name is found.
+func (h *SniffHeader) malformedExtensions() error {
+	if h.domain != "" {
+		return nil
+	}
+	return errNotClientHello
+}
+
+// readALPN returns the protocols in the ALPN extension d, or nil if it is malformed.
+func readALPN(d []byte) []string {
+	if len(d) < 2 {
+		return nil
+	}
+	protosLen := int(d[0])<<8 | int(d[1])
+	d = d[2:]
+	if len(d) != protosLen {
+		return nil
+	}
+	var protos []string
+	for len(d) > 0 {
+		protoLen := int(d[0])
+		if protoLen == 0 || len(d) < 1+protoLen {
+			return nil
+		}
+		protos = append(protos, string(d[1:1+protoLen]))
+		d = d[1+protoLen:]
+	}
+	return protos
 }
 
 func SniffTLS(b []byte) (*SniffHeader, error) {
@@ -3,6 +3,8 @@ package tls_test
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	. "github.com/v2fly/v2ray-core/v5/common/protocol/tls"
 )
 
@@ -159,3 +161,82 @@ func TestTLSHeaders(t *testing.T) {
 		}
 	}
 }
+
+func buildClientHello(extensions ...[]byte) []byte {
+	hello := []byte{0x03, 0x03}
+	hello = append(hello, make([]byte, 32)...)
+	hello = append(hello, 0x00, 0x00, 0x02, 0x13, 0x01, 0x01, 0x00)
+	var exts []byte
+	for _, ext := range extensions {
+		exts = append(exts, ext...)
+	}
+	hello = append(hello, byte(len(exts)>>8), byte(len(exts)))
+	hello = append(hello, exts...)
+	hello = append([]byte{0x01, 0x00, byte(len(hello) >> 8), byte(len(hello))}, hello...)
+	return append([]byte{0x16, 0x03, 0x01, byte(len(hello) >> 8), byte(len(hello))}, hello...)
+}
+
+func buildExtension(extension uint16, data []byte) []byte {
+	return append([]byte{byte(extension >> 8), byte(extension), byte(len(data) >> 8), byte(len(data))}, data...)
+}
+
+func TestTLSALPN(t *testing.T) {
+	sni := buildExtension(0x00, []byte{0x00, 0x0e, 0x00, 0x00, 0x0b, 'e', 'x', 'a', 'm', 'p', 'l', 'e', '.', 'c', 'o', 'm'})
+	alpn := buildExtension(0x10, []byte{0x00, 0x0c, 0x02, 'h', '2', 0x08, 'h', 't', 't', 'p', '/', '1', '.', '1'})
+	badALPN := buildExtension(0x10, []byte{0x00, 0x05, 0x02, 'h', '2'})
+
+	cases := []struct {
+		name   string
+		input  []byte
+		domain string
+		alpn   []string
+		err    bool
+	}{
+		{
+			name:   "alpn",
+			input:  buildClientHello(alpn, sni),
+			domain: "example.com",
+			alpn:   []string{"h2", "http/1.1"},
+		},
+		{
+			name:   "malformed alpn after server name",
+			input:  buildClientHello(sni, badALPN),
+			domain: "example.com",
+		},
+		{
+			name:   "malformed alpn before server name",
+			input:  buildClientHello(badALPN, sni),
+			domain: "example.com",
+		},
+		{
+			name:   "truncated extension after server name",
+			input:  buildClientHello(sni, alpn[:3]),
+			domain: "example.com",
+		},
+		{
+			name:  "malformed alpn without server name",
+			input: buildClientHello(badALPN),
+			err:   true,
+		},
+	}
+
+	for _, test := range cases {
+		header, err := SniffTLS(test.input)
+		if test.err {
+			if err == nil {
+				t.Error(test.name, ": expect error but nil")
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(test.name, ": expect no error but actually ", err)
+			continue
+		}
+		if header.Domain() != test.domain {
+			t.Error(test.name, ": expect domain ", test.domain, " but got ", header.Domain())
+		}
+		if r := cmp.Diff(header.ALPN(), test.alpn); r != "" {
+			t.Error(test.name, ": ", r)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/environment"
+	"github.com/v2fly/v2ray-core/v5/common/environment/envctx"
 	"github.com/v2fly/v2ray-core/v5/common/serial"
 	"github.com/v2fly/v2ray-core/v5/features"
 )
@@ -16,7 +18,16 @@ func NewHolderFromConfig(ctx context.Context, config *Config, memberType interfa
 		if err != nil {
 			return nil, newError("unable to get instance").Base(err)
 		}
-		obj, err := common.CreateObject(ctx, instance)
+		featureCtx := ctx
+		// Give each feature its own scope of the app environment, so that their storage does not collide.
+		if appEnv, ok := envctx.EnvironmentFromContext(ctx).(environment.AppEnvironment); ok {
+			featureEnv, err := appEnv.NarrowScope(k)
+			if err != nil {
+				return nil, newError("unable to narrow environment scope").Base(err)
+			}
+			featureCtx = envctx.ContextWithEnvironment(ctx, featureEnv)
+		}
+		obj, err := common.CreateObject(featureCtx, instance)
 		if err != nil {
 			return nil, newError("unable to create object").Base(err)
 		}
@@ -1,6 +1,8 @@
 package dns
 
 import (
+	"context"
+
 	"github.com/v2fly/v2ray-core/v5/common/errors"
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/common/serial"
@@ -38,6 +40,14 @@ type IPv6Lookup interface {
 	LookupIPv6(domain string) ([]net.IP, error)
 }
 
+// RecordLookup is an optional feature for querying DNS records of any type.
+//
+// v2ray:api:beta
+type RecordLookup interface {
+	// LookupRecord answers the query in the packed DNS message, and returns the packed response.
+	LookupRecord(ctx context.Context, query []byte) ([]byte, error)
+}
+
 // ClientWithIPOption is an optional feature for querying DNS information.
 //
 // v2ray:api:beta
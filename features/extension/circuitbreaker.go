@@ -0,0 +1,28 @@
+package extension
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/v2fly/v2ray-core/v5/features"
+)
+
+// CircuitBreaker tracks connection failures of outbounds, and stops sending traffic to failing outbounds for a
+// while.
+type CircuitBreaker interface {
+	features.Feature
+	// ReportResult records the result of a connection through the outbound, err is nil for success.
+	ReportResult(tag string, err error)
+	// Allow returns whether the outbound may be used. It doesn't change the state of the circuit.
+	Allow(tag string) bool
+	// Admit records that a connection is sent through the outbound, and returns whether it may be. Only a single
+	// trial connection is admitted while the circuit is half-open.
+	Admit(tag string) bool
+	// GetCircuitStatus returns the state of the circuit of each outbound.
+	GetCircuitStatus(ctx context.Context) (proto.Message, error)
+}
+
+func CircuitBreakerType() interface{} {
+	return (*CircuitBreaker)(nil)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/v2fly/v2ray-core/v5/common/signal/pubsub"
 	"github.com/v2fly/v2ray-core/v5/features"
 )
 
@@ -13,6 +14,13 @@ type Observatory interface {
 	GetObservation(ctx context.Context) (proto.Message, error)
 }
 
+// ObservationSubscribable is implemented by observatories publishing the status of an outbound each time it is
+// updated.
+type ObservationSubscribable interface {
+	// SubscribeObservation returns a subscriber receiving the status of outbounds as proto messages.
+	SubscribeObservation() *pubsub.Subscriber
+}
+
 func ObservatoryType() interface{} {
 	return (*Observatory)(nil)
 }
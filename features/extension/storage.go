@@ -6,10 +6,17 @@ import (
 	"github.com/v2fly/v2ray-core/v5/features"
 )
 
+// PersistentStorageEngine stores values that survive restarts of V2Ray.
 type PersistentStorageEngine interface {
 	features.Feature
 	PersistentStorageEngine()
+	// Put stores value at key, a nil value removes the key.
 	Put(ctx context.Context, key []byte, value []byte) error
 	Get(ctx context.Context, key []byte) ([]byte, error)
+	// List returns all keys starting with keyPrefix.
 	List(ctx context.Context, keyPrefix []byte) ([][]byte, error)
 }
+
+func PersistentStorageEngineType() interface{} {
+	return (*PersistentStorageEngine)(nil)
+}
@@ -45,6 +45,14 @@ type SystemStats struct {
 	OutboundUplink bool
 	// Whether or not to enable stat counter for downlink traffic in outbound handlers.
 	OutboundDownlink bool
+	// Whether or not to enable stat counters for hit count and last hit time of routing rules.
+	RuleHit bool
+	// Whether or not to enable stat counter for uplink traffic routed by routing rules.
+	RuleUplink bool
+	// Whether or not to enable stat counter for downlink traffic routed by routing rules.
+	RuleDownlink bool
+	// Whether or not to enable stat counters for query count, error count and latency of DNS name servers.
+	DNSServer bool
 }
 
 // System contains policy settings at system level.
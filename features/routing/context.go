@@ -1,6 +1,8 @@
 package routing
 
 import (
+	"time"
+
 	"github.com/v2fly/v2ray-core/v5/common/net"
 )
 
@@ -40,4 +42,7 @@ type Context interface {
 
 	// GetSkipDNSResolve returns a flag switch for weather skip dns resolve during route pick.
 	GetSkipDNSResolve() bool
+
+	// GetTime returns the time at which the connection is routed.
+	GetTime() time.Time
 }
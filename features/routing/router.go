@@ -29,6 +29,18 @@ type Route interface {
 	GetOutboundTag() string
 }
 
+// RuleRoute is a Route decided by a routing rule.
+type RuleRoute interface {
+	Route
+
+	// GetRuleTag returns the tag of the routing rule that decided the route, or empty if the rule has no tag.
+	GetRuleTag() string
+
+	// GetRuleID returns the identity of the routing rule that decided the route. It is assigned when the rule is
+	// created, and doesn't change as other rules are added or removed.
+	GetRuleID() uint32
+}
+
 // RouterType return the type of Router interface. Can be used to implement common.HasType.
 //
 // v2ray:api:stable
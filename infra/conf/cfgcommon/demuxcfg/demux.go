@@ -0,0 +1,50 @@
+package demuxcfg
+
+import (
+	"strings"
+
+	"github.com/v2fly/v2ray-core/v5/app/proxyman"
+	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon"
+)
+
+//go:generate go run github.com/v2fly/v2ray-core/v5/common/errors/errorgen
+
+type DemuxConfig struct {
+	ServerName  *cfgcommon.StringList `json:"serverName"`
+	ALPN        *cfgcommon.StringList `json:"alpn"`
+	Host        *cfgcommon.StringList `json:"host"`
+	Path        *cfgcommon.StringList `json:"path"`
+	Protocol    *cfgcommon.StringList `json:"protocol"`
+	PeekTimeout uint32                `json:"peekTimeout"`
+}
+
+// Build implements Buildable.
+func (c *DemuxConfig) Build() (*proxyman.DemuxConfig, error) {
+	config := &proxyman.DemuxConfig{
+		Enabled:     true,
+		PeekTimeout: c.PeekTimeout,
+	}
+	if c.ServerName != nil {
+		config.ServerName = *c.ServerName
+	}
+	if c.ALPN != nil {
+		config.Alpn = *c.ALPN
+	}
+	if c.Host != nil {
+		config.Host = *c.Host
+	}
+	if c.Path != nil {
+		config.Path = *c.Path
+	}
+	if c.Protocol != nil {
+		for _, p := range *c.Protocol {
+			switch strings.ToLower(p) {
+			case "tls", "http", "ssh", "socks":
+				config.Protocol = append(config.Protocol, strings.ToLower(p))
+			default:
+				return nil, newError("unknown demux protocol: ", p)
+			}
+		}
+	}
+	return config, nil
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/v2fly/v2ray-core/v5/transport/internet"
 )
 
+//go:generate go run github.com/v2fly/v2ray-core/v5/common/errors/errorgen
+
 type SocketConfig struct {
 	Mark                 uint32 `json:"mark"`
 	TFO                  *bool  `json:"tcpFastOpen"`
@@ -18,6 +20,13 @@ type SocketConfig struct {
 	RxBufSize            uint64 `json:"rxBufSize"`
 	TxBufSize            uint64 `json:"txBufSize"`
 	ForceBufSize         bool   `json:"forceBufSize"`
+	MPTCP                bool   `json:"mptcp"`
+	TCPCongestion        string `json:"tcpCongestion"`
+	TCPUserTimeout       uint32 `json:"tcpUserTimeout"`
+	DomainStrategy       string `json:"domainStrategy"`
+	HappyEyeballs        bool   `json:"happyEyeballs"`
+	HappyEyeballsDelay   uint32 `json:"happyEyeballsDelay"`
+	FreeBind             bool   `json:"freeBind"`
 }
 
 // Build implements Buildable.
@@ -46,6 +55,32 @@ func (c *SocketConfig) Build() (*internet.SocketConfig, error) {
 		tproxy = internet.SocketConfig_Off
 	}
 
+	var domainStrategy internet.SocketConfig_DomainStrategy
+	switch strings.ToLower(c.DomainStrategy) {
+	case "", "asis", "as_is", "as-is":
+		domainStrategy = internet.SocketConfig_AS_IS
+	case "useip", "use_ip", "use-ip":
+		domainStrategy = internet.SocketConfig_USE_IP
+	case "useip4", "useipv4", "use_ip4", "use_ipv4", "use-ip4", "use-ipv4":
+		domainStrategy = internet.SocketConfig_USE_IP4
+	case "useip6", "useipv6", "use_ip6", "use_ipv6", "use-ip6", "use-ipv6":
+		domainStrategy = internet.SocketConfig_USE_IP6
+	case "preferip4", "preferipv4", "prefer_ip4", "prefer_ipv4", "prefer-ip4", "prefer-ipv4":
+		domainStrategy = internet.SocketConfig_PREFER_IP4
+	case "preferip6", "preferipv6", "prefer_ip6", "prefer_ipv6", "prefer-ip6", "prefer-ipv6":
+		domainStrategy = internet.SocketConfig_PREFER_IP6
+	default:
+		return nil, newError("unknown domain strategy: ", c.DomainStrategy)
+	}
+
+	var happyEyeballs *internet.HappyEyeballsConfig
+	if c.HappyEyeballs {
+		happyEyeballs = &internet.HappyEyeballsConfig{
+			Enabled:  true,
+			TryDelay: c.HappyEyeballsDelay,
+		}
+	}
+
 	return &internet.SocketConfig{
 		Mark:                 c.Mark,
 		Tfo:                  tfoSettings,
@@ -58,5 +93,11 @@ func (c *SocketConfig) Build() (*internet.SocketConfig, error) {
 		TxBufSize:            int64(c.TxBufSize),
 		ForceBufSize:         c.ForceBufSize,
 		BindToDevice:         c.BindToDevice,
+		Mptcp:                c.MPTCP,
+		TcpCongestion:        c.TCPCongestion,
+		TcpUserTimeout:       c.TCPUserTimeout,
+		DomainStrategy:       domainStrategy,
+		HappyEyeballs:        happyEyeballs,
+		FreeBind:             c.FreeBind,
 	}, nil
 }
@@ -1,6 +1,7 @@
 package geodata
 
 import (
+	"path/filepath"
 	"strings"
 
 	"github.com/v2fly/v2ray-core/v5/app/router/routercommon"
@@ -58,6 +59,36 @@ func (l *loader) LoadGeoIP(country string) ([]*routercommon.CIDR, error) {
 	return l.LoadIP("geoip.dat", country)
 }
 
+func (l *loader) LoadSite(filename, list string) ([]*routercommon.Domain, error) {
+	if formatLoader, ok := getFileFormatLoader(filename); ok {
+		return formatLoader.LoadSite(filename, list)
+	}
+	return l.LoaderImplementation.LoadSite(filename, list)
+}
+
+func (l *loader) LoadIP(filename, country string) ([]*routercommon.CIDR, error) {
+	if formatLoader, ok := getFileFormatLoader(filename); ok {
+		return formatLoader.LoadIP(filename, country)
+	}
+	return l.LoaderImplementation.LoadIP(filename, country)
+}
+
+var fileFormatLoaders map[string]LoaderImplementation
+
+// RegisterFileFormatLoader registers a loader for files with the given extension, such as ".mmdb". These files are
+// loaded by it instead of the selected loader implementation.
+func RegisterFileFormatLoader(ext string, loader LoaderImplementation) {
+	if fileFormatLoaders == nil {
+		fileFormatLoaders = map[string]LoaderImplementation{}
+	}
+	fileFormatLoaders[strings.ToLower(ext)] = loader
+}
+
+func getFileFormatLoader(filename string) (LoaderImplementation, bool) {
+	formatLoader, ok := fileFormatLoaders[strings.ToLower(filepath.Ext(filename))]
+	return formatLoader, ok
+}
+
 var loaders map[string]func() LoaderImplementation
 
 func RegisterGeoDataLoaderImplementationCreator(name string, loader func() LoaderImplementation) {
@@ -0,0 +1,197 @@
+package mmdb
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Data types of the MaxMind DB data section.
+const (
+	typeExtended = iota
+	typePointer
+	typeString
+	typeDouble
+	typeBytes
+	typeUint16
+	typeUint32
+	typeMap
+	typeInt32
+	typeUint64
+	typeUint128
+	typeArray
+	typeContainer
+	typeEndMarker
+	typeBool
+	typeFloat
+)
+
+// maxDecodeDepth limits nesting of maps, arrays and pointers to reject malformed data.
+const maxDecodeDepth = 64
+
+// decoder decodes values in the data section, or the metadata section, of a MaxMind DB file. Pointers are offsets
+// from the start of buf.
+type decoder struct {
+	buf []byte
+}
+
+func (d *decoder) bytes(offset, size int) ([]byte, error) {
+	if offset < 0 || size < 0 || offset+size > len(d.buf) {
+		return nil, newError("unexpected end of data at offset ", offset)
+	}
+	return d.buf[offset : offset+size], nil
+}
+
+// decodeControl decodes the control byte and the extended type and size following it.
+func (d *decoder) decodeControl(offset int) (int, int, int, error) {
+	b, err := d.bytes(offset, 1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	ctrl := b[0]
+	offset++
+	dataType := int(ctrl >> 5)
+	if dataType == typeExtended {
+		b, err := d.bytes(offset, 1)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		dataType = 7 + int(b[0])
+		offset++
+	}
+	if dataType == typePointer {
+		return d.decodePointer(ctrl, offset)
+	}
+
+	size := int(ctrl & 0x1f)
+	if size >= 29 {
+		n := size - 28
+		b, err := d.bytes(offset, n)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		offset += n
+		switch n {
+		case 1:
+			size = 29 + int(b[0])
+		case 2:
+			size = 285 + (int(b[0])<<8 | int(b[1]))
+		case 3:
+			size = 65821 + (int(b[0])<<16 | int(b[1])<<8 | int(b[2]))
+		}
+	}
+	return dataType, size, offset, nil
+}
+
+// decodePointer returns the pointer as size, to be followed by decode.
+func (d *decoder) decodePointer(ctrl byte, offset int) (int, int, int, error) {
+	n := int((ctrl>>3)&0x3) + 1
+	b, err := d.bytes(offset, n)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	v := int(ctrl & 0x7)
+	var pointer int
+	switch n {
+	case 1:
+		pointer = v<<8 | int(b[0])
+	case 2:
+		pointer = 2048 + (v<<16 | int(b[0])<<8 | int(b[1]))
+	case 3:
+		pointer = 526336 + (v<<24 | int(b[0])<<16 | int(b[1])<<8 | int(b[2]))
+	case 4:
+		pointer = int(binary.BigEndian.Uint32(b))
+	}
+	return typePointer, pointer, offset + n, nil
+}
+
+// decode decodes the value at offset, and returns the offset after it.
+func (d *decoder) decode(offset int) (interface{}, int, error) {
+	return d.decodeWithDepth(offset, 0)
+}
+
+func (d *decoder) decodeWithDepth(offset int, depth int) (interface{}, int, error) {
+	if depth > maxDecodeDepth {
+		return nil, 0, newError("data nested too deep")
+	}
+	dataType, size, offset, err := d.decodeControl(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch dataType {
+	case typePointer:
+		value, _, err := d.decodeWithDepth(size, depth+1)
+		return value, offset, err
+	case typeMap:
+		m := make(map[string]interface{}, size)
+		for i := 0; i < size; i++ {
+			var key, value interface{}
+			if key, offset, err = d.decodeWithDepth(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			if value, offset, err = d.decodeWithDepth(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			k, ok := key.(string)
+			if !ok {
+				return nil, 0, newError("map key is not a string")
+			}
+			m[k] = value
+		}
+		return m, offset, nil
+	case typeArray:
+		a := make([]interface{}, 0, size)
+		for i := 0; i < size; i++ {
+			var value interface{}
+			if value, offset, err = d.decodeWithDepth(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			a = append(a, value)
+		}
+		return a, offset, nil
+	case typeBool:
+		return size != 0, offset, nil
+	}
+
+	b, err := d.bytes(offset, size)
+	if err != nil {
+		return nil, 0, err
+	}
+	offset += size
+	switch dataType {
+	case typeString:
+		return string(b), offset, nil
+	case typeBytes, typeUint128:
+		return append([]byte(nil), b...), offset, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, newError("invalid size of double: ", size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), offset, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, newError("invalid size of float: ", size)
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(b)), offset, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, newError("invalid size of unsigned integer: ", size)
+		}
+		var v uint64
+		for _, c := range b {
+			v = v<<8 | uint64(c)
+		}
+		return v, offset, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, newError("invalid size of int32: ", size)
+		}
+		var v uint32
+		for _, c := range b {
+			v = v<<8 | uint32(c)
+		}
+		return int32(v), offset, nil
+	default:
+		return nil, 0, newError("unsupported data type: ", dataType)
+	}
+}
@@ -0,0 +1,221 @@
+// Package mmdb loads GeoIP lists from country databases in MaxMind DB format, such as GeoLite2 Country and DB-IP
+// IP to Country Lite.
+package mmdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/v2fly/v2ray-core/v5/app/router/routercommon"
+	"github.com/v2fly/v2ray-core/v5/common/platform/filesystem"
+	"github.com/v2fly/v2ray-core/v5/infra/conf/geodata"
+)
+
+//go:generate go run github.com/v2fly/v2ray-core/v5/common/errors/errorgen
+
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// dataSectionSeparator is the size of zeros between the search tree and the data section.
+const dataSectionSeparator = 16
+
+// Reader reads a MaxMind DB file.
+type Reader struct {
+	buf        []byte
+	nodeCount  uint32
+	recordSize int
+	ipVersion  int
+	nodeBytes  int
+	data       *decoder
+}
+
+// NewReader parses the metadata of a MaxMind DB file.
+func NewReader(buf []byte) (*Reader, error) {
+	i := bytes.LastIndex(buf, metadataMarker)
+	if i < 0 {
+		return nil, newError("metadata not found, not a MaxMind DB file")
+	}
+	metadata, _, err := (&decoder{buf: buf[i+len(metadataMarker):]}).decode(0)
+	if err != nil {
+		return nil, newError("failed to decode metadata").Base(err)
+	}
+	m, ok := metadata.(map[string]interface{})
+	if !ok {
+		return nil, newError("invalid metadata")
+	}
+	nodeCount, _ := m["node_count"].(uint64)
+	recordSize, _ := m["record_size"].(uint64)
+	ipVersion, _ := m["ip_version"].(uint64)
+
+	r := &Reader{
+		buf:        buf,
+		nodeCount:  uint32(nodeCount),
+		recordSize: int(recordSize),
+		ipVersion:  int(ipVersion),
+	}
+	switch r.recordSize {
+	case 24, 28, 32:
+		r.nodeBytes = r.recordSize / 4
+	default:
+		return nil, newError("unsupported record size: ", recordSize)
+	}
+	if r.ipVersion != 4 && r.ipVersion != 6 {
+		return nil, newError("unsupported IP version: ", ipVersion)
+	}
+	dataStart := int(r.nodeCount)*r.nodeBytes + dataSectionSeparator
+	if dataStart > i {
+		return nil, newError("search tree exceeds file size")
+	}
+	r.data = &decoder{buf: buf[dataStart:i]}
+	return r, nil
+}
+
+// readRecord reads the left (bit 0) or right (bit 1) record of a node.
+func (r *Reader) readRecord(node uint32, bit int) uint32 {
+	b := r.buf[int(node)*r.nodeBytes:]
+	switch r.recordSize {
+	case 24:
+		b = b[bit*3:]
+		return uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+	case 28:
+		if bit == 0 {
+			return uint32(b[3]&0xf0)<<20 | uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+		}
+		return uint32(b[3]&0x0f)<<24 | uint32(b[4])<<16 | uint32(b[5])<<8 | uint32(b[6])
+	default:
+		b = b[bit*4:]
+		return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	}
+}
+
+// Networks calls fn with each network in the database, and the offset of its data in the data section. IPv4
+// networks in an IPv6 database are reported in their IPv4 form, and only once even if aliased.
+func (r *Reader) Networks(fn func(ip []byte, prefix int, offset int) error) error {
+	if r.ipVersion == 4 {
+		return r.walk(0, make([]byte, 4), 0, 0, fn)
+	}
+
+	ipv4Start := uint32(0)
+	for i := 0; i < 96 && ipv4Start < r.nodeCount; i++ {
+		ipv4Start = r.readRecord(ipv4Start, 0)
+	}
+	return r.walk(0, make([]byte, 16), 0, ipv4Start, fn)
+}
+
+func (r *Reader) walk(node uint32, ip []byte, depth int, ipv4Start uint32, fn func([]byte, int, int) error) error {
+	if depth >= len(ip)*8 {
+		return newError("search tree is deeper than address length")
+	}
+	for bit := 0; bit < 2; bit++ {
+		record := r.readRecord(node, bit)
+		childIP := append([]byte(nil), ip...)
+		if bit == 1 {
+			childIP[depth/8] |= 0x80 >> (depth % 8)
+		}
+
+		switch {
+		case record < r.nodeCount:
+			if record == ipv4Start && ipv4Start != 0 && !(depth+1 == 96 && isZero(childIP)) {
+				// An alias of the IPv4 subtree, such as ::ffff:0:0/96.
+				continue
+			}
+			if err := r.walk(record, childIP, depth+1, ipv4Start, fn); err != nil {
+				return err
+			}
+		case record == r.nodeCount:
+			// No data for this network.
+		default:
+			offset := int(record-r.nodeCount) - dataSectionSeparator
+			prefix := depth + 1
+			if len(childIP) == 16 && prefix >= 96 && isZero(childIP[:12]) {
+				childIP, prefix = childIP[12:], prefix-96
+			}
+			if err := fn(childIP, prefix, offset); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Decode decodes the data at offset in the data section.
+func (r *Reader) Decode(offset int) (interface{}, error) {
+	value, _, err := r.data.decode(offset)
+	return value, err
+}
+
+// countryCode returns the ISO code of the country of a record, or its registered country if unknown.
+func countryCode(record interface{}) string {
+	m, _ := record.(map[string]interface{})
+	for _, key := range []string{"country", "registered_country"} {
+		if country, ok := m[key].(map[string]interface{}); ok {
+			if code, ok := country["iso_code"].(string); ok && code != "" {
+				return code
+			}
+		}
+	}
+	return ""
+}
+
+// LoadIP returns the networks of the country in the database.
+func LoadIP(buf []byte, country string) ([]*routercommon.CIDR, error) {
+	r, err := NewReader(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make(map[int]string)
+	var cidrs []*routercommon.CIDR
+	err = r.Networks(func(ip []byte, prefix int, offset int) error {
+		code, found := codes[offset]
+		if !found {
+			record, err := r.Decode(offset)
+			if err != nil {
+				return newError("failed to decode record at offset ", offset).Base(err)
+			}
+			code = countryCode(record)
+			codes[offset] = code
+		}
+		if strings.EqualFold(code, country) {
+			cidrs = append(cidrs, &routercommon.CIDR{Ip: ip, Prefix: uint32(prefix)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(cidrs) == 0 {
+		return nil, newError("country not found: ", country)
+	}
+	return cidrs, nil
+}
+
+type mmdbLoader struct{}
+
+func (mmdbLoader) LoadSite(filename, list string) ([]*routercommon.Domain, error) {
+	return nil, newError("domain lists are not available in MaxMind DB file: ", filename)
+}
+
+func (mmdbLoader) LoadIP(filename, country string) ([]*routercommon.CIDR, error) {
+	buf, err := filesystem.ReadAsset(filename)
+	if err != nil {
+		return nil, newError("failed to open file: ", filename).Base(err)
+	}
+	cidrs, err := LoadIP(buf, country)
+	if err != nil {
+		return nil, newError("failed to load ", filename).Base(err)
+	}
+	return cidrs, nil
+}
+
+func init() {
+	geodata.RegisterFileFormatLoader(".mmdb", mmdbLoader{})
+}
@@ -0,0 +1,217 @@
+package mmdb_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/v2fly/v2ray-core/v5/app/router/routercommon"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/infra/conf/geodata"
+	"github.com/v2fly/v2ray-core/v5/infra/conf/geodata/mmdb"
+	_ "github.com/v2fly/v2ray-core/v5/infra/conf/geodata/standard"
+)
+
+// treeBuilder builds the search tree and data section of an IPv6 MaxMind DB with 24 bit records.
+type treeBuilder struct {
+	nodes [][2]int // child node index, -1 for empty, or -2-i for data i
+	data  [][]byte
+}
+
+func newTreeBuilder() *treeBuilder {
+	return &treeBuilder{nodes: [][2]int{{-1, -1}}}
+}
+
+// walk returns the node reached by the first bits of ip, creating nodes as needed.
+func (b *treeBuilder) walk(ip net.IP, bits int) int {
+	node := 0
+	for i := 0; i < bits; i++ {
+		bit := int(ip[i/8]>>(7-i%8)) & 1
+		if b.nodes[node][bit] < 0 {
+			b.nodes = append(b.nodes, [2]int{-1, -1})
+			b.nodes[node][bit] = len(b.nodes) - 1
+		}
+		node = b.nodes[node][bit]
+	}
+	return node
+}
+
+func (b *treeBuilder) insert(cidr string, data []byte) {
+	_, network, err := net.ParseCIDR(cidr)
+	common.Must(err)
+	prefix, _ := network.Mask.Size()
+	ip := network.IP.To16()
+	if v4 := network.IP.To4(); v4 != nil {
+		// IPv4 networks are in the ::/96 subtree of an IPv6 database.
+		ip = append(make(net.IP, 12), v4...)
+		prefix += 96
+	}
+	node := b.walk(ip, prefix-1)
+	b.data = append(b.data, data)
+	b.nodes[node][int(ip[(prefix-1)/8]>>(7-(prefix-1)%8))&1] = -1 - len(b.data)
+}
+
+// alias links ::ffff:0:0/96 to the IPv4 subtree at ::/96.
+func (b *treeBuilder) alias() {
+	ipv4Start := b.walk(net.IPv6zero, 96)
+	node := b.walk(net.ParseIP("::ffff:0:0"), 95)
+	b.nodes[node][1] = ipv4Start
+}
+
+func (b *treeBuilder) build() []byte {
+	var section []byte
+	offsets := make([]int, len(b.data))
+	for i, d := range b.data {
+		offsets[i] = len(section)
+		section = append(section, d...)
+	}
+
+	nodeCount := len(b.nodes)
+	buf := new(bytes.Buffer)
+	for _, node := range b.nodes {
+		for _, child := range node {
+			record := nodeCount
+			switch {
+			case child >= 0:
+				record = child
+			case child < -1:
+				record = nodeCount + 16 + offsets[-2-child]
+			}
+			buf.Write([]byte{byte(record >> 16), byte(record >> 8), byte(record)})
+		}
+	}
+	buf.Write(make([]byte, 16))
+	buf.Write(section)
+	buf.WriteString("\xAB\xCD\xEFMaxMind.com")
+	buf.Write(encodeMap(
+		"node_count", encodeUint32(uint32(nodeCount)),
+		"record_size", encodeUint16(24),
+		"ip_version", encodeUint16(6),
+	))
+	return buf.Bytes()
+}
+
+// encodeControl encodes the control byte of dataType, followed by size in the extended forms if needed.
+func encodeControl(dataType byte, size int) []byte {
+	switch {
+	case size < 29:
+		return []byte{dataType<<5 | byte(size)}
+	case size < 285:
+		return []byte{dataType<<5 | 29, byte(size - 29)}
+	case size < 65821:
+		return []byte{dataType<<5 | 30, byte((size - 285) >> 8), byte(size - 285)}
+	default:
+		return []byte{dataType<<5 | 31, byte((size - 65821) >> 16), byte((size - 65821) >> 8), byte(size - 65821)}
+	}
+}
+
+func encodeString(s string) []byte {
+	return append(encodeControl(2, len(s)), s...)
+}
+
+func encodeUint16(v uint16) []byte {
+	b := []byte{5<<5 | 2, 0, 0}
+	binary.BigEndian.PutUint16(b[1:], v)
+	return b
+}
+
+func encodeUint32(v uint32) []byte {
+	b := []byte{6<<5 | 4, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(b[1:], v)
+	return b
+}
+
+func encodeMap(kv ...interface{}) []byte {
+	b := []byte{7<<5 | byte(len(kv)/2)}
+	for i := 0; i < len(kv); i += 2 {
+		b = append(b, encodeString(kv[i].(string))...)
+		b = append(b, kv[i+1].([]byte)...)
+	}
+	return b
+}
+
+func country(key, code string) []byte {
+	return encodeMap(key, encodeMap("iso_code", encodeString(code)))
+}
+
+func buildTestDB() []byte {
+	b := newTreeBuilder()
+	b.insert("1.0.0.0/8", country("country", "CN"))
+	b.insert("8.0.0.0/8", country("country", "US"))
+	b.insert("2001:db8::/32", country("country", "CN"))
+	b.insert("2400::/12", country("registered_country", "CN"))
+	b.alias()
+	return b.build()
+}
+
+func TestLoadIP(t *testing.T) {
+	db := buildTestDB()
+
+	cidrs, err := mmdb.LoadIP(db, "cn")
+	common.Must(err)
+	expected := []*routercommon.CIDR{
+		{Ip: []byte{1, 0, 0, 0}, Prefix: 8},
+		{Ip: net.ParseIP("2001:db8::"), Prefix: 32},
+		{Ip: net.ParseIP("2400::"), Prefix: 12},
+	}
+	if diff := cmp.Diff(expected, cidrs, cmpopts.IgnoreUnexported(routercommon.CIDR{})); diff != "" {
+		t.Error(diff)
+	}
+
+	cidrs, err = mmdb.LoadIP(db, "US")
+	common.Must(err)
+	expected = []*routercommon.CIDR{
+		{Ip: []byte{8, 0, 0, 0}, Prefix: 8},
+	}
+	if diff := cmp.Diff(expected, cidrs, cmpopts.IgnoreUnexported(routercommon.CIDR{})); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err := mmdb.LoadIP(db, "JP"); err == nil {
+		t.Error("expect error for unknown country")
+	}
+	if _, err := mmdb.LoadIP([]byte("not a database"), "CN"); err == nil {
+		t.Error("expect error for invalid file")
+	}
+}
+
+func TestLoadIPWithLongValues(t *testing.T) {
+	// Values sized in each extended form, including 2-byte sizes with both bytes set.
+	for _, size := range []int{30, 300, 541, 1000, 70000} {
+		b := newTreeBuilder()
+		b.insert("1.0.0.0/8", encodeMap("country", encodeMap(
+			"names", encodeString(strings.Repeat("n", size)),
+			"iso_code", encodeString("CN"),
+		)))
+		cidrs, err := mmdb.LoadIP(b.build(), "CN")
+		if err != nil || len(cidrs) != 1 {
+			t.Error("unexpected cidrs with value of size ", size, ": ", cidrs, err)
+		}
+	}
+}
+
+func TestGeoDataLoader(t *testing.T) {
+	dir := t.TempDir()
+	common.Must(os.WriteFile(filepath.Join(dir, "Country.mmdb"), buildTestDB(), 0o644))
+	os.Setenv("v2ray.location.asset", dir)
+	defer os.Unsetenv("v2ray.location.asset")
+
+	loader, err := geodata.GetGeoDataLoader("standard")
+	common.Must(err)
+
+	cidrs, err := loader.LoadIP("Country.mmdb", "US")
+	common.Must(err)
+	if len(cidrs) != 1 || cidrs[0].Prefix != 8 {
+		t.Error("unexpected cidrs: ", cidrs)
+	}
+	if _, err := loader.LoadSite("Country.mmdb", "CN"); err == nil {
+		t.Error("expect error for loading domains from mmdb")
+	}
+}
@@ -0,0 +1,462 @@
+// Package srs loads domain and IP lists from sing-box binary rule-set files.
+package srs
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/v2fly/v2ray-core/v5/app/router/routercommon"
+	"github.com/v2fly/v2ray-core/v5/common/platform/filesystem"
+	"github.com/v2fly/v2ray-core/v5/infra/conf/geodata"
+)
+
+//go:generate go run github.com/v2fly/v2ray-core/v5/common/errors/errorgen
+
+var magic = [3]byte{'S', 'R', 'S'}
+
+const maxVersion = 3
+
+// Item types of a default rule.
+const (
+	ruleItemQueryType = iota
+	ruleItemNetwork
+	ruleItemDomain
+	ruleItemDomainKeyword
+	ruleItemDomainRegex
+	ruleItemSourceIPCIDR
+	ruleItemIPCIDR
+	ruleItemSourcePort
+	ruleItemSourcePortRange
+	ruleItemPort
+	ruleItemPortRange
+	ruleItemProcessName
+	ruleItemProcessPath
+	ruleItemPackageName
+	ruleItemWIFISSID
+	ruleItemWIFIBSSID
+	ruleItemAdGuardDomain
+	ruleItemProcessPathRegex
+	ruleItemNetworkType
+	ruleItemNetworkIsExpensive
+	ruleItemNetworkIsConstrained
+	ruleItemFinal = 0xFF
+)
+
+const (
+	ruleTypeDefault = iota
+	ruleTypeLogical
+)
+
+// maxRuleDepth limits nesting of logical rules to reject malformed files.
+const maxRuleDepth = 32
+
+// maxItemCount limits the length of a single list in the file to reject malformed files.
+const maxItemCount = 1 << 24
+
+// RuleSet is the domains and IP networks collected from a rule-set file. Only non-inverted default rules are
+// collected, as logical rules and other conditions can not be expressed in a plain list.
+type RuleSet struct {
+	Domain []*routercommon.Domain
+	CIDR   []*routercommon.CIDR
+}
+
+// Parse parses a sing-box binary rule-set.
+func Parse(data []byte) (*RuleSet, error) {
+	if len(data) < 4 || !bytes.Equal(data[:3], magic[:]) {
+		return nil, newError("not a sing-box rule-set file")
+	}
+	if version := data[3]; version == 0 || version > maxVersion {
+		return nil, newError("unsupported rule-set version: ", version)
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(data[4:]))
+	if err != nil {
+		return nil, newError("failed to decompress rule-set").Base(err)
+	}
+	defer zr.Close()
+	r := bufio.NewReader(zr)
+
+	count, err := readCount(r)
+	if err != nil {
+		return nil, err
+	}
+	set := new(RuleSet)
+	for i := 0; i < count; i++ {
+		if err := set.readRule(r, 0); err != nil {
+			return nil, newError("failed to read rule ", i).Base(err)
+		}
+	}
+	return set, nil
+}
+
+func (s *RuleSet) readRule(r *bufio.Reader, depth int) error {
+	if depth > maxRuleDepth {
+		return newError("rules nested too deep")
+	}
+	ruleType, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch ruleType {
+	case ruleTypeDefault:
+		return s.readDefaultRule(r)
+	case ruleTypeLogical:
+		if _, err := r.ReadByte(); err != nil { // mode
+			return err
+		}
+		count, err := readCount(r)
+		if err != nil {
+			return err
+		}
+		discard := new(RuleSet)
+		for i := 0; i < count; i++ {
+			if err := discard.readRule(r, depth+1); err != nil {
+				return err
+			}
+		}
+		_, err = r.ReadByte() // invert
+		return err
+	default:
+		return newError("unknown rule type: ", ruleType)
+	}
+}
+
+func (s *RuleSet) readDefaultRule(r *bufio.Reader) error {
+	rule := new(RuleSet)
+	for {
+		itemType, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch itemType {
+		case ruleItemQueryType, ruleItemSourcePort, ruleItemPort:
+			if err := skipUint16s(r); err != nil {
+				return err
+			}
+		case ruleItemNetwork, ruleItemDomainKeyword, ruleItemDomainRegex, ruleItemSourcePortRange, ruleItemPortRange,
+			ruleItemProcessName, ruleItemProcessPath, ruleItemPackageName, ruleItemWIFISSID, ruleItemWIFIBSSID,
+			ruleItemProcessPathRegex:
+			values, err := readStrings(r)
+			if err != nil {
+				return err
+			}
+			switch itemType {
+			case ruleItemDomainKeyword:
+				for _, v := range values {
+					rule.Domain = append(rule.Domain, &routercommon.Domain{Type: routercommon.Domain_Plain, Value: v})
+				}
+			case ruleItemDomainRegex:
+				for _, v := range values {
+					rule.Domain = append(rule.Domain, &routercommon.Domain{Type: routercommon.Domain_Regex, Value: v})
+				}
+			}
+		case ruleItemNetworkType:
+			if err := skipUint8s(r); err != nil {
+				return err
+			}
+		case ruleItemNetworkIsExpensive, ruleItemNetworkIsConstrained:
+			// Flags without a value.
+		case ruleItemDomain:
+			domains, err := readDomainSet(r)
+			if err != nil {
+				return err
+			}
+			rule.Domain = append(rule.Domain, domains...)
+		case ruleItemAdGuardDomain:
+			// AdGuard filter rules are stored in the same way as domains, but can not be expressed as domain rules.
+			if _, err := readDomainSet(r); err != nil {
+				return err
+			}
+		case ruleItemSourceIPCIDR, ruleItemIPCIDR:
+			cidrs, err := readIPSet(r)
+			if err != nil {
+				return err
+			}
+			if itemType == ruleItemIPCIDR {
+				rule.CIDR = append(rule.CIDR, cidrs...)
+			}
+		case ruleItemFinal:
+			invert, err := r.ReadByte()
+			if err != nil {
+				return err
+			}
+			if invert == 0 {
+				s.Domain = append(s.Domain, rule.Domain...)
+				s.CIDR = append(s.CIDR, rule.CIDR...)
+			}
+			return nil
+		default:
+			return newError("unsupported rule item type: ", itemType)
+		}
+	}
+}
+
+func readCount(r *bufio.Reader) (int, error) {
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	if count > maxItemCount {
+		return 0, newError("too many items: ", count)
+	}
+	return int(count), nil
+}
+
+func readBytes(r *bufio.Reader) ([]byte, error) {
+	length, err := readCount(r)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func readStrings(r *bufio.Reader) ([]string, error) {
+	count, err := readCount(r)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		b, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, string(b))
+	}
+	return values, nil
+}
+
+func skipUint8s(r *bufio.Reader) error {
+	count, err := readCount(r)
+	if err != nil {
+		return err
+	}
+	_, err = r.Discard(count)
+	return err
+}
+
+func skipUint16s(r *bufio.Reader) error {
+	count, err := readCount(r)
+	if err != nil {
+		return err
+	}
+	_, err = r.Discard(count * 2)
+	return err
+}
+
+func readUint64s(r *bufio.Reader) ([]uint64, error) {
+	count, err := readCount(r)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]uint64, count)
+	if err := binary.Read(r, binary.BigEndian, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// readDomainSet reads a succinct trie of reversed domains.
+func readDomainSet(r *bufio.Reader) ([]*routercommon.Domain, error) {
+	version, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if version != 1 {
+		return nil, newError("unsupported domain set version: ", version)
+	}
+	leaves, err := readUint64s(r)
+	if err != nil {
+		return nil, err
+	}
+	labelBitmap, err := readUint64s(r)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := readBytes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var domains []*routercommon.Domain
+	for _, key := range succinctKeys(leaves, labelBitmap, labels) {
+		domains = append(domains, keyToDomain(reverse(key)))
+	}
+	return domains, nil
+}
+
+func getBit(bm []uint64, i int) bool {
+	return i>>6 < len(bm) && bm[i>>6]&(1<<uint(i&63)) != 0
+}
+
+// succinctKeys enumerates the keys in a succinct trie. Nodes are numbered in breadth-first order, and the children of
+// each node are encoded in labelBitmap as a 0 bit per child label followed by a 1 bit.
+func succinctKeys(leaves, labelBitmap []uint64, labels []byte) []string {
+	parents := []int{-1}
+	nodeLabels := []byte{0}
+	node, labelIndex := 0, 0
+	for i := 0; i < len(labelBitmap)*64 && node < len(parents); i++ {
+		if getBit(labelBitmap, i) {
+			node++
+			continue
+		}
+		if labelIndex >= len(labels) {
+			break
+		}
+		parents = append(parents, node)
+		nodeLabels = append(nodeLabels, labels[labelIndex])
+		labelIndex++
+	}
+
+	var keys []string
+	for n := range parents {
+		if !getBit(leaves, n) {
+			continue
+		}
+		var key []byte
+		for p := n; p > 0; p = parents[p] {
+			key = append(key, nodeLabels[p])
+		}
+		// Labels are collected from leaf to root.
+		keys = append(keys, string(reverse(string(key))))
+	}
+	return keys
+}
+
+func reverse(s string) string {
+	b := []byte(s)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// keyToDomain converts a domain in the set to a domain rule. A domain with a leading '.' in the rule-set source
+// matches its subdomains only, and is stored with a '\r' or '\b' prefix. A domain suffix matching the domain itself
+// as well is stored with a '\n' prefix.
+func keyToDomain(key string) *routercommon.Domain {
+	switch {
+	case strings.HasPrefix(key, "\r") || strings.HasPrefix(key, "\b"):
+		suffix := strings.TrimPrefix(key[1:], ".")
+		return &routercommon.Domain{Type: routercommon.Domain_Regex, Value: `\.` + regexp.QuoteMeta(suffix) + `$`}
+	case strings.HasPrefix(key, "\n"):
+		return &routercommon.Domain{Type: routercommon.Domain_RootDomain, Value: strings.TrimPrefix(key[1:], ".")}
+	default:
+		return &routercommon.Domain{Type: routercommon.Domain_Full, Value: key}
+	}
+}
+
+// readIPSet reads a list of IP ranges, and converts them to CIDRs.
+func readIPSet(r *bufio.Reader) ([]*routercommon.CIDR, error) {
+	version, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if version != 1 {
+		return nil, newError("unsupported IP set version: ", version)
+	}
+	var count uint64
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return nil, err
+	}
+	if count > maxItemCount {
+		return nil, newError("too many IP ranges: ", count)
+	}
+	var cidrs []*routercommon.CIDR
+	for i := uint64(0); i < count; i++ {
+		from, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		to, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(from) != len(to) || (len(from) != net.IPv4len && len(from) != net.IPv6len) {
+			return nil, newError("invalid IP range")
+		}
+		cidrs = append(cidrs, rangeToCIDRs(from, to)...)
+	}
+	return cidrs, nil
+}
+
+// rangeToCIDRs returns the smallest list of CIDRs covering the inclusive range from-to.
+func rangeToCIDRs(from, to []byte) []*routercommon.CIDR {
+	bits := len(from) * 8
+	start := new(big.Int).SetBytes(from)
+	end := new(big.Int).SetBytes(to)
+	one := big.NewInt(1)
+
+	var cidrs []*routercommon.CIDR
+	for start.Cmp(end) <= 0 {
+		size := 0
+		for size < bits {
+			if start.Bit(size) != 0 {
+				break
+			}
+			last := new(big.Int).Lsh(one, uint(size+1))
+			last.Sub(last, one).Add(last, start)
+			if last.Cmp(end) > 0 {
+				break
+			}
+			size++
+		}
+		ip := make([]byte, len(from))
+		start.FillBytes(ip)
+		cidrs = append(cidrs, &routercommon.CIDR{Ip: ip, Prefix: uint32(bits - size)})
+		start.Add(start, new(big.Int).Lsh(one, uint(size)))
+	}
+	return cidrs
+}
+
+type srsLoader struct{}
+
+func (srsLoader) load(filename string) (*RuleSet, error) {
+	data, err := filesystem.ReadAsset(filename)
+	if err != nil {
+		return nil, newError("failed to open file: ", filename).Base(err)
+	}
+	set, err := Parse(data)
+	if err != nil {
+		return nil, newError("failed to load ", filename).Base(err)
+	}
+	return set, nil
+}
+
+// LoadSite returns all domains in the rule-set. The list name is ignored, as each rule-set file is a single list.
+func (l srsLoader) LoadSite(filename, list string) ([]*routercommon.Domain, error) {
+	set, err := l.load(filename)
+	if err != nil {
+		return nil, err
+	}
+	if len(set.Domain) == 0 {
+		return nil, newError("no domain found in ", filename)
+	}
+	return set.Domain, nil
+}
+
+// LoadIP returns all IP networks in the rule-set. The country code is ignored, as each rule-set file is a single list.
+func (l srsLoader) LoadIP(filename, country string) ([]*routercommon.CIDR, error) {
+	set, err := l.load(filename)
+	if err != nil {
+		return nil, err
+	}
+	if len(set.CIDR) == 0 {
+		return nil, newError("no IP found in ", filename)
+	}
+	return set.CIDR, nil
+}
+
+func init() {
+	geodata.RegisterFileFormatLoader(".srs", srsLoader{})
+}
@@ -0,0 +1,224 @@
+package srs_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/v2fly/v2ray-core/v5/app/router/routercommon"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/infra/conf/geodata"
+	"github.com/v2fly/v2ray-core/v5/infra/conf/geodata/srs"
+	_ "github.com/v2fly/v2ray-core/v5/infra/conf/geodata/standard"
+)
+
+type writer struct {
+	bytes.Buffer
+}
+
+func (w *writer) uvarint(v int) {
+	w.Write(binary.AppendUvarint(nil, uint64(v)))
+}
+
+func (w *writer) bytes(b []byte) {
+	w.uvarint(len(b))
+	w.Write(b)
+}
+
+func (w *writer) strings(values ...string) {
+	w.uvarint(len(values))
+	for _, v := range values {
+		w.bytes([]byte(v))
+	}
+}
+
+func (w *writer) uint64s(values []uint64) {
+	w.uvarint(len(values))
+	common.Must(binary.Write(w, binary.BigEndian, values))
+}
+
+func setBit(bm *[]uint64, i int) {
+	for i>>6 >= len(*bm) {
+		*bm = append(*bm, 0)
+	}
+	(*bm)[i>>6] |= 1 << uint(i&63)
+}
+
+// domainSet writes domains as a succinct trie of reversed keys, in the same way as sing-box.
+func (w *writer) domainSet(keys ...string) {
+	for i, key := range keys {
+		b := []byte(key)
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+		keys[i] = string(b)
+	}
+	sort.Strings(keys)
+
+	var leaves, labelBitmap []uint64
+	var labels []byte
+	type element struct{ s, e, col int }
+	queue := []element{{0, len(keys), 0}}
+	labelIndex := 0
+	for i := 0; i < len(queue); i++ {
+		elt := queue[i]
+		if elt.col == len(keys[elt.s]) {
+			elt.s++
+			setBit(&leaves, i)
+		}
+		for j := elt.s; j < elt.e; {
+			from := j
+			for ; j < elt.e && keys[j][elt.col] == keys[from][elt.col]; j++ {
+			}
+			queue = append(queue, element{from, j, elt.col + 1})
+			labels = append(labels, keys[from][elt.col])
+			labelIndex++
+		}
+		setBit(&labelBitmap, labelIndex)
+		labelIndex++
+	}
+
+	w.WriteByte(1)
+	w.uint64s(leaves)
+	w.uint64s(labelBitmap)
+	w.bytes(labels)
+}
+
+func (w *writer) ipSet(ranges ...string) {
+	w.WriteByte(1)
+	common.Must(binary.Write(w, binary.BigEndian, uint64(len(ranges)/2)))
+	for _, r := range ranges {
+		ip := net.ParseIP(r)
+		if v4 := ip.To4(); v4 != nil {
+			ip = v4
+		}
+		w.bytes(ip)
+	}
+}
+
+func buildRuleSet() []byte {
+	rules := new(writer)
+	rules.uvarint(5)
+
+	rules.WriteByte(0) // default rule
+	rules.WriteByte(2) // domain
+	rules.domainSet("example.com", "\ngoogle.com", "\r.cn")
+	rules.WriteByte(3) // domain keyword
+	rules.strings("keyword")
+	rules.WriteByte(4) // domain regex
+	rules.strings(`^ads\.`)
+	rules.WriteByte(9) // port
+	rules.uvarint(1)
+	rules.Write([]byte{0, 80})
+	rules.WriteByte(0xFF)
+	rules.WriteByte(0)
+
+	rules.WriteByte(0) // default rule
+	rules.WriteByte(6) // ip cidr
+	rules.ipSet("10.0.0.0", "10.255.255.255", "192.168.1.0", "192.168.2.127", "2001:db8::", "2001:db8::ffff")
+	rules.WriteByte(0xFF)
+	rules.WriteByte(0)
+
+	rules.WriteByte(0)  // default rule
+	rules.WriteByte(16) // adguard domain
+	rules.domainSet("||adguard.com^", "@@||allowed.adguard.com^")
+	rules.WriteByte(2)
+	rules.domainSet("v2fly.org")
+	rules.WriteByte(18) // network type
+	rules.bytes([]byte{1, 2})
+	rules.WriteByte(19) // network is expensive
+	rules.WriteByte(20) // network is constrained
+	rules.WriteByte(0xFF)
+	rules.WriteByte(0)
+
+	rules.WriteByte(0) // inverted default rule
+	rules.WriteByte(3)
+	rules.strings("inverted")
+	rules.WriteByte(0xFF)
+	rules.WriteByte(1)
+
+	rules.WriteByte(1) // logical rule
+	rules.WriteByte(0) // and
+	rules.uvarint(1)
+	rules.WriteByte(0)
+	rules.WriteByte(3)
+	rules.strings("logical")
+	rules.WriteByte(0xFF)
+	rules.WriteByte(0)
+	rules.WriteByte(0) // invert
+
+	buf := bytes.NewBufferString("SRS\x01")
+	zw := zlib.NewWriter(buf)
+	common.Must2(zw.Write(rules.Bytes()))
+	common.Must(zw.Close())
+	return buf.Bytes()
+}
+
+func TestParse(t *testing.T) {
+	set, err := srs.Parse(buildRuleSet())
+	common.Must(err)
+
+	expectedDomains := []*routercommon.Domain{
+		{Type: routercommon.Domain_Full, Value: "example.com"},
+		{Type: routercommon.Domain_RootDomain, Value: "google.com"},
+		{Type: routercommon.Domain_Regex, Value: `\.cn$`},
+		{Type: routercommon.Domain_Plain, Value: "keyword"},
+		{Type: routercommon.Domain_Regex, Value: `^ads\.`},
+		{Type: routercommon.Domain_Full, Value: "v2fly.org"},
+	}
+	sortDomains := cmpopts.SortSlices(func(a, b *routercommon.Domain) bool { return a.Value < b.Value })
+	if diff := cmp.Diff(expectedDomains, set.Domain, sortDomains, cmpopts.IgnoreUnexported(routercommon.Domain{})); diff != "" {
+		t.Error(diff)
+	}
+
+	expectedCIDRs := []*routercommon.CIDR{
+		{Ip: []byte{10, 0, 0, 0}, Prefix: 8},
+		{Ip: []byte{192, 168, 1, 0}, Prefix: 24},
+		{Ip: []byte{192, 168, 2, 0}, Prefix: 25},
+		{Ip: net.ParseIP("2001:db8::"), Prefix: 112},
+	}
+	if diff := cmp.Diff(expectedCIDRs, set.CIDR, cmpopts.IgnoreUnexported(routercommon.CIDR{})); diff != "" {
+		t.Error(diff)
+	}
+
+	unknown := bytes.NewBufferString("SRS\x01")
+	zw := zlib.NewWriter(unknown)
+	common.Must2(zw.Write([]byte{1, 0, 0xFE}))
+	common.Must(zw.Close())
+	if _, err := srs.Parse(unknown.Bytes()); err == nil {
+		t.Error("expect error for unknown rule item type")
+	}
+
+	if _, err := srs.Parse([]byte("not a rule-set")); err == nil {
+		t.Error("expect error for invalid file")
+	}
+}
+
+func TestGeoDataLoader(t *testing.T) {
+	dir := t.TempDir()
+	common.Must(os.WriteFile(filepath.Join(dir, "rules.srs"), buildRuleSet(), 0o644))
+	os.Setenv("v2ray.location.asset", dir)
+	defer os.Unsetenv("v2ray.location.asset")
+
+	loader, err := geodata.GetGeoDataLoader("standard")
+	common.Must(err)
+
+	domains, err := loader.LoadGeoSiteWithAttr("rules.srs", "anything")
+	common.Must(err)
+	if len(domains) != 6 {
+		t.Error("unexpected domains: ", domains)
+	}
+	cidrs, err := loader.LoadIP("rules.srs", "anything")
+	common.Must(err)
+	if len(cidrs) != 4 {
+		t.Error("unexpected cidrs: ", cidrs)
+	}
+}
@@ -201,7 +201,8 @@ func toCidrList(ctx context.Context, ips cfgcommon.StringList) ([]*routercommon.
 	return geoipList, nil
 }
 
-func parseFieldRule(ctx context.Context, msg json.RawMessage) (*router.RoutingRule, error) {
+// parseFieldCondition parses the conditions of a field rule, without its target.
+func parseFieldCondition(ctx context.Context, msg json.RawMessage) (*router.RoutingRule, error) {
 	type RawFieldRule struct {
 		RouterRule
 		Domain     *cfgcommon.StringList  `json:"domain"`
@@ -215,6 +216,11 @@ func parseFieldRule(ctx context.Context, msg json.RawMessage) (*router.RoutingRu
 		InboundTag *cfgcommon.StringList  `json:"inboundTag"`
 		Protocols  *cfgcommon.StringList  `json:"protocol"`
 		Attributes string                 `json:"attrs"`
+		Process    *cfgcommon.StringList  `json:"process"`
+		UID        []uint32               `json:"uid"`
+		GID        []uint32               `json:"gid"`
+		Schedule   *ScheduleConfig        `json:"schedule"`
+		RuleSet    *cfgcommon.StringList  `json:"ruleSet"`
 	}
 	rawFieldRule := new(RawFieldRule)
 	err := json.Unmarshal(msg, rawFieldRule)
@@ -223,19 +229,6 @@ func parseFieldRule(ctx context.Context, msg json.RawMessage) (*router.RoutingRu
 	}
 
 	rule := new(router.RoutingRule)
-	switch {
-	case len(rawFieldRule.OutboundTag) > 0:
-		rule.TargetTag = &router.RoutingRule_Tag{
-			Tag: rawFieldRule.OutboundTag,
-		}
-	case len(rawFieldRule.BalancerTag) > 0:
-		rule.TargetTag = &router.RoutingRule_BalancingTag{
-			BalancingTag: rawFieldRule.BalancerTag,
-		}
-	default:
-		return nil, newError("neither outboundTag nor balancerTag is specified in routing rule")
-	}
-
 	if rawFieldRule.DomainMatcher != "" {
 		rule.DomainMatcher = rawFieldRule.DomainMatcher
 	}
@@ -310,24 +303,117 @@ func parseFieldRule(ctx context.Context, msg json.RawMessage) (*router.RoutingRu
 		rule.Attributes = rawFieldRule.Attributes
 	}
 
+	if rawFieldRule.Process != nil {
+		for _, s := range *rawFieldRule.Process {
+			// Absolute paths match the executable path, others match the program name.
+			if strings.HasPrefix(s, "/") {
+				rule.ProcessPath = append(rule.ProcessPath, s)
+			} else {
+				rule.ProcessName = append(rule.ProcessName, s)
+			}
+		}
+	}
+
+	rule.Uid = rawFieldRule.UID
+	rule.Gid = rawFieldRule.GID
+
+	if rawFieldRule.Schedule != nil {
+		rule.Schedule = rawFieldRule.Schedule.Build()
+	}
+
+	if rawFieldRule.RuleSet != nil {
+		for _, s := range *rawFieldRule.RuleSet {
+			rule.RuleSet = append(rule.RuleSet, s)
+		}
+	}
+
 	return rule, nil
 }
 
+// parseLogicalCondition parses a logical rule combining sub-rules, without its target.
+func parseLogicalCondition(ctx context.Context, msg json.RawMessage) (*router.RoutingRule, error) {
+	type RawLogicalRule struct {
+		Operator string            `json:"operator"`
+		Rules    []json.RawMessage `json:"rules"`
+	}
+	rawLogicalRule := new(RawLogicalRule)
+	if err := json.Unmarshal(msg, rawLogicalRule); err != nil {
+		return nil, err
+	}
+
+	logical := new(router.LogicalRule)
+	switch strings.ToLower(rawLogicalRule.Operator) {
+	case "and":
+		logical.Operator = router.LogicalRule_And
+	case "or":
+		logical.Operator = router.LogicalRule_Or
+	case "not":
+		logical.Operator = router.LogicalRule_Not
+	default:
+		return nil, newError("unknown logical operator: ", rawLogicalRule.Operator)
+	}
+
+	for _, msg := range rawLogicalRule.Rules {
+		rawRule := new(RouterRule)
+		if err := json.Unmarshal(msg, rawRule); err != nil {
+			return nil, newError("invalid sub-rule").Base(err)
+		}
+		var sub *router.RoutingRule
+		var err error
+		switch strings.ToLower(rawRule.Type) {
+		case "", "field":
+			sub, err = parseFieldCondition(ctx, msg)
+		case "logical":
+			sub, err = parseLogicalCondition(ctx, msg)
+		default:
+			return nil, newError("unknown sub-rule type: ", rawRule.Type)
+		}
+		if err != nil {
+			return nil, newError("invalid sub-rule").Base(err)
+		}
+		logical.Rule = append(logical.Rule, sub)
+	}
+
+	return &router.RoutingRule{Logical: logical}, nil
+}
+
 func ParseRule(ctx context.Context, msg json.RawMessage) (*router.RoutingRule, error) {
 	rawRule := new(RouterRule)
 	err := json.Unmarshal(msg, rawRule)
 	if err != nil {
 		return nil, newError("invalid router rule").Base(err)
 	}
-	if strings.EqualFold(rawRule.Type, "field") {
-		fieldrule, err := parseFieldRule(ctx, msg)
+
+	var rule *router.RoutingRule
+	switch strings.ToLower(rawRule.Type) {
+	case "field":
+		rule, err = parseFieldCondition(ctx, msg)
 		if err != nil {
 			return nil, newError("invalid field rule").Base(err)
 		}
-		return fieldrule, nil
+	case "logical":
+		rule, err = parseLogicalCondition(ctx, msg)
+		if err != nil {
+			return nil, newError("invalid logical rule").Base(err)
+		}
+	default:
+		return nil, newError("unknown router rule type: ", rawRule.Type)
 	}
 
-	return nil, newError("unknown router rule type: ", rawRule.Type)
+	switch {
+	case len(rawRule.OutboundTag) > 0:
+		rule.TargetTag = &router.RoutingRule_Tag{
+			Tag: rawRule.OutboundTag,
+		}
+	case len(rawRule.BalancerTag) > 0:
+		rule.TargetTag = &router.RoutingRule_BalancingTag{
+			BalancingTag: rawRule.BalancerTag,
+		}
+	default:
+		return nil, newError("neither outboundTag nor balancerTag is specified in routing rule")
+	}
+	rule.RuleTag = rawRule.RuleTag
+	return rule, nil
 }
 
 func ParseIP(s string) (*routercommon.CIDR, error) {
@@ -386,10 +472,37 @@ func ToCidrList(ctx context.Context, ips cfgcommon.StringList) ([]*routercommon.
 	return toCidrList(ctx, ips)
 }
 
+type ScheduleRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type ScheduleConfig struct {
+	Weekday  cfgcommon.StringList `json:"weekday"`
+	Time     []ScheduleRange      `json:"time"`
+	Date     []ScheduleRange      `json:"date"`
+	TimeZone string               `json:"timeZone"`
+}
+
+func (c *ScheduleConfig) Build() *router.Schedule {
+	schedule := &router.Schedule{
+		Weekday:  c.Weekday,
+		TimeZone: c.TimeZone,
+	}
+	for _, r := range c.Time {
+		schedule.Time = append(schedule.Time, &router.Schedule_Range{From: r.From, To: r.To})
+	}
+	for _, r := range c.Date {
+		schedule.Date = append(schedule.Date, &router.Schedule_Range{From: r.From, To: r.To})
+	}
+	return schedule
+}
+
 type RouterRule struct {
 	Type        string `json:"type"`
 	OutboundTag string `json:"outboundTag"`
 	BalancerTag string `json:"balancerTag"`
+	RuleTag     string `json:"ruleTag"`
 
 	DomainMatcher string `json:"domainMatcher"`
 }
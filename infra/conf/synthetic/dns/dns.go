@@ -13,8 +13,10 @@ import (
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/common/platform"
 	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon"
+	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon/tlscfg"
 	"github.com/v2fly/v2ray-core/v5/infra/conf/geodata"
 	rule2 "github.com/v2fly/v2ray-core/v5/infra/conf/rule"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tls"
 )
 
 type NameServerConfig struct {
@@ -24,6 +26,8 @@ type NameServerConfig struct {
 	SkipFallback bool
 	Domains      []string
 	ExpectIPs    cfgcommon.StringList
+	SourceIPs    cfgcommon.StringList
+	TLSSettings  *tlscfg.TLSConfig
 
 	cfgctx context.Context
 }
@@ -42,6 +46,8 @@ func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
 		SkipFallback bool                 `json:"skipFallback"`
 		Domains      []string             `json:"domains"`
 		ExpectIPs    cfgcommon.StringList `json:"expectIps"`
+		SourceIPs    cfgcommon.StringList `json:"sourceIps"`
+		TLSSettings  *tlscfg.TLSConfig    `json:"tlsSettings"`
 	}
 	if err := json.Unmarshal(data, &advanced); err == nil {
 		c.Address = advanced.Address
@@ -50,6 +56,8 @@ func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
 		c.SkipFallback = advanced.SkipFallback
 		c.Domains = advanced.Domains
 		c.ExpectIPs = advanced.ExpectIPs
+		c.SourceIPs = advanced.SourceIPs
+		c.TLSSettings = advanced.TLSSettings
 		return nil
 	}
 
@@ -109,6 +117,11 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 		return nil, newError("invalid IP rule: ", c.ExpectIPs).Base(err)
 	}
 
+	sourceList, err := rule2.ToCidrList(cfgctx, c.SourceIPs)
+	if err != nil {
+		return nil, newError("invalid source IP rule: ", c.SourceIPs).Base(err)
+	}
+
 	var myClientIP []byte
 	if c.ClientIP != nil {
 		if !c.ClientIP.Family().IsIP() {
@@ -117,6 +130,15 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 		myClientIP = []byte(c.ClientIP.IP())
 	}
 
+	var tlsSettings *tls.Config
+	if c.TLSSettings != nil {
+		config, err := c.TLSSettings.Build()
+		if err != nil {
+			return nil, newError("invalid TLS settings").Base(err)
+		}
+		tlsSettings = config.(*tls.Config)
+	}
+
 	return &dns.NameServer{
 		Address: &net.Endpoint{
 			Network: net.Network_UDP,
@@ -128,6 +150,8 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 		PrioritizedDomain: domains,
 		Geoip:             geoipList,
 		OriginalRules:     originalRules,
+		SourceGeoip:       sourceList,
+		TlsSettings:       tlsSettings,
 	}, nil
 }
 
@@ -148,23 +172,114 @@ type DNSConfig struct { // nolint: revive
 	DisableCache           bool                    `json:"disableCache"`
 	DisableFallback        bool                    `json:"disableFallback"`
 	DisableFallbackIfMatch bool                    `json:"disableFallbackIfMatch"`
+	Cache                  *DNSCacheConfig         `json:"cache"`
+	QueryMode              string                  `json:"queryMode"`
+	ParallelConcurrency    uint32                  `json:"parallelConcurrency"`
+	DNSSEC                 *DNSSECConfig           `json:"dnssec"`
+	StripRules             []*DNSStripRule         `json:"stripRules"`
 	cfgctx                 context.Context
 }
 
+// DNSCacheConfig is a JSON serializable object for dns.CacheConfig.
+type DNSCacheConfig struct { // nolint: revive
+	ServeStaleMaxAge uint32 `json:"serveStaleMaxAge"`
+	Prefetch         bool   `json:"prefetch"`
+	PrefetchMinHits  uint32 `json:"prefetchMinHits"`
+	MinTTL           uint32 `json:"minTTL"`
+	MaxTTL           uint32 `json:"maxTTL"`
+	Persistent       bool   `json:"persistent"`
+}
+
+// Build implements Buildable
+func (c *DNSCacheConfig) Build() (*dns.CacheConfig, error) {
+	if c.MaxTTL != 0 && c.MinTTL > c.MaxTTL {
+		return nil, newError("minTTL ", c.MinTTL, " is greater than maxTTL ", c.MaxTTL)
+	}
+	return &dns.CacheConfig{
+		ServeStaleMaxAge: c.ServeStaleMaxAge,
+		Prefetch:         c.Prefetch,
+		PrefetchMinHits:  c.PrefetchMinHits,
+		MinTtl:           c.MinTTL,
+		MaxTtl:           c.MaxTTL,
+		Persistent:       c.Persistent,
+	}, nil
+}
+
+// DNSSECConfig is a JSON serializable object for dns.DNSSECConfig.
+type DNSSECConfig struct { // nolint: revive
+	TrustAnchor []string `json:"trustAnchor"`
+	BogusPolicy string   `json:"bogusPolicy"`
+}
+
+// Build implements Buildable
+func (c *DNSSECConfig) Build() (*dns.DNSSECConfig, error) {
+	config := &dns.DNSSECConfig{
+		TrustAnchor: c.TrustAnchor,
+	}
+	switch strings.ToLower(c.BogusPolicy) {
+	case "", "fail":
+		config.BogusPolicy = dns.DNSSECConfig_FAIL
+	case "flag":
+		config.BogusPolicy = dns.DNSSECConfig_FLAG
+	default:
+		return nil, newError("unknown bogus policy: ", c.BogusPolicy)
+	}
+	return config, nil
+}
+
+// DNSStripRule is a JSON serializable object for dns.StripRule.
+type DNSStripRule struct { // nolint: revive
+	Domains []string `json:"domains"`
+	AAAA    bool     `json:"aaaa"`
+	HTTPS   bool     `json:"https"`
+}
+
+// Build builds the strip rule, with the domain rules loaded in cfgctx.
+func (c *DNSStripRule) Build(cfgctx context.Context) (*dns.StripRule, error) {
+	rule := &dns.StripRule{
+		Aaaa:  c.AAAA,
+		Https: c.HTTPS,
+	}
+	for _, domain := range c.Domains {
+		parsedDomain, err := rule2.ParseDomainRule(cfgctx, domain)
+		if err != nil {
+			return nil, newError("invalid domain rule: ", domain).Base(err)
+		}
+		for _, pd := range parsedDomain {
+			rule.Domain = append(rule.Domain, &dns.NameServer_PriorityDomain{
+				Type:   toDomainMatchingType(pd.Type),
+				Domain: pd.Value,
+			})
+		}
+	}
+	return rule, nil
+}
+
 type HostAddress struct {
-	addr  *cfgcommon.Address
-	addrs []*cfgcommon.Address
+	addr    *cfgcommon.Address
+	addrs   []*cfgcommon.Address
+	cname   string
+	records []string
 }
 
 // UnmarshalJSON implements encoding/json.Unmarshaler.UnmarshalJSON
 func (h *HostAddress) UnmarshalJSON(data []byte) error {
 	addr := new(cfgcommon.Address)
 	var addrs []*cfgcommon.Address
+	var advanced struct {
+		IP      []*cfgcommon.Address `json:"ip"`
+		CNAME   string               `json:"cname"`
+		Records []string             `json:"records"`
+	}
 	switch {
 	case json.Unmarshal(data, &addr) == nil:
 		h.addr = addr
 	case json.Unmarshal(data, &addrs) == nil:
 		h.addrs = addrs
+	case json.Unmarshal(data, &advanced) == nil:
+		h.addrs = advanced.IP
+		h.cname = advanced.CNAME
+		h.records = advanced.Records
 	default:
 		return newError("invalid address")
 	}
@@ -183,18 +298,19 @@ func getHostMapping(ha *HostAddress) *dns.HostMapping {
 		}
 	}
 
-	ips := make([][]byte, 0, len(ha.addrs))
+	mapping := &dns.HostMapping{
+		Cname:  ha.cname,
+		Record: ha.records,
+	}
 	for _, addr := range ha.addrs {
 		if addr.Family().IsDomain() {
-			return &dns.HostMapping{
-				ProxiedDomain: addr.Domain(),
-			}
+			mapping.ProxiedDomain = addr.Domain()
+			mapping.Ip = nil
+			return mapping
 		}
-		ips = append(ips, []byte(addr.IP()))
-	}
-	return &dns.HostMapping{
-		Ip: ips,
+		mapping.Ip = append(mapping.Ip, []byte(addr.IP()))
 	}
+	return mapping
 }
 
 func (c *DNSConfig) BuildV5(ctx context.Context) (*dns.Config, error) {
@@ -235,6 +351,30 @@ func (c *DNSConfig) Build() (*dns.Config, error) {
 		config.ClientIp = []byte(c.ClientIP.IP())
 	}
 
+	if c.Cache != nil {
+		cache, err := c.Cache.Build()
+		if err != nil {
+			return nil, newError("failed to build cache config").Base(err)
+		}
+		config.Cache = cache
+	}
+
+	if c.DNSSEC != nil {
+		dnssec, err := c.DNSSEC.Build()
+		if err != nil {
+			return nil, newError("failed to build DNSSEC config").Base(err)
+		}
+		config.Dnssec = dnssec
+	}
+
+	for _, rule := range c.StripRules {
+		stripRule, err := rule.Build(c.cfgctx)
+		if err != nil {
+			return nil, newError("failed to build strip rule").Base(err)
+		}
+		config.StripRule = append(config.StripRule, stripRule)
+	}
+
 	config.QueryStrategy = dns.QueryStrategy_USE_IP
 	switch strings.ToLower(c.QueryStrategy) {
 	case "useip", "use_ip", "use-ip":
@@ -245,6 +385,16 @@ func (c *DNSConfig) Build() (*dns.Config, error) {
 		config.QueryStrategy = dns.QueryStrategy_USE_IP6
 	}
 
+	switch strings.ToLower(c.QueryMode) {
+	case "", "sequential":
+		config.QueryMode = dns.QueryMode_SEQUENTIAL
+	case "parallel":
+		config.QueryMode = dns.QueryMode_PARALLEL
+		config.ParallelConcurrency = c.ParallelConcurrency
+	default:
+		return nil, newError("unknown query mode: ", c.QueryMode)
+	}
+
 	for _, server := range c.Servers {
 		server.cfgctx = c.cfgctx
 		ns, err := server.Build()
@@ -53,6 +53,12 @@ func (r *BalancingRule) Build() (*router.BalancingRule, error) {
 		strategy = strategyLeastLoad
 	case strategyLeastPing:
 		strategy = "leastping"
+	case strategyConsistentHash:
+		strategy = strategyConsistentHash
+	case strategyWeightedRoundRobin:
+		strategy = strategyWeightedRoundRobin
+	case strategyFailover:
+		strategy = strategyFailover
 	default:
 		return nil, newError("unknown balancing strategy: " + r.Strategy.Type)
 	}
@@ -82,11 +88,68 @@ func (r *BalancingRule) Build() (*router.BalancingRule, error) {
 	}, nil
 }
 
+// RuleSetConfig is a rule set loaded from a file or URL.
+type RuleSetConfig struct {
+	Tag             string `json:"tag"`
+	Format          string `json:"format"`
+	Path            string `json:"path"`
+	URL             string `json:"url"`
+	OutboundTag     string `json:"outboundTag"`
+	Code            string `json:"code"`
+	RefreshInterval uint32 `json:"refreshInterval"`
+}
+
+// Build builds the rule set.
+func (r *RuleSetConfig) Build() (*router.RuleSet, error) {
+	if r.Tag == "" {
+		return nil, newError("empty rule set tag")
+	}
+
+	ruleSet := &router.RuleSet{
+		Tag:             r.Tag,
+		OutboundTag:     r.OutboundTag,
+		Code:            r.Code,
+		RefreshInterval: r.RefreshInterval,
+	}
+
+	switch strings.ToLower(r.Format) {
+	case "text", "":
+		ruleSet.Format = router.RuleSet_Text
+	case "geosite":
+		ruleSet.Format = router.RuleSet_GeoSite
+	case "geoip":
+		ruleSet.Format = router.RuleSet_GeoIP
+	case "clash":
+		ruleSet.Format = router.RuleSet_Clash
+	case "singbox", "sing-box":
+		ruleSet.Format = router.RuleSet_SingBox
+	default:
+		return nil, newError("unknown rule set format: ", r.Format)
+	}
+	if (ruleSet.Format == router.RuleSet_GeoSite || ruleSet.Format == router.RuleSet_GeoIP) && r.Code == "" {
+		return nil, newError("code is required for rule set ", r.Tag)
+	}
+
+	switch {
+	case r.Path != "" && r.URL != "":
+		return nil, newError("both path and url are specified in rule set ", r.Tag)
+	case r.Path != "":
+		ruleSet.Source = &router.RuleSet_Path{Path: r.Path}
+	case r.URL != "":
+		ruleSet.Source = &router.RuleSet_Url{Url: r.URL}
+	default:
+		return nil, newError("neither path nor url is specified in rule set ", r.Tag)
+	}
+
+	return ruleSet, nil
+}
+
 type RouterConfig struct { // nolint: revive
 	Settings       *RouterRulesConfig `json:"settings"` // Deprecated
 	RuleList       []json.RawMessage  `json:"rules"`
 	DomainStrategy *string            `json:"domainStrategy"`
 	Balancers      []*BalancingRule   `json:"balancers"`
+	RuleSets       []*RuleSetConfig   `json:"ruleSets"`
 
 	DomainMatcher string `json:"domainMatcher"`
 
@@ -164,5 +227,12 @@ func (c *RouterConfig) Build() (*router.Config, error) {
 		}
 		config.BalancingRule = append(config.BalancingRule, balancer)
 	}
+	for _, rawRuleSet := range c.RuleSets {
+		ruleSet, err := rawRuleSet.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.RuleSet = append(config.RuleSet, ruleSet)
+	}
 	return config, nil
 }
@@ -1,8 +1,11 @@
 package router
 
 import (
+	"strings"
+
 	"github.com/golang/protobuf/proto"
 
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
 	"github.com/v2fly/v2ray-core/v5/app/observatory/burst"
 	"github.com/v2fly/v2ray-core/v5/app/router"
 	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon/duration"
@@ -13,12 +16,20 @@ const (
 	strategyRandom    string = "random"
 	strategyLeastLoad string = "leastload"
 	strategyLeastPing string = "leastping"
+
+	strategyConsistentHash     string = "consistenthash"
+	strategyWeightedRoundRobin string = "weightedroundrobin"
+	strategyFailover           string = "failover"
 )
 
 var strategyConfigLoader = loader.NewJSONConfigLoader(loader.ConfigCreatorCache{
 	strategyRandom:    func() interface{} { return new(strategyEmptyConfig) },
 	strategyLeastLoad: func() interface{} { return new(strategyLeastLoadConfig) },
 	strategyLeastPing: func() interface{} { return new(strategyLeastPingConfig) },
+
+	strategyConsistentHash:     func() interface{} { return new(strategyConsistentHashConfig) },
+	strategyWeightedRoundRobin: func() interface{} { return new(strategyWeightedRoundRobinConfig) },
+	strategyFailover:           func() interface{} { return new(strategyFailoverConfig) },
 }, "type", "settings")
 
 type strategyEmptyConfig struct{}
@@ -40,6 +51,11 @@ type strategyLeastLoadConfig struct {
 	Tolerance float64 `json:"tolerance,omitempty"`
 
 	ObserverTag string `json:"observerTag,omitempty"`
+
+	// bandwidth in bytes per second that costs are scaled against
+	BandwidthReference int64 `json:"bandwidthReference,omitempty"`
+	// min acceptable bandwidth in bytes per second
+	MinBandwidth int64 `json:"minBandwidth,omitempty"`
 }
 
 // HealthCheckSettings holds settings for health Checker
@@ -49,15 +65,97 @@ type HealthCheckSettings struct {
 	Interval      duration.Duration `json:"interval"`
 	SamplingCount int               `json:"sampling"`
 	Timeout       duration.Duration `json:"timeout"`
+	Probe         *ProbeSettings    `json:"probe"`
+
+	BandwidthProbe *BandwidthProbeSettings `json:"bandwidthProbe"`
 }
 
 func (h HealthCheckSettings) Build() (proto.Message, error) {
-	return &burst.HealthPingConfig{
+	config := &burst.HealthPingConfig{
 		Destination:   h.Destination,
 		Connectivity:  h.Connectivity,
 		Interval:      int64(h.Interval),
 		Timeout:       int64(h.Timeout),
 		SamplingCount: int32(h.SamplingCount),
+	}
+	if h.Probe != nil {
+		probe, err := h.Probe.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.Probe = probe.(*observatory.ProbeConfig)
+	}
+	if h.BandwidthProbe != nil {
+		probe, err := h.BandwidthProbe.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.BandwidthProbe = probe.(*observatory.BandwidthProbeConfig)
+	}
+	return config, nil
+}
+
+// ProbeSettings holds settings for a probe of observatories
+type ProbeSettings struct {
+	Type        string `json:"type"`
+	Destination string `json:"destination"`
+	ServerName  string `json:"serverName"`
+	Domain      string `json:"domain"`
+	Payload     string `json:"payload"`
+}
+
+func (p ProbeSettings) Build() (proto.Message, error) {
+	config := &observatory.ProbeConfig{
+		Destination: p.Destination,
+		ServerName:  p.ServerName,
+		Domain:      p.Domain,
+	}
+	if p.Payload != "" {
+		config.Payload = []byte(p.Payload)
+	}
+	switch strings.ToLower(p.Type) {
+	case "", "http":
+		config.Kind = observatory.ProbeConfig_HTTP
+	case "tcp":
+		config.Kind = observatory.ProbeConfig_TCP
+	case "tls":
+		config.Kind = observatory.ProbeConfig_TLS
+	case "dns":
+		config.Kind = observatory.ProbeConfig_DNS
+	case "udp":
+		config.Kind = observatory.ProbeConfig_UDP
+		if p.Payload == "" {
+			return nil, newError("payload of UDP probe is required")
+		}
+	default:
+		return nil, newError("unknown probe type: ", p.Type)
+	}
+	if config.Destination == "" && config.Kind != observatory.ProbeConfig_HTTP {
+		return nil, newError("destination of ", p.Type, " probe is required")
+	}
+	return config, nil
+}
+
+// BandwidthProbeSettings holds settings for the bandwidth probe of observatories
+type BandwidthProbeSettings struct {
+	URL      string            `json:"url"`
+	Size     int64             `json:"size"`
+	Interval duration.Duration `json:"interval"`
+	Timeout  duration.Duration `json:"timeout"`
+}
+
+func (b BandwidthProbeSettings) Build() (proto.Message, error) {
+	if b.URL == "" {
+		return nil, newError("url of bandwidth probe is required")
+	}
+	if b.Size < 0 {
+		return nil, newError("negative size of bandwidth probe")
+	}
+	return &observatory.BandwidthProbeConfig{
+		Url:      b.URL,
+		Size:     b.Size,
+		Interval: int64(b.Interval),
+		Timeout:  int64(b.Timeout),
 	}, nil
 }
 
@@ -88,6 +186,11 @@ func (v *strategyLeastLoadConfig) Build() (proto.Message, error) {
 		}
 		config.Baselines = append(config.Baselines, int64(b))
 	}
+	if v.BandwidthReference < 0 || v.MinBandwidth < 0 {
+		return nil, newError("negative bandwidth of least load strategy")
+	}
+	config.BandwidthReference = v.BandwidthReference
+	config.MinBandwidth = v.MinBandwidth
 	return config, nil
 }
 
@@ -98,3 +201,55 @@ type strategyLeastPingConfig struct {
 func (s strategyLeastPingConfig) Build() (proto.Message, error) {
 	return &router.StrategyLeastPingConfig{ObserverTag: s.ObserverTag}, nil
 }
+
+type strategyConsistentHashConfig struct {
+	// Key to hash connections by: sourceIP, user, domain or attribute.
+	Key string `json:"key,omitempty"`
+	// Attribute to hash connections by, when key is attribute.
+	Attribute   string `json:"attribute,omitempty"`
+	ObserverTag string `json:"observerTag,omitempty"`
+}
+
+func (s strategyConsistentHashConfig) Build() (proto.Message, error) {
+	config := &router.StrategyConsistentHashConfig{ObserverTag: s.ObserverTag}
+	switch strings.ToLower(s.Key) {
+	case "sourceip", "":
+		config.Key = router.StrategyConsistentHashConfig_SourceIp
+	case "user", "email", "useremail":
+		config.Key = router.StrategyConsistentHashConfig_UserEmail
+	case "domain", "targetdomain":
+		config.Key = router.StrategyConsistentHashConfig_TargetDomain
+	case "attribute":
+		if s.Attribute == "" {
+			return nil, newError("empty attribute name for consistent hash key")
+		}
+		config.Key = router.StrategyConsistentHashConfig_Attribute
+		config.Attribute = s.Attribute
+	default:
+		return nil, newError("unknown consistent hash key: ", s.Key)
+	}
+	return config, nil
+}
+
+type strategyWeightedRoundRobinConfig struct {
+	// weight settings
+	Weights     []*router.StrategyWeight `json:"weights,omitempty"`
+	ObserverTag string                   `json:"observerTag,omitempty"`
+}
+
+func (s strategyWeightedRoundRobinConfig) Build() (proto.Message, error) {
+	return &router.StrategyWeightedRoundRobinConfig{Weights: s.Weights, ObserverTag: s.ObserverTag}, nil
+}
+
+type strategyFailoverConfig struct {
+	// time a failed outbound has to stay alive before it is used again
+	RecoveryHold duration.Duration `json:"recoveryHold,omitempty"`
+	ObserverTag  string            `json:"observerTag,omitempty"`
+}
+
+func (s strategyFailoverConfig) Build() (proto.Message, error) {
+	if s.RecoveryHold < 0 {
+		return nil, newError("negative recovery hold time")
+	}
+	return &router.StrategyFailoverConfig{RecoveryHold: int64(s.RecoveryHold), ObserverTag: s.ObserverTag}, nil
+}
@@ -67,6 +67,28 @@ func TestRouterConfig(t *testing.T) {
 							"type": "field",
 							"port": 123,
 							"outboundTag": "test"
+						},{
+							"type": "field",
+							"process": ["curl", "/usr/bin/wget"],
+							"uid": [1000],
+							"schedule": {
+								"weekday": ["Sat", "Sun"],
+								"time": [{"from": "09:00", "to": "18:00"}],
+								"timeZone": "UTC"
+							},
+							"outboundTag": "test"
+						},{
+							"type": "logical",
+							"operator": "or",
+							"rules": [
+								{"port": 443},
+								{
+									"type": "logical",
+									"operator": "not",
+									"rules": [{"network": "tcp"}]
+								}
+							],
+							"balancerTag": "b1"
 						}
 					]
 				},
@@ -98,10 +120,48 @@ func TestRouterConfig(t *testing.T) {
 								"baselines": ["400ms", "600ms"],
 								"expected": 6,
 								"maxRTT": "1000ms",
-								"tolerance": 0.5
+								"tolerance": 0.5,
+								"bandwidthReference": 1048576,
+								"minBandwidth": 65536
 							}
 						},
 						"fallbackTag": "fall"
+					},
+					{
+						"tag": "b3",
+						"selector": ["test"],
+						"strategy": {
+							"type": "consistentHash",
+							"settings": {
+								"key": "domain",
+								"observerTag": "obs"
+							}
+						}
+					},
+					{
+						"tag": "b4",
+						"selector": ["test"],
+						"strategy": {
+							"type": "weightedRoundRobin",
+							"settings": {
+								"weights": [
+									{
+										"match": "a",
+										"value": 3
+									}
+								]
+							}
+						}
+					},
+					{
+						"tag": "b5",
+						"selector": ["primary", "backup"],
+						"strategy": {
+							"type": "failover",
+							"settings": {
+								"recoveryHold": "5m"
+							}
+						}
 					}
 				]
 			}`,
@@ -133,9 +193,42 @@ func TestRouterConfig(t *testing.T) {
 							Expected:  6,
 							MaxRTT:    int64(time.Duration(1000) * time.Millisecond),
 							Tolerance: 0.5,
+
+							BandwidthReference: 1048576,
+							MinBandwidth:       65536,
 						}),
 						FallbackTag: "fall",
 					},
+					{
+						Tag:              "b3",
+						OutboundSelector: []string{"test"},
+						Strategy:         "consistenthash",
+						StrategySettings: serial.ToTypedMessage(&router.StrategyConsistentHashConfig{
+							Key:         router.StrategyConsistentHashConfig_TargetDomain,
+							ObserverTag: "obs",
+						}),
+					},
+					{
+						Tag:              "b4",
+						OutboundSelector: []string{"test"},
+						Strategy:         "weightedroundrobin",
+						StrategySettings: serial.ToTypedMessage(&router.StrategyWeightedRoundRobinConfig{
+							Weights: []*router.StrategyWeight{
+								{
+									Match: "a",
+									Value: 3,
+								},
+							},
+						}),
+					},
+					{
+						Tag:              "b5",
+						OutboundSelector: []string{"primary", "backup"},
+						Strategy:         "failover",
+						StrategySettings: serial.ToTypedMessage(&router.StrategyFailoverConfig{
+							RecoveryHold: int64(5 * time.Minute),
+						}),
+					},
 				},
 				Rule: []*router.RoutingRule{
 					{
@@ -209,6 +302,42 @@ func TestRouterConfig(t *testing.T) {
 							Tag: "test",
 						},
 					},
+					{
+						ProcessName: []string{"curl"},
+						ProcessPath: []string{"/usr/bin/wget"},
+						Uid:         []uint32{1000},
+						Schedule: &router.Schedule{
+							Weekday:  []string{"Sat", "Sun"},
+							Time:     []*router.Schedule_Range{{From: "09:00", To: "18:00"}},
+							TimeZone: "UTC",
+						},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "test",
+						},
+					},
+					{
+						Logical: &router.LogicalRule{
+							Operator: router.LogicalRule_Or,
+							Rule: []*router.RoutingRule{
+								{
+									PortList: &net.PortList{
+										Range: []*net.PortRange{{From: 443, To: 443}},
+									},
+								},
+								{
+									Logical: &router.LogicalRule{
+										Operator: router.LogicalRule_Not,
+										Rule: []*router.RoutingRule{
+											{Networks: []net.Network{net.Network_TCP}},
+										},
+									},
+								},
+							},
+						},
+						TargetTag: &router.RoutingRule_BalancingTag{
+							BalancingTag: "b1",
+						},
+					},
 				},
 			},
 		},
@@ -387,5 +516,60 @@ func TestRouterConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			Input: `{
+				"rules": [
+					{
+						"type": "field",
+						"ruleSet": ["ads", "cn"],
+						"ruleTag": "ads",
+						"outboundTag": "block"
+					}
+				],
+				"ruleSets": [
+					{
+						"tag": "ads",
+						"format": "clash",
+						"url": "https://example.com/ads.yaml",
+						"outboundTag": "proxy",
+						"refreshInterval": 3600
+					},
+					{
+						"tag": "cn",
+						"format": "geoip",
+						"path": "geoip.dat",
+						"code": "CN"
+					}
+				]
+			}`,
+			Parser: createParser(),
+			Output: &router.Config{
+				DomainStrategy: router.DomainStrategy_AsIs,
+				Rule: []*router.RoutingRule{
+					{
+						RuleSet: []string{"ads", "cn"},
+						RuleTag: "ads",
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "block",
+						},
+					},
+				},
+				RuleSet: []*router.RuleSet{
+					{
+						Tag:             "ads",
+						Format:          router.RuleSet_Clash,
+						Source:          &router.RuleSet_Url{Url: "https://example.com/ads.yaml"},
+						OutboundTag:     "proxy",
+						RefreshInterval: 3600,
+					},
+					{
+						Tag:    "cn",
+						Format: router.RuleSet_GeoIP,
+						Source: &router.RuleSet_Path{Path: "geoip.dat"},
+						Code:   "CN",
+					},
+				},
+			},
+		},
 	})
 }
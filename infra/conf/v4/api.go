@@ -8,6 +8,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/v2fly/v2ray-core/v5/app/commander"
+	dnsservice "github.com/v2fly/v2ray-core/v5/app/dns/command"
 	loggerservice "github.com/v2fly/v2ray-core/v5/app/log/command"
 	observatoryservice "github.com/v2fly/v2ray-core/v5/app/observatory/command"
 	handlerservice "github.com/v2fly/v2ray-core/v5/app/proxyman/command"
@@ -39,6 +40,8 @@ func (c *APIConfig) Build() (*commander.Config, error) {
 			services = append(services, serial.ToTypedMessage(&statsservice.Config{}))
 		case "observatoryservice":
 			services = append(services, serial.ToTypedMessage(&observatoryservice.Config{}))
+		case "dnsservice":
+			services = append(services, serial.ToTypedMessage(&dnsservice.Config{}))
 		case "routingservice":
 			services = append(services, serial.ToTypedMessage(&routerservice.Config{}))
 		default:
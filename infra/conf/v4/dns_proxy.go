@@ -28,3 +28,15 @@ func (c *DNSOutboundConfig) Build() (proto.Message, error) {
 	}
 	return config, nil
 }
+
+type DNSInboundConfig struct {
+	UserLevel uint32 `json:"userLevel"`
+	DoHPath   string `json:"dohPath"`
+}
+
+func (c *DNSInboundConfig) Build() (proto.Message, error) {
+	return &dns.ServerConfig{
+		UserLevel: c.UserLevel,
+		DohPath:   c.DoHPath,
+	}, nil
+}
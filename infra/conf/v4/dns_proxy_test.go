@@ -33,3 +33,23 @@ func TestDnsProxyConfig(t *testing.T) {
 		},
 	})
 }
+
+func TestDnsInboundConfig(t *testing.T) {
+	creator := func() cfgcommon.Buildable {
+		return new(v4.DNSInboundConfig)
+	}
+
+	testassist.RunMultiTestCase(t, []testassist.TestCase{
+		{
+			Input: `{
+				"userLevel": 1,
+				"dohPath": "/dns-query"
+			}`,
+			Parser: testassist.LoadJSON(creator),
+			Output: &dns.ServerConfig{
+				UserLevel: 1,
+				DohPath:   "/dns-query",
+			},
+		},
+	})
+}
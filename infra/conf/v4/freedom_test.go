@@ -9,6 +9,7 @@ import (
 	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon/testassist"
 	v4 "github.com/v2fly/v2ray-core/v5/infra/conf/v4"
 	"github.com/v2fly/v2ray-core/v5/proxy/freedom"
+	"github.com/v2fly/v2ray-core/v5/transport/internet"
 )
 
 func TestFreedomConfig(t *testing.T) {
@@ -41,5 +42,20 @@ func TestFreedomConfig(t *testing.T) {
 				UserLevel: 1,
 			},
 		},
+		{
+			Input: `{
+				"domainStrategy": "PreferIPv6",
+				"happyEyeballs": true,
+				"happyEyeballsDelay": 100
+			}`,
+			Parser: testassist.LoadJSON(creator),
+			Output: &freedom.Config{
+				DomainStrategy: freedom.Config_PREFER_IP6,
+				HappyEyeballs: &internet.HappyEyeballsConfig{
+					Enabled:  true,
+					TryDelay: 100,
+				},
+			},
+		},
 	})
 }
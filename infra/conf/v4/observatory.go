@@ -8,6 +8,7 @@ import (
 
 	"github.com/v2fly/v2ray-core/v5/app/observatory"
 	"github.com/v2fly/v2ray-core/v5/app/observatory/burst"
+	"github.com/v2fly/v2ray-core/v5/app/observatory/circuitbreaker"
 	"github.com/v2fly/v2ray-core/v5/app/observatory/multiobservatory"
 	"github.com/v2fly/v2ray-core/v5/common/serial"
 	"github.com/v2fly/v2ray-core/v5/common/taggedfeatures"
@@ -19,10 +20,34 @@ type ObservatoryConfig struct {
 	SubjectSelector []string          `json:"subjectSelector"`
 	ProbeURL        string            `json:"probeURL"`
 	ProbeInterval   duration.Duration `json:"probeInterval"`
+	ProbeTimeout    duration.Duration `json:"probeTimeout"`
+
+	Probes         []router.ProbeSettings         `json:"probes"`
+	BandwidthProbe *router.BandwidthProbeSettings `json:"bandwidthProbe"`
 }
 
 func (o *ObservatoryConfig) Build() (proto.Message, error) {
-	return &observatory.Config{SubjectSelector: o.SubjectSelector, ProbeUrl: o.ProbeURL, ProbeInterval: int64(o.ProbeInterval)}, nil
+	config := &observatory.Config{
+		SubjectSelector: o.SubjectSelector,
+		ProbeUrl:        o.ProbeURL,
+		ProbeInterval:   int64(o.ProbeInterval),
+		ProbeTimeout:    int64(o.ProbeTimeout),
+	}
+	for _, p := range o.Probes {
+		probe, err := p.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.Probe = append(config.Probe, probe.(*observatory.ProbeConfig))
+	}
+	if o.BandwidthProbe != nil {
+		probe, err := o.BandwidthProbe.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.BandwidthProbe = probe.(*observatory.BandwidthProbeConfig)
+	}
+	return config, nil
 }
 
 type BurstObservatoryConfig struct {
@@ -81,3 +106,20 @@ func (o *MultiObservatoryConfig) Build() (proto.Message, error) {
 	}
 	return ret, nil
 }
+
+type CircuitBreakerConfig struct {
+	SubjectSelector  []string          `json:"subjectSelector"`
+	FailureThreshold uint32            `json:"failureThreshold"`
+	OpenDuration     duration.Duration `json:"openDuration"`
+}
+
+func (c *CircuitBreakerConfig) Build() (proto.Message, error) {
+	if c.OpenDuration < 0 {
+		return nil, newError("negative open duration of circuit breaker")
+	}
+	return &circuitbreaker.Config{
+		SubjectSelector:  c.SubjectSelector,
+		FailureThreshold: c.FailureThreshold,
+		OpenDuration:     int64(c.OpenDuration),
+	}, nil
+}
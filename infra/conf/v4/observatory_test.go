@@ -0,0 +1,95 @@
+package v4_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/app/observatory"
+	"github.com/v2fly/v2ray-core/v5/app/observatory/burst"
+	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon"
+	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon/testassist"
+	v4 "github.com/v2fly/v2ray-core/v5/infra/conf/v4"
+)
+
+func TestObservatoryConfig(t *testing.T) {
+	creator := func() cfgcommon.Buildable {
+		return new(v4.ObservatoryConfig)
+	}
+
+	testassist.RunMultiTestCase(t, []testassist.TestCase{
+		{
+			Input: `{
+				"subjectSelector": ["proxy"],
+				"probeInterval": "30s",
+				"probeTimeout": "3s",
+				"probes": [
+					{
+						"type": "tls",
+						"destination": "1.1.1.1:443",
+						"serverName": "one.one.one.one"
+					},
+					{
+						"type": "dns",
+						"destination": "8.8.8.8",
+						"domain": "example.com"
+					},
+					{
+						"type": "udp",
+						"destination": "10.0.0.1:7",
+						"payload": "ping"
+					}
+				],
+				"bandwidthProbe": {
+					"url": "https://example.com/10MB.bin",
+					"size": 1048576,
+					"interval": "30m"
+				}
+			}`,
+			Parser: testassist.LoadJSON(creator),
+			Output: &observatory.Config{
+				SubjectSelector: []string{"proxy"},
+				ProbeInterval:   int64(30 * time.Second),
+				ProbeTimeout:    int64(3 * time.Second),
+				Probe: []*observatory.ProbeConfig{
+					{Kind: observatory.ProbeConfig_TLS, Destination: "1.1.1.1:443", ServerName: "one.one.one.one"},
+					{Kind: observatory.ProbeConfig_DNS, Destination: "8.8.8.8", Domain: "example.com"},
+					{Kind: observatory.ProbeConfig_UDP, Destination: "10.0.0.1:7", Payload: []byte("ping")},
+				},
+				BandwidthProbe: &observatory.BandwidthProbeConfig{
+					Url:      "https://example.com/10MB.bin",
+					Size:     1048576,
+					Interval: int64(30 * time.Minute),
+				},
+			},
+		},
+	})
+}
+
+func TestBurstObservatoryConfig(t *testing.T) {
+	creator := func() cfgcommon.Buildable {
+		return new(v4.BurstObservatoryConfig)
+	}
+
+	testassist.RunMultiTestCase(t, []testassist.TestCase{
+		{
+			Input: `{
+				"subjectSelector": ["proxy"],
+				"pingConfig": {
+					"interval": "1m",
+					"probe": {
+						"type": "tcp",
+						"destination": "example.com:80"
+					}
+				}
+			}`,
+			Parser: testassist.LoadJSON(creator),
+			Output: &burst.Config{
+				SubjectSelector: []string{"proxy"},
+				PingConfig: &burst.HealthPingConfig{
+					Interval: int64(time.Minute),
+					Probe:    &observatory.ProbeConfig{Kind: observatory.ProbeConfig_TCP, Destination: "example.com:80"},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package v4
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/v2fly/v2ray-core/v5/app/persistentstorage/filesystemstorage"
+)
+
+type PersistentStorageConfig struct {
+	Path string `json:"path"`
+}
+
+func (c *PersistentStorageConfig) Build() (proto.Message, error) {
+	if c.Path == "" {
+		return nil, newError("path of persistent storage is not set")
+	}
+	return &filesystemstorage.Config{Path: c.Path}, nil
+}
@@ -55,6 +55,10 @@ type SystemPolicy struct {
 	StatsInboundDownlink  bool `json:"statsInboundDownlink"`
 	StatsOutboundUplink   bool `json:"statsOutboundUplink"`
 	StatsOutboundDownlink bool `json:"statsOutboundDownlink"`
+	StatsRuleHit          bool `json:"statsRuleHit"`
+	StatsRuleUplink       bool `json:"statsRuleUplink"`
+	StatsRuleDownlink     bool `json:"statsRuleDownlink"`
+	StatsDNSServer        bool `json:"statsDnsServer"`
 }
 
 func (p *SystemPolicy) Build() (*policy.SystemPolicy, error) {
@@ -64,6 +68,10 @@ func (p *SystemPolicy) Build() (*policy.SystemPolicy, error) {
 			InboundDownlink:  p.StatsInboundDownlink,
 			OutboundUplink:   p.StatsOutboundUplink,
 			OutboundDownlink: p.StatsOutboundDownlink,
+			RuleHit:          p.StatsRuleHit,
+			RuleUplink:       p.StatsRuleUplink,
+			RuleDownlink:     p.StatsRuleDownlink,
+			DnsServer:        p.StatsDNSServer,
 		},
 	}, nil
 }
@@ -47,6 +47,28 @@ func TestSocketConfig(t *testing.T) {
 				TfoQueueLength: 1024,
 			},
 		},
+		{
+			Input: `{
+				"mptcp": true,
+				"tcpCongestion": "bbr",
+				"tcpUserTimeout": 30000,
+				"domainStrategy": "PreferIPv4",
+				"happyEyeballs": true,
+				"freeBind": true
+			}`,
+			Parser: createParser(),
+			Output: &internet.SocketConfig{
+				TfoQueueLength: 4096,
+				Mptcp:          true,
+				TcpCongestion:  "bbr",
+				TcpUserTimeout: 30000,
+				DomainStrategy: internet.SocketConfig_PREFER_IP4,
+				HappyEyeballs: &internet.HappyEyeballsConfig{
+					Enabled: true,
+				},
+				FreeBind: true,
+			},
+		},
 	})
 }
 
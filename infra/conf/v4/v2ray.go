@@ -10,8 +10,10 @@ import (
 	"github.com/v2fly/v2ray-core/v5/app/dispatcher"
 	"github.com/v2fly/v2ray-core/v5/app/proxyman"
 	"github.com/v2fly/v2ray-core/v5/app/stats"
+	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/common/serial"
 	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon"
+	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon/demuxcfg"
 	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon/loader"
 	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon/muxcfg"
 	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon/proxycfg"
@@ -30,6 +32,7 @@ var (
 		"vless":         func() interface{} { return new(VLessInboundConfig) },
 		"vmess":         func() interface{} { return new(VMessInboundConfig) },
 		"trojan":        func() interface{} { return new(TrojanServerConfig) },
+		"dns":           func() interface{} { return new(DNSInboundConfig) },
 	}, "protocol", "settings")
 
 	outboundConfigLoader = loader.NewJSONConfigLoader(loader.ConfigCreatorCache{
@@ -105,6 +108,7 @@ type InboundDetourConfig struct {
 	StreamSetting  *StreamConfig                  `json:"streamSettings"`
 	DomainOverride *cfgcommon.StringList          `json:"domainOverride"`
 	SniffingConfig *sniffer.SniffingConfig        `json:"sniffing"`
+	DemuxConfig    *demuxcfg.DemuxConfig          `json:"demux"`
 }
 
 // Build implements Buildable.
@@ -170,6 +174,13 @@ func (c *InboundDetourConfig) Build() (*core.InboundHandlerConfig, error) {
 		}
 		receiverSettings.SniffingSettings = s
 	}
+	if c.DemuxConfig != nil {
+		d, err := c.DemuxConfig.Build()
+		if err != nil {
+			return nil, newError("failed to build demux config").Base(err)
+		}
+		receiverSettings.Demux = d
+	}
 	if c.DomainOverride != nil {
 		kp, err := toProtocolList(*c.DomainOverride)
 		if err != nil {
@@ -204,6 +215,7 @@ func (c *InboundDetourConfig) Build() (*core.InboundHandlerConfig, error) {
 type OutboundDetourConfig struct {
 	Protocol      string                `json:"protocol"`
 	SendThrough   *cfgcommon.Address    `json:"sendThrough"`
+	SendStrategy  string                `json:"sendThroughStrategy"`
 	Tag           string                `json:"tag"`
 	Settings      *json.RawMessage      `json:"settings"`
 	StreamSetting *StreamConfig         `json:"streamSettings"`
@@ -217,10 +229,28 @@ func (c *OutboundDetourConfig) Build() (*core.OutboundHandlerConfig, error) {
 
 	if c.SendThrough != nil {
 		address := c.SendThrough
-		if address.Family().IsDomain() {
+		switch {
+		case address.Family().IsDomain() && strings.Contains(address.Domain(), "/"):
+			if _, _, err := net.ParseCIDR(address.Domain()); err != nil {
+				return nil, newError("unable to send through: " + address.String()).Base(err)
+			}
+			senderSettings.ViaCidr = address.Domain()
+		case address.Family().IsDomain():
 			return nil, newError("unable to send through: " + address.String())
+		default:
+			senderSettings.Via = address.Build()
 		}
-		senderSettings.Via = address.Build()
+	}
+
+	switch strings.ToLower(c.SendStrategy) {
+	case "", "random":
+		senderSettings.ViaStrategy = proxyman.SenderConfig_Random
+	case "user", "userhash":
+		senderSettings.ViaStrategy = proxyman.SenderConfig_UserHash
+	case "destination", "destinationhash":
+		senderSettings.ViaStrategy = proxyman.SenderConfig_DestinationHash
+	default:
+		return nil, newError("unknown send through strategy: ", c.SendStrategy)
 	}
 
 	if c.StreamSetting != nil {
@@ -307,6 +337,9 @@ type Config struct {
 	Observatory      *ObservatoryConfig      `json:"observatory"`
 	BurstObservatory *BurstObservatoryConfig `json:"burstObservatory"`
 	MultiObservatory *MultiObservatoryConfig `json:"multiObservatory"`
+	CircuitBreaker   *CircuitBreakerConfig   `json:"circuitBreaker"`
+
+	PersistentStorage *PersistentStorageConfig `json:"persistentStorage"`
 
 	Services map[string]*json.RawMessage `json:"services"`
 }
@@ -463,6 +496,22 @@ func (c *Config) Build() (*core.Config, error) {
 		config.App = append(config.App, serial.ToTypedMessage(r))
 	}
 
+	if c.CircuitBreaker != nil {
+		r, err := c.CircuitBreaker.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.App = append(config.App, serial.ToTypedMessage(r))
+	}
+
+	if c.PersistentStorage != nil {
+		r, err := c.PersistentStorage.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.App = append(config.App, serial.ToTypedMessage(r))
+	}
+
 	// Load Additional Services that do not have a json translator
 
 	if msg, err := c.BuildServices(c.Services); err != nil {
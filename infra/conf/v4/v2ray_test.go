@@ -380,3 +380,41 @@ func TestMuxConfig_Build(t *testing.T) {
 		})
 	}
 }
+
+func TestOutboundDetourConfig_SendThrough(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+		want   *proxyman.SenderConfig
+	}{
+		{"ip", `{"sendThrough": "10.0.0.1"}`, &proxyman.SenderConfig{
+			Via: net.NewIPOrDomain(net.ParseAddress("10.0.0.1")),
+		}},
+		{"cidr", `{"sendThrough": "2001:db8::/64", "sendThroughStrategy": "user"}`, &proxyman.SenderConfig{
+			ViaCidr:     "2001:db8::/64",
+			ViaStrategy: proxyman.SenderConfig_UserHash,
+		}},
+		{"invalid cidr", `{"sendThrough": "2001:db8::/129"}`, nil},
+		{"domain", `{"sendThrough": "example.com"}`, nil},
+		{"unknown strategy", `{"sendThrough": "2001:db8::/64", "sendThroughStrategy": "round"}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &v4.OutboundDetourConfig{Protocol: "freedom"}
+			common.Must(json.Unmarshal([]byte(tt.fields), c))
+			config, err := c.Build()
+			if tt.want == nil {
+				if err == nil {
+					t.Error("expect error, but got nil")
+				}
+				return
+			}
+			common.Must(err)
+			got, err := serial.GetInstanceOf(config.SenderSettings)
+			common.Must(err)
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("OutboundDetourConfig.Build() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -59,6 +59,13 @@ func (c InboundConfig) BuildV5(ctx context.Context) (proto.Message, error) {
 		}
 		receiverSettings.SniffingSettings = s
 	}
+	if c.DemuxConfig != nil {
+		d, err := c.DemuxConfig.Build()
+		if err != nil {
+			return nil, newError("failed to build demux config").Base(err)
+		}
+		receiverSettings.Demux = d
+	}
 
 	if c.Settings == nil {
 		c.Settings = []byte("{}")
@@ -2,11 +2,13 @@ package v5cfg
 
 import (
 	"context"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 
 	core "github.com/v2fly/v2ray-core/v5"
 	"github.com/v2fly/v2ray-core/v5/app/proxyman"
+	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/common/serial"
 	"github.com/v2fly/v2ray-core/v5/transport/internet"
 )
@@ -16,10 +18,28 @@ func (c OutboundConfig) BuildV5(ctx context.Context) (proto.Message, error) {
 
 	if c.SendThrough != nil {
 		address := c.SendThrough
-		if address.Family().IsDomain() {
+		switch {
+		case address.Family().IsDomain() && strings.Contains(address.Domain(), "/"):
+			if _, _, err := net.ParseCIDR(address.Domain()); err != nil {
+				return nil, newError("unable to send through: " + address.String()).Base(err)
+			}
+			senderSettings.ViaCidr = address.Domain()
+		case address.Family().IsDomain():
 			return nil, newError("unable to send through: " + address.String())
+		default:
+			senderSettings.Via = address.Build()
 		}
-		senderSettings.Via = address.Build()
+	}
+
+	switch strings.ToLower(c.SendStrategy) {
+	case "", "random":
+		senderSettings.ViaStrategy = proxyman.SenderConfig_Random
+	case "user", "userhash":
+		senderSettings.ViaStrategy = proxyman.SenderConfig_UserHash
+	case "destination", "destinationhash":
+		senderSettings.ViaStrategy = proxyman.SenderConfig_DestinationHash
+	default:
+		return nil, newError("unknown send through strategy: ", c.SendStrategy)
 	}
 
 	if c.StreamSetting != nil {
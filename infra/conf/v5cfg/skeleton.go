@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon"
+	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon/demuxcfg"
 	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon/muxcfg"
 	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon/proxycfg"
 	"github.com/v2fly/v2ray-core/v5/infra/conf/cfgcommon/sniffer"
@@ -28,11 +29,13 @@ type InboundConfig struct {
 	Tag            string                  `json:"tag"`
 	SniffingConfig *sniffer.SniffingConfig `json:"sniffing"`
 	StreamSetting  *StreamConfig           `json:"streamSettings"`
+	DemuxConfig    *demuxcfg.DemuxConfig   `json:"demux"`
 }
 
 type OutboundConfig struct {
 	Protocol      string                `json:"protocol"`
 	SendThrough   *cfgcommon.Address    `json:"sendThrough"`
+	SendStrategy  string                `json:"sendThroughStrategy"`
 	Tag           string                `json:"tag"`
 	Settings      json.RawMessage       `json:"settings"`
 	StreamSetting *StreamConfig         `json:"streamSettings"`
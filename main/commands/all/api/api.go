@@ -15,5 +15,9 @@ var CmdAPI = &base.Command{
 		cmdStats,
 		cmdBalancerInfo,
 		cmdBalancerOverride,
+		cmdListRules,
+		cmdRuleStats,
+		cmdObserve,
+		cmdDNSCache,
 	},
 }
@@ -78,6 +78,15 @@ func showBalancerInfo(b *routerService.BalancerMsg) {
 	for i, o := range b.PrincipleTarget.Tag {
 		writeRow(sb, tableIndent, i+1, []string{o}, nil)
 	}
+	// Circuits
+	if len(b.Circuit) > 0 {
+		sb.WriteString("  - Circuits:\n")
+		formats := []string{"%-20s ", "%-10s ", "%-10s ", "%s"}
+		writeRow(sb, tableIndent, 0, []string{"Outbound", "State", "Failures", "Last Error"}, formats)
+		for i, c := range b.Circuit {
+			writeRow(sb, tableIndent, i+1, []string{c.OutboundTag, c.State.String(), fmt.Sprint(c.Failures), c.LastErrorReason}, formats)
+		}
+	}
 	os.Stdout.WriteString(sb.String())
 }
 
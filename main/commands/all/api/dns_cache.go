@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	dnsService "github.com/v2fly/v2ray-core/v5/app/dns/command"
+	"github.com/v2fly/v2ray-core/v5/main/commands/base"
+)
+
+var cmdDNSCache = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api dnscache [--server=127.0.0.1:8080] [--flush] [domain]",
+	Short:       "inspect or flush DNS cache",
+	Long: `
+Print the records cached by the name servers of the DNS, or remove them 
+from the cache, for a domain or wholesale.
+
+> Make sure you have "DNSService" set in "config.api.services" 
+of server config.
+
+Arguments:
+
+	-flush
+		Remove the records instead of printing them.
+
+	-json
+		Use json output.
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout seconds to call API. Default 3
+
+Example:
+
+    {{.Exec}} {{.LongName}}
+    {{.Exec}} {{.LongName}} example.com
+    {{.Exec}} {{.LongName}} --flush example.com
+`,
+	Run: executeDNSCache,
+}
+
+func executeDNSCache(cmd *base.Command, args []string) {
+	var flush bool
+	cmd.Flag.BoolVar(&flush, "flush", false, "")
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	domain := cmd.Flag.Arg(0)
+	conn, ctx, close := dialAPIServer()
+	defer close()
+	client := dnsService.NewDNSServiceClient(conn)
+
+	if flush {
+		resp, err := client.FlushCache(ctx, &dnsService.FlushCacheRequest{Domain: domain})
+		if err != nil {
+			base.Fatalf("failed to flush DNS cache: %s", err)
+		}
+		if apiJSON {
+			showJSONResponse(resp)
+			return
+		}
+		fmt.Printf("%d domain(s) flushed\n", resp.Count)
+		return
+	}
+
+	resp, err := client.ListCache(ctx, &dnsService.ListCacheRequest{Domain: domain})
+	if err != nil {
+		base.Fatalf("failed to list DNS cache: %s", err)
+	}
+	if apiJSON {
+		showJSONResponse(resp)
+		return
+	}
+	showDNSCache(resp)
+}
+
+func showDNSCache(resp *dnsService.ListCacheResponse) {
+	now := time.Now()
+	for _, record := range resp.Record {
+		recordType := "A"
+		if record.Type == 28 {
+			recordType = "AAAA"
+		}
+		ips := make([]string, 0, len(record.Ip))
+		for _, ip := range record.Ip {
+			ips = append(ips, net.IP(ip).String())
+		}
+		ttl := time.Unix(record.Expire, 0).Sub(now).Truncate(time.Second)
+		line := fmt.Sprintf("%-24s %-32s %-4s %8s", record.Server, record.Domain, recordType, ttl)
+		if record.Rcode != 0 {
+			line += fmt.Sprintf(" rcode=%d", record.Rcode)
+		}
+		fmt.Println(line, ips)
+	}
+}
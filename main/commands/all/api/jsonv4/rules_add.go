@@ -0,0 +1,117 @@
+package jsonv4
+
+import (
+	"fmt"
+
+	routerService "github.com/v2fly/v2ray-core/v5/app/router/command"
+	"github.com/v2fly/v2ray-core/v5/main/commands/all/api"
+	"github.com/v2fly/v2ray-core/v5/main/commands/base"
+	"github.com/v2fly/v2ray-core/v5/main/commands/helpers"
+)
+
+var cmdAddRules = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api adrules [--server=127.0.0.1:8080] [-i index] [-replace] [c1.json] [dir1]...",
+	Short:       "add routing rules",
+	Long: `
+Add routing rules and balancers in the "routing" object of config 
+files to V2Ray, without restarting it.
+
+> Make sure you have "RoutingService" set in "config.api.services" 
+of server config.
+
+Arguments:
+
+	-format <format>
+		The input format.
+		Available values: "auto", "json", "toml", "yaml"
+		Default: "auto"
+
+	-r
+		Load folders recursively.
+
+	-i, -index <index>
+		Insert the rules before the rule at index, starting from 0. 
+		Default -1, which appends the rules.
+
+	-replace
+		Replace the rules with the same "ruleTag", and the balancers 
+		with the same tag, instead of adding them.
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout seconds to call API. Default 3
+
+Example:
+
+    {{.Exec}} {{.LongName}} -i 0 customer.json
+    {{.Exec}} {{.LongName}} -replace customer.json
+`,
+	Run: executeAddRules,
+}
+
+func executeAddRules(cmd *base.Command, args []string) {
+	var (
+		index   int
+		replace bool
+	)
+	api.SetSharedFlags(cmd)
+	api.SetSharedConfigFlags(cmd)
+	cmd.Flag.IntVar(&index, "i", -1, "")
+	cmd.Flag.IntVar(&index, "index", -1, "")
+	cmd.Flag.BoolVar(&replace, "replace", false, "")
+	cmd.Flag.Parse(args)
+	c, err := helpers.LoadConfig(cmd.Flag.Args(), api.APIConfigFormat, api.APIConfigRecursively)
+	if err != nil {
+		base.Fatalf("failed to load: %s", err)
+	}
+	if c.RouterConfig == nil {
+		base.Fatalf("no routing config found")
+	}
+	config, err := c.RouterConfig.Build()
+	if err != nil {
+		base.Fatalf("failed to build conf: %s", err)
+	}
+	if len(config.Rule) == 0 && len(config.BalancingRule) == 0 {
+		base.Fatalf("no valid rule found")
+	}
+
+	conn, ctx, close := api.DialAPIServer()
+	defer close()
+
+	client := routerService.NewRoutingServiceClient(conn)
+	// Balancers go first, as rules may point to them.
+	for _, balancer := range config.BalancingRule {
+		if replace {
+			fmt.Println("replacing balancer:", balancer.Tag)
+			_, err = client.ReplaceBalancingRule(ctx, &routerService.ReplaceBalancingRuleRequest{BalancingRule: balancer})
+		} else {
+			fmt.Println("adding balancer:", balancer.Tag)
+			_, err = client.AddBalancingRule(ctx, &routerService.AddBalancingRuleRequest{BalancingRule: balancer})
+		}
+		if err != nil {
+			base.Fatalf("failed to add balancer: %s", err)
+		}
+	}
+	for _, rule := range config.Rule {
+		if replace {
+			if rule.RuleTag == "" {
+				base.Fatalf("rule to replace has no ruleTag")
+			}
+			fmt.Println("replacing rule:", rule.RuleTag)
+			_, err = client.ReplaceRule(ctx, &routerService.ReplaceRuleRequest{RuleTag: rule.RuleTag, Rule: rule})
+		} else {
+			fmt.Println("adding rule:", rule.RuleTag)
+			_, err = client.AddRule(ctx, &routerService.AddRuleRequest{Rule: rule, Position: int32(index + 1)})
+			if index >= 0 {
+				// Keep the rules in the order of the config.
+				index++
+			}
+		}
+		if err != nil {
+			base.Fatalf("failed to add rule: %s", err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package jsonv4
+
+import (
+	"fmt"
+
+	routerService "github.com/v2fly/v2ray-core/v5/app/router/command"
+	"github.com/v2fly/v2ray-core/v5/main/commands/all/api"
+	"github.com/v2fly/v2ray-core/v5/main/commands/base"
+	"github.com/v2fly/v2ray-core/v5/main/commands/helpers"
+)
+
+var cmdRemoveRules = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api rmrules [--server=127.0.0.1:8080] [c1.json] [dir1]...",
+	Short:       "remove routing rules",
+	Long: `
+Remove routing rules and balancers from V2Ray. Rules are identified 
+by "ruleTag".
+
+> Make sure you have "RoutingService" set in "config.api.services" 
+of server config.
+
+Arguments:
+
+	-format <format>
+		The input format.
+		Available values: "auto", "json", "toml", "yaml"
+		Default: "auto"
+
+	-r
+		Load folders recursively.
+
+	-tags
+		The input are rule tags instead of config files
+
+	-balancers
+		The input are balancer tags instead of config files
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout seconds to call API. Default 3
+
+Example:
+
+    {{.Exec}} {{.LongName}} customer.json
+    {{.Exec}} {{.LongName}} -tags rule1 rule2
+    {{.Exec}} {{.LongName}} -balancers balancer1
+`,
+	Run: executeRemoveRules,
+}
+
+func executeRemoveRules(cmd *base.Command, args []string) {
+	api.SetSharedFlags(cmd)
+	api.SetSharedConfigFlags(cmd)
+	isTags := cmd.Flag.Bool("tags", false, "")
+	isBalancers := cmd.Flag.Bool("balancers", false, "")
+	cmd.Flag.Parse(args)
+
+	var ruleTags, balancerTags []string
+	switch {
+	case *isTags:
+		ruleTags = cmd.Flag.Args()
+	case *isBalancers:
+		balancerTags = cmd.Flag.Args()
+	default:
+		c, err := helpers.LoadConfig(cmd.Flag.Args(), api.APIConfigFormat, api.APIConfigRecursively)
+		if err != nil {
+			base.Fatalf("failed to load: %s", err)
+		}
+		if c.RouterConfig == nil {
+			base.Fatalf("no routing config found")
+		}
+		config, err := c.RouterConfig.Build()
+		if err != nil {
+			base.Fatalf("failed to build conf: %s", err)
+		}
+		for _, rule := range config.Rule {
+			if rule.RuleTag == "" {
+				base.Fatalf("rule to remove has no ruleTag")
+			}
+			ruleTags = append(ruleTags, rule.RuleTag)
+		}
+		for _, balancer := range config.BalancingRule {
+			balancerTags = append(balancerTags, balancer.Tag)
+		}
+	}
+	if len(ruleTags) == 0 && len(balancerTags) == 0 {
+		base.Fatalf("no rule to remove")
+	}
+
+	conn, ctx, close := api.DialAPIServer()
+	defer close()
+
+	client := routerService.NewRoutingServiceClient(conn)
+	// Rules go first, as they may point to the balancers.
+	for _, tag := range ruleTags {
+		fmt.Println("removing rule:", tag)
+		_, err := client.RemoveRule(ctx, &routerService.RemoveRuleRequest{RuleTag: tag})
+		if err != nil {
+			base.Fatalf("failed to remove rule: %s", err)
+		}
+	}
+	for _, tag := range balancerTags {
+		fmt.Println("removing balancer:", tag)
+		_, err := client.RemoveBalancingRule(ctx, &routerService.RemoveBalancingRuleRequest{Tag: tag})
+		if err != nil {
+			base.Fatalf("failed to remove balancer: %s", err)
+		}
+	}
+}
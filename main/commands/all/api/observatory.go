@@ -0,0 +1,101 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	observatoryService "github.com/v2fly/v2ray-core/v5/app/observatory/command"
+	"github.com/v2fly/v2ray-core/v5/main/commands/base"
+)
+
+var cmdObserve = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api observe [--server=127.0.0.1:8080] [--tag=observatory]",
+	Short:       "follow outbound status",
+	Long: `
+Follow and print the status of outbounds from the observatory, each time 
+an outbound turns alive or dead, its delay changes, or it fails with 
+another error.
+
+> Make sure you have "ObservatoryService" set in "config.api.services" 
+of server config.
+
+> It ignores -timeout flag while following status
+
+Arguments:
+
+	-tag <tag>
+		The tag of the observatory in a multi observatory.
+
+	-threshold <milliseconds>
+		Delay changes within the threshold are not printed. Default 0
+
+	-json
+		Use json output.
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+Example:
+
+    {{.Exec}} {{.LongName}}
+    {{.Exec}} {{.LongName}} --tag=burst --threshold=50
+`,
+	Run: executeObserve,
+}
+
+func executeObserve(cmd *base.Command, args []string) {
+	var (
+		tag       string
+		threshold int64
+	)
+	cmd.Flag.StringVar(&tag, "tag", "", "")
+	cmd.Flag.Int64Var(&threshold, "threshold", 0, "")
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServerWithoutTimeout()
+	defer close()
+	client := observatoryService.NewObservatoryServiceClient(conn)
+	stream, err := client.SubscribeOutboundStatus(ctx, &observatoryService.SubscribeOutboundStatusRequest{
+		Tag:            tag,
+		DelayThreshold: threshold,
+	})
+	if err != nil {
+		base.Fatalf("failed to follow outbound status: %s", err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			base.Fatalf("failed to fetch outbound status: %s", err)
+		}
+		if apiJSON {
+			showJSONResponse(resp)
+			continue
+		}
+		showOutboundStatusUpdate(resp)
+	}
+}
+
+func showOutboundStatusUpdate(resp *observatoryService.SubscribeOutboundStatusResponse) {
+	changes := make([]string, 0, len(resp.Change))
+	for _, c := range resp.Change {
+		changes = append(changes, c.String())
+	}
+	status := resp.Status
+	state := "dead"
+	if status.Alive {
+		state = fmt.Sprintf("alive %dms", status.Delay)
+	}
+	line := fmt.Sprintf("%s %-20s %-16s [%s]",
+		time.Now().Format("2006/01/02 15:04:05"), status.OutboundTag, state, strings.Join(changes, ","))
+	if status.LastErrorReason != "" {
+		line += " " + status.LastErrorReason
+	}
+	fmt.Println(line)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"os"
+	"strings"
+
+	routerService "github.com/v2fly/v2ray-core/v5/app/router/command"
+	"github.com/v2fly/v2ray-core/v5/main/commands/base"
+)
+
+var cmdListRules = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api lsrules [--server=127.0.0.1:8080]",
+	Short:       "list routing rules",
+	Long: `
+List routing rules in the order they are matched, and balancing rules.
+
+> Make sure you have "RoutingService" set in "config.api.services"
+of server config.
+
+Arguments:
+
+	-json
+		Use json output.
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout seconds to call API. Default 3
+
+Example:
+
+    {{.Exec}} {{.LongName}} --server=127.0.0.1:8080
+`,
+	Run: executeListRules,
+}
+
+func executeListRules(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := routerService.NewRoutingServiceClient(conn)
+	resp, err := client.ListRules(ctx, &routerService.ListRulesRequest{})
+	if err != nil {
+		base.Fatalf("failed to list rules: %s", err)
+	}
+
+	if apiJSON {
+		showJSONResponse(resp)
+		return
+	}
+
+	const tableIndent = 4
+	sb := new(strings.Builder)
+	sb.WriteString("  - Rules:\n")
+	titles := []string{"Tag", "Outbound", "Balancer"}
+	formats := []string{"%-20s ", "%-20s ", "%-20s "}
+	writeRow(sb, tableIndent, 0, titles, formats)
+	for i, rule := range resp.Rule {
+		writeRow(sb, tableIndent, i+1, []string{rule.RuleTag, rule.GetTag(), rule.GetBalancingTag()}, formats)
+	}
+	sb.WriteString("  - Balancers:\n")
+	titles = []string{"Tag", "Strategy", "Selectors"}
+	writeRow(sb, tableIndent, 0, titles, formats)
+	for i, rule := range resp.BalancingRule {
+		writeRow(sb, tableIndent, i+1, []string{rule.Tag, rule.Strategy, strings.Join(rule.OutboundSelector, ",")}, formats)
+	}
+	os.Stdout.WriteString(sb.String())
+}
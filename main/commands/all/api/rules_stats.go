@@ -0,0 +1,98 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	routerService "github.com/v2fly/v2ray-core/v5/app/router/command"
+	"github.com/v2fly/v2ray-core/v5/common/units"
+	"github.com/v2fly/v2ray-core/v5/main/commands/base"
+)
+
+var cmdRuleStats = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api rulestats [--server=127.0.0.1:8080] [ruleTag]...",
+	Short:       "routing rule statistics",
+	Long: `
+Query hit counts, last hit time and traffic of routing rules. If no
+rule tag specified, get statistics of all rules. Rules without a tag
+are identified by the ID assigned when they are created.
+
+> Make sure you have "RoutingService" set in "config.api.services"
+of server config, and "statsRuleHit", "statsRuleUplink" or
+"statsRuleDownlink" enabled in "config.policy.system".
+
+Arguments:
+
+	-reset
+		Reset counters but the last hit time to 0 after fetching their
+		values.
+
+	-json
+		Use json output.
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout seconds to call API. Default 3
+
+Example:
+
+    {{.Exec}} {{.LongName}} --server=127.0.0.1:8080
+    {{.Exec}} {{.LongName}} -reset rule1 rule2
+`,
+	Run: executeRuleStats,
+}
+
+func executeRuleStats(cmd *base.Command, args []string) {
+	var reset bool
+	setSharedFlags(cmd)
+	cmd.Flag.BoolVar(&reset, "reset", false, "")
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := routerService.NewRoutingServiceClient(conn)
+	r := &routerService.GetRuleStatsRequest{
+		RuleTag: cmd.Flag.Args(),
+		Reset_:  reset,
+	}
+	resp, err := client.GetRuleStats(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to get rule stats: %s", err)
+	}
+
+	if apiJSON {
+		showJSONResponse(resp)
+		return
+	}
+
+	formats := []string{"%-6s ", "%-20s ", "%-10s ", "%-20s ", "%-12s ", "%-12s "}
+	sb := new(strings.Builder)
+	writeRow(sb, 0, 0,
+		[]string{"ID", "Rule", "Hits", "Last Hit", "Uplink", "Downlink"},
+		formats,
+	)
+	for i, s := range resp.Stats {
+		lastHit := "-"
+		if s.LastHit > 0 {
+			lastHit = time.Unix(s.LastHit, 0).Format("2006-01-02 15:04:05")
+		}
+		writeRow(sb, 0, i+1,
+			[]string{
+				fmt.Sprintf("%d", s.RuleId),
+				s.RuleTag,
+				fmt.Sprintf("%d", s.Hits),
+				lastHit,
+				units.ByteSize(s.Uplink).String(),
+				units.ByteSize(s.Downlink).String(),
+			},
+			formats,
+		)
+	}
+	os.Stdout.WriteString(sb.String())
+}
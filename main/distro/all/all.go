@@ -15,6 +15,7 @@ import (
 	_ "github.com/v2fly/v2ray-core/v5/app/stats/command"
 
 	// Developer preview services
+	_ "github.com/v2fly/v2ray-core/v5/app/dns/command"
 	_ "github.com/v2fly/v2ray-core/v5/app/instman/command"
 	_ "github.com/v2fly/v2ray-core/v5/app/observatory/command"
 
@@ -33,6 +34,8 @@ import (
 	// Developer preview features
 	_ "github.com/v2fly/v2ray-core/v5/app/instman"
 	_ "github.com/v2fly/v2ray-core/v5/app/observatory"
+	_ "github.com/v2fly/v2ray-core/v5/app/observatory/circuitbreaker"
+	_ "github.com/v2fly/v2ray-core/v5/app/persistentstorage/filesystemstorage"
 	_ "github.com/v2fly/v2ray-core/v5/app/restfulapi"
 
 	// Inbound and outbound proxies.
@@ -75,6 +78,8 @@ import (
 
 	// Geo loaders
 	_ "github.com/v2fly/v2ray-core/v5/infra/conf/geodata/memconservative"
+	_ "github.com/v2fly/v2ray-core/v5/infra/conf/geodata/mmdb"
+	_ "github.com/v2fly/v2ray-core/v5/infra/conf/geodata/srs"
 	_ "github.com/v2fly/v2ray-core/v5/infra/conf/geodata/standard"
 
 	// JSON, TOML, YAML config support. (jsonv4) This disable selective compile
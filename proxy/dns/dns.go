@@ -49,6 +49,7 @@ type Handler struct {
 	client          dns.Client
 	ipv4Lookup      dns.IPv4Lookup
 	ipv6Lookup      dns.IPv6Lookup
+	recordLookup    dns.RecordLookup
 	ownLinkVerifier ownLinkVerifier
 	server          net.Destination
 	timeout         time.Duration
@@ -70,6 +71,10 @@ func (h *Handler) Init(config *Config, dnsClient dns.Client, policyManager polic
 		return newError("dns.Client doesn't implement IPv6Lookup")
 	}
 
+	if recordLookup, ok := dnsClient.(dns.RecordLookup); ok {
+		h.recordLookup = recordLookup
+	}
+
 	if v, ok := dnsClient.(ownLinkVerifier); ok {
 		h.ownLinkVerifier = v
 	}
@@ -84,6 +89,20 @@ func (h *Handler) isOwnLink(ctx context.Context) bool {
 	return h.ownLinkVerifier != nil && h.ownLinkVerifier.IsOwnLink(ctx)
 }
 
+const (
+	typeSVCB  dnsmessage.Type = 64
+	typeHTTPS dnsmessage.Type = 65
+)
+
+// isRecordQuery tells whether a query of qType is answered by the DNS client, besides A and AAAA queries.
+func isRecordQuery(qType dnsmessage.Type) bool {
+	switch qType {
+	case typeHTTPS, typeSVCB, dnsmessage.TypeMX, dnsmessage.TypeTXT, dnsmessage.TypeSRV, dnsmessage.TypeCNAME, dnsmessage.TypePTR:
+		return true
+	}
+	return false
+}
+
 func parseIPQuery(b []byte) (r bool, domain string, id uint16, qType dnsmessage.Type) {
 	var parser dnsmessage.Parser
 	header, err := parser.Start(b)
@@ -193,6 +212,12 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, d internet.
 					go h.handleIPQuery(id, qType, domain, writer)
 					continue
 				}
+				if h.recordLookup != nil && isRecordQuery(qType) {
+					query := append([]byte(nil), b.Bytes()...)
+					b.Release()
+					go h.handleRecordQuery(ctx, query, writer, srcNetwork != net.Network_TCP)
+					continue
+				}
 			}
 
 			if err := connWriter.WriteMessage(b); err != nil {
@@ -296,6 +321,25 @@ func (h *Handler) handleIPQuery(id uint16, qType dnsmessage.Type, domain string,
 	}
 }
 
+func (h *Handler) handleRecordQuery(ctx context.Context, query []byte, writer dns_proto.MessageWriter, udp bool) {
+	resp, err := h.recordLookup.LookupRecord(ctx, query)
+	if err != nil {
+		newError("record query").Base(err).WriteToLog(session.ExportIDToError(ctx))
+		return
+	}
+	resp, err = dns_proto.TruncateMessage(query, resp, udp)
+	if err != nil {
+		newError("truncate record answer").Base(err).WriteToLog(session.ExportIDToError(ctx))
+		return
+	}
+
+	b := buf.New()
+	common.Must2(b.Write(resp))
+	if err := writer.WriteMessage(b); err != nil {
+		newError("write record answer").Base(err).WriteToLog(session.ExportIDToError(ctx))
+	}
+}
+
 type outboundConn struct {
 	access sync.Mutex
 	dialer func() (internet.Connection, error)
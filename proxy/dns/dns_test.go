@@ -1,6 +1,11 @@
 package dns_test
 
 import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"io"
+	"net/http"
 	"strconv"
 	"testing"
 	"time"
@@ -16,13 +21,18 @@ import (
 	"github.com/v2fly/v2ray-core/v5/app/proxyman"
 	_ "github.com/v2fly/v2ray-core/v5/app/proxyman/inbound"
 	_ "github.com/v2fly/v2ray-core/v5/app/proxyman/outbound"
+	"github.com/v2fly/v2ray-core/v5/app/stats"
 	"github.com/v2fly/v2ray-core/v5/common"
 	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/protocol/tls/cert"
 	"github.com/v2fly/v2ray-core/v5/common/serial"
 	dns_proxy "github.com/v2fly/v2ray-core/v5/proxy/dns"
 	"github.com/v2fly/v2ray-core/v5/proxy/dokodemo"
+	"github.com/v2fly/v2ray-core/v5/proxy/freedom"
 	"github.com/v2fly/v2ray-core/v5/testing/servers/tcp"
 	"github.com/v2fly/v2ray-core/v5/testing/servers/udp"
+	"github.com/v2fly/v2ray-core/v5/transport/internet"
+	v2tls "github.com/v2fly/v2ray-core/v5/transport/internet/tls"
 )
 
 type staticHandler struct{}
@@ -368,3 +378,275 @@ func TestUDP2TCPDNSTunnel(t *testing.T) {
 		t.Error(r)
 	}
 }
+
+func TestDNSServer(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+	defer dnsServer.Shutdown()
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	serverPort := udp.PickPort()
+	dohPort := tcp.PickPort()
+	config := &core.Config{
+		App: []*anypb.Any{
+			serial.ToTypedMessage(&dnsapp.Config{
+				NameServers: []*net.Endpoint{
+					{
+						Network: net.Network_UDP,
+						Address: &net.IPOrDomain{
+							Address: &net.IPOrDomain_Ip{
+								Ip: []byte{127, 0, 0, 1},
+							},
+						},
+						Port: uint32(port),
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Inbound: []*core.InboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&dns_proxy.ServerConfig{}),
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: net.SinglePortRange(serverPort),
+					Listen:    net.NewIPOrDomain(net.LocalHostIP),
+				}),
+			},
+			{
+				ProxySettings: serial.ToTypedMessage(&dns_proxy.ServerConfig{
+					DohPath: "/dns-query",
+				}),
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: net.SinglePortRange(dohPort),
+					Listen:    net.NewIPOrDomain(net.LocalHostIP),
+				}),
+			},
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+	common.Must(v.Start())
+	defer v.Close()
+
+	checkAnswer := func(in *dns.Msg, ip net.IP) {
+		t.Helper()
+		if len(in.Answer) != 1 {
+			t.Fatal("len(answer): ", len(in.Answer))
+		}
+		rr, ok := in.Answer[0].(*dns.A)
+		if !ok {
+			t.Fatal("not A record")
+		}
+		if r := cmp.Diff(rr.A[:], ip); r != "" {
+			t.Error(r)
+		}
+	}
+
+	for _, network := range []string{"udp", "tcp"} {
+		m1 := new(dns.Msg)
+		m1.SetQuestion("google.com.", dns.TypeA)
+
+		c := &dns.Client{Net: network}
+		in, _, err := c.Exchange(m1, "127.0.0.1:"+strconv.Itoa(int(serverPort)))
+		common.Must(err)
+		checkAnswer(in, net.IP{8, 8, 8, 8})
+	}
+
+	{
+		m1 := new(dns.Msg)
+		m1.SetQuestion("notexist.google.com.", dns.TypeAAAA)
+
+		c := new(dns.Client)
+		in, _, err := c.Exchange(m1, "127.0.0.1:"+strconv.Itoa(int(serverPort)))
+		common.Must(err)
+		if in.Rcode != dns.RcodeNameError {
+			t.Error("expected NameError, but got ", in.Rcode)
+		}
+	}
+
+	{
+		// A query whose question is cut short.
+		conn, err := net.Dial("udp", "127.0.0.1:"+serverPort.String())
+		common.Must(err)
+		common.Must2(conn.Write([]byte{0x12, 0x34, 0x01, 0x00, 0, 1, 0, 0, 0, 0, 0, 0, 3, 'a', 'b'}))
+		common.Must(conn.SetReadDeadline(time.Now().Add(time.Second * 5)))
+		b := make([]byte, 512)
+		n, err := conn.Read(b)
+		common.Must(err)
+		conn.Close()
+
+		in := new(dns.Msg)
+		common.Must(in.Unpack(b[:n]))
+		if in.Id != 0x1234 || in.Rcode != dns.RcodeFormatError {
+			t.Error("expected format error, but got ", in)
+		}
+	}
+
+	dohURL := "http://127.0.0.1:" + dohPort.String() + "/dns-query"
+	{
+		m1 := new(dns.Msg)
+		m1.SetQuestion("facebook.com.", dns.TypeA)
+		query, err := m1.Pack()
+		common.Must(err)
+
+		resp, err := http.Post(dohURL, "application/dns-message", bytes.NewReader(query))
+		common.Must(err)
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		common.Must(err)
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/dns-message" {
+			t.Fatal("unexpected response: ", resp.Status, " ", resp.Header)
+		}
+
+		in := new(dns.Msg)
+		common.Must(in.Unpack(b))
+		checkAnswer(in, net.IP{9, 9, 9, 9})
+	}
+
+	{
+		m1 := new(dns.Msg)
+		m1.SetQuestion("google.com.", dns.TypeA)
+		query, err := m1.Pack()
+		common.Must(err)
+
+		resp, err := http.Get(dohURL + "?dns=" + base64.RawURLEncoding.EncodeToString(query))
+		common.Must(err)
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		common.Must(err)
+
+		in := new(dns.Msg)
+		common.Must(in.Unpack(b))
+		checkAnswer(in, net.IP{8, 8, 8, 8})
+	}
+
+	{
+		resp, err := http.Get("http://127.0.0.1:" + dohPort.String() + "/resolve?name=google.com")
+		common.Must(err)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Error("expected not found, but got ", resp.Status)
+		}
+	}
+}
+
+func TestDNSServerDoHOverTLSWithStats(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+	defer dnsServer.Shutdown()
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	dohPort := tcp.PickPort()
+	config := &core.Config{
+		App: []*anypb.Any{
+			serial.ToTypedMessage(&dnsapp.Config{
+				NameServers: []*net.Endpoint{
+					{
+						Network: net.Network_UDP,
+						Address: &net.IPOrDomain{
+							Address: &net.IPOrDomain_Ip{
+								Ip: []byte{127, 0, 0, 1},
+							},
+						},
+						Port: uint32(port),
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&stats.Config{}),
+			serial.ToTypedMessage(&policy.Config{
+				System: &policy.SystemPolicy{
+					Stats: &policy.SystemPolicy_Stats{
+						InboundUplink:   true,
+						InboundDownlink: true,
+					},
+				},
+			}),
+		},
+		Inbound: []*core.InboundHandlerConfig{
+			{
+				Tag: "doh",
+				ProxySettings: serial.ToTypedMessage(&dns_proxy.ServerConfig{
+					DohPath: "/dns-query",
+				}),
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: net.SinglePortRange(dohPort),
+					Listen:    net.NewIPOrDomain(net.LocalHostIP),
+					StreamSettings: &internet.StreamConfig{
+						SecurityType: serial.GetMessageType(&v2tls.Config{}),
+						SecuritySettings: []*anypb.Any{
+							serial.ToTypedMessage(&v2tls.Config{
+								Certificate: []*v2tls.Certificate{v2tls.ParseCertificate(cert.MustGenerate(nil))},
+							}),
+						},
+					},
+				}),
+			},
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+	common.Must(v.Start())
+	defer v.Close()
+
+	m1 := new(dns.Msg)
+	m1.SetQuestion("facebook.com.", dns.TypeA)
+	query, err := m1.Pack()
+	common.Must(err)
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			ForceAttemptHTTP2: true,
+		},
+		Timeout: time.Second * 5,
+	}
+	defer client.CloseIdleConnections()
+	resp, err := client.Post("https://127.0.0.1:"+dohPort.String()+"/dns-query", "application/dns-message", bytes.NewReader(query))
+	common.Must(err)
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	common.Must(err)
+	if resp.ProtoMajor != 2 || resp.StatusCode != http.StatusOK {
+		t.Fatal("unexpected response: ", resp.Proto, " ", resp.Status)
+	}
+
+	in := new(dns.Msg)
+	common.Must(in.Unpack(b))
+	if len(in.Answer) != 1 || in.Answer[0].(*dns.A).A.String() != "9.9.9.9" {
+		t.Error("unexpected answer: ", in)
+	}
+}
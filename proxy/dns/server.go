@@ -0,0 +1,327 @@
+package dns
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/net/http2"
+
+	core "github.com/v2fly/v2ray-core/v5"
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/buf"
+	"github.com/v2fly/v2ray-core/v5/common/errors"
+	"github.com/v2fly/v2ray-core/v5/common/log"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/common/protocol"
+	dns_proto "github.com/v2fly/v2ray-core/v5/common/protocol/dns"
+	"github.com/v2fly/v2ray-core/v5/common/session"
+	"github.com/v2fly/v2ray-core/v5/common/signal"
+	"github.com/v2fly/v2ray-core/v5/common/task"
+	"github.com/v2fly/v2ray-core/v5/features/dns"
+	"github.com/v2fly/v2ray-core/v5/features/policy"
+	"github.com/v2fly/v2ray-core/v5/features/routing"
+	"github.com/v2fly/v2ray-core/v5/transport/internet"
+	"github.com/v2fly/v2ray-core/v5/transport/internet/tls"
+)
+
+func init() {
+	common.Must(common.RegisterConfig((*ServerConfig)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return NewServer(ctx, config.(*ServerConfig))
+	}))
+
+	common.Must(common.RegisterConfig((*SimplifiedServerConfig)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		simplifiedServer := config.(*SimplifiedServerConfig)
+		fullServer := &ServerConfig{
+			DohPath: simplifiedServer.DohPath,
+		}
+		return common.CreateObject(ctx, fullServer)
+	}))
+}
+
+// Server is a DNS inbound handler, answering the queries of its clients with the DNS client.
+type Server struct {
+	config        *ServerConfig
+	recordLookup  dns.RecordLookup
+	policyManager policy.Manager
+}
+
+// NewServer creates a new DNS inbound handler.
+func NewServer(ctx context.Context, config *ServerConfig) (*Server, error) {
+	s := &Server{
+		config: config,
+	}
+	if err := core.RequireFeatures(ctx, func(dnsClient dns.Client, policyManager policy.Manager) error {
+		recordLookup, ok := dnsClient.(dns.RecordLookup)
+		if !ok {
+			return newError("dns.Client doesn't implement RecordLookup")
+		}
+		s.recordLookup = recordLookup
+		s.policyManager = policyManager
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Network implements proxy.Inbound.
+func (*Server) Network() []net.Network {
+	return []net.Network{net.Network_TCP, net.Network_UDP}
+}
+
+// Process implements proxy.Inbound.
+func (s *Server) Process(ctx context.Context, network net.Network, conn internet.Connection, dispatcher routing.Dispatcher) error {
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		inbound.User = &protocol.MemoryUser{
+			Level: s.config.UserLevel,
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	timer := signal.CancelAfterInactivity(ctx, cancel, s.policyManager.ForLevel(s.config.UserLevel).Timeouts.ConnectionIdle)
+
+	serve := func() error {
+		switch {
+		case network == net.Network_UDP:
+			reader := &dns_proto.UDPReader{Reader: buf.NewPacketReader(conn)}
+			writer := &dns_proto.UDPWriter{Writer: &buf.SequentialWriter{Writer: conn}}
+			return s.serveMessages(ctx, reader, writer, true, timer)
+		case s.config.DohPath != "":
+			return s.serveDoH(ctx, conn, timer)
+		default:
+			reader := dns_proto.NewTCPReader(buf.NewReader(conn))
+			writer := &dns_proto.TCPWriter{Writer: buf.NewWriter(conn)}
+			return s.serveMessages(ctx, reader, writer, false, timer)
+		}
+	}
+
+	if err := task.Run(ctx, serve); err != nil {
+		return newError("connection ends").Base(err)
+	}
+	return nil
+}
+
+// answer resolves query with the DNS client, and logs it to the access log. A malformed query is answered with a
+// format error, and a failed lookup with a server failure, along with the error, unless query has no header to reply
+// to.
+func (s *Server) answer(ctx context.Context, query []byte, udp bool) ([]byte, error) {
+	var parser dnsmessage.Parser
+	if _, err := parser.Start(query); err != nil {
+		return nil, newError("failed to parse query").Base(err)
+	}
+	q, err := parser.Question()
+	if err != nil {
+		return errorResponse(query, dnsmessage.RCodeFormatError), newError("failed to parse question").Base(err)
+	}
+
+	resp, err := s.recordLookup.LookupRecord(ctx, query)
+	if err != nil {
+		return errorResponse(query, dnsmessage.RCodeServerFailure), newError("failed to lookup ", q.Type, " records for domain ", q.Name.String()).Base(err)
+	}
+	header, err := parser.Start(resp)
+	if err != nil {
+		return errorResponse(query, dnsmessage.RCodeServerFailure), newError("failed to parse answer").Base(err)
+	}
+
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		msg := &log.AccessMessage{
+			From:   inbound.Source,
+			To:     q.Name.String() + " " + q.Type.String(),
+			Status: log.AccessAccepted,
+			Reason: "",
+		}
+		if header.RCode != dnsmessage.RCodeSuccess {
+			msg.Reason = header.RCode
+		}
+		log.Record(msg)
+	}
+
+	resp, err = dns_proto.TruncateMessage(query, resp, udp)
+	if err != nil {
+		return errorResponse(query, dnsmessage.RCodeServerFailure), err
+	}
+	return resp, nil
+}
+
+// errorResponse returns a response to query with rcode and its question if any, or nil if query has no header.
+func errorResponse(query []byte, rcode dnsmessage.RCode) []byte {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		return nil
+	}
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:               header.ID,
+			Response:         true,
+			OpCode:           header.OpCode,
+			RecursionDesired: header.RecursionDesired,
+			RCode:            rcode,
+		},
+	}
+	if q, err := parser.Question(); err == nil {
+		msg.Questions = []dnsmessage.Question{q}
+	}
+	b, err := msg.Pack()
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// serveMessages answers the queries of DNS over UDP, TCP or TLS, concurrently.
+func (s *Server) serveMessages(ctx context.Context, reader dns_proto.MessageReader, writer dns_proto.MessageWriter, udp bool, timer signal.ActivityUpdater) error {
+	var access sync.Mutex
+	for {
+		b, err := reader.ReadMessage()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		timer.Update()
+
+		query := append([]byte(nil), b.Bytes()...)
+		b.Release()
+		go func() {
+			resp, err := s.answer(ctx, query, udp)
+			if err != nil {
+				newError("failed to answer query").Base(err).WriteToLog(session.ExportIDToError(ctx))
+			}
+			if resp == nil {
+				return
+			}
+			b := buf.New()
+			common.Must2(b.Write(resp))
+
+			access.Lock()
+			defer access.Unlock()
+			if err := writer.WriteMessage(b); err != nil {
+				newError("failed to write answer").Base(err).WriteToLog(session.ExportIDToError(ctx))
+			}
+		}()
+	}
+}
+
+// serveDoH answers the queries of DNS over HTTPS, with HTTP/2 if negotiated over TLS, or HTTP/1.1 otherwise.
+func (s *Server) serveDoH(ctx context.Context, conn internet.Connection, timer signal.ActivityUpdater) error {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timer.Update()
+		s.handleDoH(ctx, w, r)
+	})
+
+	iConn := conn
+	if statConn, ok := iConn.(*internet.StatCouterConnection); ok {
+		iConn = statConn.Connection
+	}
+	if tlsConn, ok := iConn.(*tls.Conn); ok {
+		if err := tlsConn.Handshake(); err != nil {
+			return newError("failed to complete TLS handshake").Base(err)
+		}
+		if tlsConn.ConnectionState().NegotiatedProtocol == http2.NextProtoTLS {
+			(&http2.Server{}).ServeConn(conn, &http2.ServeConnOpts{
+				Context: ctx,
+				Handler: handler,
+			})
+			return nil
+		}
+	}
+
+	reader := bufio.NewReaderSize(conn, buf.Size)
+	for {
+		request, err := http.ReadRequest(reader)
+		if err != nil {
+			if errors.Cause(err) == io.EOF {
+				return nil
+			}
+			return newError("failed to read http request").Base(err)
+		}
+
+		w := &responseWriter{header: make(http.Header), status: http.StatusOK}
+		handler.ServeHTTP(w, request)
+		response := &http.Response{
+			StatusCode:    w.status,
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        w.header,
+			Body:          io.NopCloser(&w.body),
+			ContentLength: int64(w.body.Len()),
+			Close:         request.Close,
+		}
+		if err := response.Write(conn); err != nil {
+			return newError("failed to write http response").Base(err)
+		}
+		if request.Close {
+			return nil
+		}
+	}
+}
+
+// handleDoH answers the DNS over HTTPS query in r, as specified by RFC 8484.
+func (s *Server) handleDoH(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != s.config.DohPath {
+		http.NotFound(w, r)
+		return
+	}
+
+	var query []byte
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		query, err = base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+	case http.MethodPost:
+		if r.Header.Get("Content-Type") != "application/dns-message" {
+			http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+			return
+		}
+		query, err = io.ReadAll(io.LimitReader(r.Body, buf.Size))
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil || len(query) == 0 {
+		http.Error(w, "invalid query", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.answer(ctx, query, false)
+	if err != nil {
+		newError("failed to answer query").Base(err).WriteToLog(session.ExportIDToError(ctx))
+	}
+	if resp == nil {
+		http.Error(w, "invalid query", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/dns-message")
+	w.Header().Set("Content-Length", strconv.Itoa(len(resp)))
+	if _, err := w.Write(resp); err != nil {
+		newError("failed to write answer").Base(err).WriteToLog(session.ExportIDToError(ctx))
+	}
+}
+
+// responseWriter buffers the response to an HTTP/1.1 request.
+type responseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.status = status
+}
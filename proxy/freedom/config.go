@@ -1,5 +1,23 @@
 package freedom
 
+import "github.com/v2fly/v2ray-core/v5/transport/internet"
+
 func (c *Config) useIP() bool {
-	return c.DomainStrategy == Config_USE_IP || c.DomainStrategy == Config_USE_IP4 || c.DomainStrategy == Config_USE_IP6
+	return c.DomainStrategy != Config_AS_IS || c.HappyEyeballs.GetEnabled()
+}
+
+// socketDomainStrategy converts the domain strategy to its transport counterpart.
+func (c *Config) socketDomainStrategy() internet.SocketConfig_DomainStrategy {
+	switch c.DomainStrategy {
+	case Config_USE_IP4:
+		return internet.SocketConfig_USE_IP4
+	case Config_USE_IP6:
+		return internet.SocketConfig_USE_IP6
+	case Config_PREFER_IP4:
+		return internet.SocketConfig_PREFER_IP4
+	case Config_PREFER_IP6:
+		return internet.SocketConfig_PREFER_IP6
+	default:
+		return internet.SocketConfig_USE_IP
+	}
 }
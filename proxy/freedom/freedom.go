@@ -65,7 +65,7 @@ func (h *Handler) policy() policy.Session {
 	return p
 }
 
-func (h *Handler) resolveIP(ctx context.Context, domain string, localAddr net.Address) net.Address {
+func (h *Handler) lookupIPs(ctx context.Context, domain string, localAddr net.Address) []net.IP {
 	if c, ok := h.dns.(dns.ClientWithIPOption); ok {
 		c.SetFakeDNSOption(false) // Skip FakeDNS
 	} else {
@@ -87,9 +87,25 @@ func (h *Handler) resolveIP(ctx context.Context, domain string, localAddr net.Ad
 	if err != nil {
 		newError("failed to get IP address for domain ", domain).Base(err).WriteToLog(session.ExportIDToError(ctx))
 	}
+	return internet.SortIPs(ips, h.config.socketDomainStrategy())
+}
+
+func (h *Handler) resolveIP(ctx context.Context, domain string, localAddr net.Address) net.Address {
+	ips := h.lookupIPs(ctx, domain, localAddr)
 	if len(ips) == 0 {
 		return nil
 	}
+	switch h.config.DomainStrategy {
+	case Config_PREFER_IP4, Config_PREFER_IP6:
+		// Sorted addresses start with the preferred family. Pick among the addresses of that family only.
+		var preferred []net.IP
+		for _, ip := range ips {
+			if (ip.To4() != nil) == (ips[0].To4() != nil) {
+				preferred = append(preferred, ip)
+			}
+		}
+		ips = preferred
+	}
 	return net.IPAddress(ips[dice.Roll(len(ips))])
 }
 
@@ -102,6 +118,11 @@ func isValidAddress(addr *net.IPOrDomain) bool {
 	return a != net.AnyIP
 }
 
+// DialsDestination implements proxy.DestinationDialer.
+func (h *Handler) DialsDestination() bool {
+	return true
+}
+
 // Process implements proxy.Outbound.
 func (h *Handler) Process(ctx context.Context, link *transport.Link, dialer internet.Dialer) error {
 	outbound := session.OutboundFromContext(ctx)
@@ -130,6 +151,19 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, dialer inte
 	fn := func() error {
 		return retry.ExponentialBackoff(5, 100).On(func() error {
 			dialDest := destination
+			if h.config.HappyEyeballs.GetEnabled() && dialDest.Network == net.Network_TCP && dialDest.Address.Family().IsDomain() {
+				ips := h.lookupIPs(ctx, dialDest.Address.Domain(), dialer.Address())
+				if len(ips) > 0 {
+					rawConn, err := internet.DialHappyEyeballs(ctx, ips, h.config.HappyEyeballs.GetTryDelayValue(), func(ctx context.Context, ip net.IP) (net.Conn, error) {
+						return dialer.Dial(ctx, net.TCPDestination(net.IPAddress(ip), dialDest.Port))
+					})
+					if err != nil {
+						return err
+					}
+					conn = rawConn
+					return nil
+				}
+			}
 			if h.config.useIP() && dialDest.Address.Family().IsDomain() {
 				ip := h.resolveIP(ctx, dialDest.Address.Domain(), dialer.Address())
 				if ip != nil {
@@ -34,6 +34,14 @@ type Outbound interface {
 	Process(context.Context, *transport.Link, internet.Dialer) error
 }
 
+// DestinationDialer is an Outbound that may connect to the destination of connections itself, rather than to a server.
+type DestinationDialer interface {
+	Outbound
+
+	// DialsDestination returns whether the connections dialed by the Outbound are to the destination.
+	DialsDestination() bool
+}
+
 // UserManager is the interface for Inbounds and Outbounds that can manage their users.
 type UserManager interface {
 	// AddUser adds a new user.
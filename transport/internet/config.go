@@ -2,6 +2,7 @@ package internet
 
 import (
 	"context"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 
@@ -133,6 +134,18 @@ func (m SocketConfig_TProxyMode) IsEnabled() bool {
 	return m != SocketConfig_Off
 }
 
+// ResolvesDomain returns true if the system dialer should resolve domain destinations by itself.
+func (c *SocketConfig) ResolvesDomain() bool {
+	return c != nil && (c.DomainStrategy != SocketConfig_AS_IS || c.HappyEyeballs.GetEnabled())
+}
+
+func (c *HappyEyeballsConfig) GetTryDelayValue() time.Duration {
+	if c == nil || c.TryDelay == 0 {
+		return 250 * time.Millisecond
+	}
+	return time.Duration(c.TryDelay) * time.Millisecond
+}
+
 func getOriginalMessageName(streamSettings *MemoryStreamConfig) string {
 	msgOpts, err := protoext.GetMessageOptions(proto.MessageV2(streamSettings.ProtocolSettings).ProtoReflect().Descriptor())
 	if err == nil {
@@ -25,3 +25,32 @@ func TestDialWithLocalAddr(t *testing.T) {
 	}
 	conn.Close()
 }
+
+type staticDNSClient struct {
+	ips []net.IP
+}
+
+func (*staticDNSClient) Type() interface{} { return nil }
+func (*staticDNSClient) Start() error      { return nil }
+func (*staticDNSClient) Close() error      { return nil }
+
+func (c *staticDNSClient) LookupIP(domain string) ([]net.IP, error) {
+	return c.ips, nil
+}
+
+func TestDialWithDNSClient(t *testing.T) {
+	server := &tcp.Server{}
+	dest, err := server.Start()
+	common.Must(err)
+	defer server.Close()
+
+	ctx := ContextWithDNSClient(context.Background(), &staticDNSClient{ips: []net.IP{net.LocalHostIP.IP()}})
+	conn, err := DialSystem(ctx, net.TCPDestination(net.DomainAddress("v2fly.invalid"), dest.Port), &SocketConfig{
+		DomainStrategy: SocketConfig_USE_IP,
+	})
+	common.Must(err)
+	if r := cmp.Diff(conn.RemoteAddr().String(), "127.0.0.1:"+dest.Port.String()); r != "" {
+		t.Error(r)
+	}
+	conn.Close()
+}
@@ -0,0 +1,133 @@
+package internet
+
+import (
+	"context"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v5/common/net"
+)
+
+// SortIPs returns the addresses usable under the given domain strategy, in the order they should be tried.
+// When both families are usable, they are interleaved as recommended by RFC 8305 section 4,
+// starting with the preferred family, or with the family of the first address if there is no preference.
+func SortIPs(ips []net.IP, strategy SocketConfig_DomainStrategy) []net.IP {
+	var ip4s, ip6s []net.IP
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			ip4s = append(ip4s, ip)
+		} else {
+			ip6s = append(ip6s, ip)
+		}
+	}
+
+	var first, second []net.IP
+	switch strategy {
+	case SocketConfig_USE_IP4:
+		return ip4s
+	case SocketConfig_USE_IP6:
+		return ip6s
+	case SocketConfig_PREFER_IP4:
+		first, second = ip4s, ip6s
+	case SocketConfig_PREFER_IP6:
+		first, second = ip6s, ip4s
+	default:
+		if len(ips) > 0 && ips[0].To4() == nil {
+			first, second = ip6s, ip4s
+		} else {
+			first, second = ip4s, ip6s
+		}
+	}
+
+	sorted := make([]net.IP, 0, len(first)+len(second))
+	for i := 0; i < len(first) || i < len(second); i++ {
+		if i < len(first) {
+			sorted = append(sorted, first[i])
+		}
+		if i < len(second) {
+			sorted = append(sorted, second[i])
+		}
+	}
+	return sorted
+}
+
+type happyEyeballsResult struct {
+	index int
+	conn  net.Conn
+	err   error
+}
+
+// DialHappyEyeballs races connection attempts to the given addresses as described in RFC 8305.
+// A new attempt is started every delay, or as soon as the previous attempt fails.
+// The first established connection is returned and all other attempts are cancelled.
+// Each attempt is dialed with its own context, which is never cancelled for the winner, as the context may outlive
+// the dial and tie the lifetime of the connection.
+func DialHappyEyeballs(ctx context.Context, ips []net.IP, delay time.Duration, dial func(ctx context.Context, ip net.IP) (net.Conn, error)) (net.Conn, error) {
+	if len(ips) == 0 {
+		return nil, newError("no address to dial")
+	}
+	if len(ips) == 1 {
+		return dial(ctx, ips[0])
+	}
+
+	// Results are buffered so that late attempts never block after a winner is chosen.
+	results := make(chan happyEyeballsResult, len(ips))
+	cancels := make([]context.CancelFunc, 0, len(ips))
+	cancelLosers := func(winner int) {
+		for i, cancel := range cancels {
+			if i != winner {
+				cancel()
+			}
+		}
+	}
+	var nextAttempt <-chan time.Time
+
+	pending := 0
+	start := func() {
+		index := len(cancels)
+		ip := ips[index]
+		attemptCtx, cancel := context.WithCancel(ctx)
+		cancels = append(cancels, cancel)
+		pending++
+		go func() {
+			conn, err := dial(attemptCtx, ip)
+			results <- happyEyeballsResult{index: index, conn: conn, err: err}
+		}()
+		nextAttempt = time.After(delay)
+	}
+
+	var errs []error
+	start()
+	for pending > 0 {
+		select {
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				cancelLosers(r.index)
+				go closeHappyEyeballsLosers(results, pending)
+				return r.conn, nil
+			}
+			cancels[r.index]()
+			errs = append(errs, r.err)
+			if len(cancels) < len(ips) {
+				start()
+			}
+		case <-nextAttempt:
+			if len(cancels) < len(ips) {
+				start()
+			}
+		case <-ctx.Done():
+			cancelLosers(-1)
+			go closeHappyEyeballsLosers(results, pending)
+			return nil, ctx.Err()
+		}
+	}
+	return nil, newError("all connection attempts failed").Base(errs[len(errs)-1])
+}
+
+func closeHappyEyeballsLosers(results <-chan happyEyeballsResult, pending int) {
+	for ; pending > 0; pending-- {
+		if r := <-results; r.conn != nil {
+			r.conn.Close()
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package internet_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/v2fly/v2ray-core/v5/common"
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	. "github.com/v2fly/v2ray-core/v5/transport/internet"
+)
+
+func TestSortIPs(t *testing.T) {
+	ip4a := net.ParseIP("1.1.1.1")
+	ip4b := net.ParseIP("1.0.0.1")
+	ip6a := net.ParseIP("2606:4700::1111")
+	ip6b := net.ParseIP("2606:4700::1001")
+	ips := []net.IP{ip4a, ip4b, ip6a, ip6b}
+
+	cases := []struct {
+		strategy SocketConfig_DomainStrategy
+		expected []net.IP
+	}{
+		{SocketConfig_USE_IP, []net.IP{ip4a, ip6a, ip4b, ip6b}},
+		{SocketConfig_USE_IP4, []net.IP{ip4a, ip4b}},
+		{SocketConfig_USE_IP6, []net.IP{ip6a, ip6b}},
+		{SocketConfig_PREFER_IP4, []net.IP{ip4a, ip6a, ip4b, ip6b}},
+		{SocketConfig_PREFER_IP6, []net.IP{ip6a, ip4a, ip6b, ip4b}},
+	}
+	for _, c := range cases {
+		if r := cmp.Diff(SortIPs(ips, c.strategy), c.expected); r != "" {
+			t.Error(c.strategy, r)
+		}
+	}
+}
+
+type fakeConn struct {
+	net.Conn
+	ip net.IP
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func TestDialHappyEyeballs(t *testing.T) {
+	broken := net.ParseIP("2001:db8::1")
+	slow := net.ParseIP("192.0.2.1")
+	fast := net.ParseIP("198.51.100.1")
+
+	dial := func(ctx context.Context, ip net.IP) (net.Conn, error) {
+		switch {
+		case ip.Equal(broken):
+			<-ctx.Done()
+			return nil, ctx.Err()
+		case ip.Equal(slow):
+			select {
+			case <-time.After(time.Second):
+				return &fakeConn{ip: ip}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		default:
+			return &fakeConn{ip: ip}, nil
+		}
+	}
+
+	start := time.Now()
+	conn, err := DialHappyEyeballs(context.Background(), []net.IP{broken, slow, fast}, 50*time.Millisecond, dial)
+	common.Must(err)
+	if ip := conn.(*fakeConn).ip; !ip.Equal(fast) {
+		t.Error("expect the fast address to win, but got ", ip)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Error("happy eyeballs took too long: ", elapsed)
+	}
+
+	failing := func(ctx context.Context, ip net.IP) (net.Conn, error) {
+		return nil, errors.New("refused")
+	}
+	if _, err := DialHappyEyeballs(context.Background(), []net.IP{slow, fast}, time.Second, failing); err == nil {
+		t.Error("expect error when all attempts fail")
+	}
+}
+
+func TestDialHappyEyeballsWinnerContext(t *testing.T) {
+	slow := net.ParseIP("192.0.2.1")
+	fast := net.ParseIP("198.51.100.1")
+
+	contexts := make(chan context.Context, 2)
+	dial := func(ctx context.Context, ip net.IP) (net.Conn, error) {
+		contexts <- ctx
+		if ip.Equal(slow) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return &fakeConn{ip: ip}, nil
+	}
+
+	if _, err := DialHappyEyeballs(context.Background(), []net.IP{slow, fast}, 10*time.Millisecond, dial); err != nil {
+		t.Fatal(err)
+	}
+	loser, winner := <-contexts, <-contexts
+	select {
+	case <-loser.Done():
+	case <-time.After(time.Second):
+		t.Error("expect the losing attempt to be cancelled")
+	}
+	if winner.Err() != nil {
+		t.Error("expect the context of the winning attempt alive, but got ", winner.Err())
+	}
+}
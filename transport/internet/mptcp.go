@@ -0,0 +1,5 @@
+package internet
+
+// errMPTCPUnavailable is returned when Multipath TCP is not supported by the system.
+// Callers fall back to plain TCP.
+var errMPTCPUnavailable = newError("Multipath TCP is not available")
@@ -0,0 +1,227 @@
+//go:build linux
+// +build linux
+
+package internet
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// mptcpSocket creates a non-blocking Multipath TCP socket for the given IP.
+// It returns errMPTCPUnavailable if the kernel does not support MPTCP.
+func mptcpSocket(ip net.IP) (*os.File, string, error) {
+	family, network := unix.AF_INET6, "tcp6"
+	if ip.To4() != nil {
+		family, network = unix.AF_INET, "tcp4"
+	}
+	fd, err := unix.Socket(family, unix.SOCK_STREAM|unix.SOCK_NONBLOCK|unix.SOCK_CLOEXEC, unix.IPPROTO_MPTCP)
+	if err != nil {
+		switch err {
+		case unix.EPROTONOSUPPORT, unix.ENOPROTOOPT, unix.EINVAL, unix.EAFNOSUPPORT:
+			return nil, "", errMPTCPUnavailable
+		}
+		return nil, "", err
+	}
+	return os.NewFile(uintptr(fd), "mptcp"), network, nil
+}
+
+func toSockaddr(ip net.IP, port int) unix.Sockaddr {
+	if ip4 := ip.To4(); ip4 != nil {
+		sa := &unix.SockaddrInet4{Port: port}
+		copy(sa.Addr[:], ip4)
+		return sa
+	}
+	sa := &unix.SockaddrInet6{Port: port}
+	copy(sa.Addr[:], ip.To16())
+	return sa
+}
+
+// dialMPTCP dials address over Multipath TCP, trying the resolved addresses of its host one by one.
+func dialMPTCP(ctx context.Context, dialer *net.Dialer, address string) (net.Conn, error) {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, err
+	}
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		ips, err = lookupIP(ctx, host, SocketConfig_AS_IS)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(ips) == 0 {
+		return nil, newError("no IP address found for ", host)
+	}
+
+	var lastErr error
+	for _, ip := range ips {
+		conn, err := dialMPTCPAddr(ctx, dialer, ip, port)
+		if err == nil || err == errMPTCPUnavailable {
+			return conn, err
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func dialMPTCPAddr(ctx context.Context, dialer *net.Dialer, ip net.IP, port int) (net.Conn, error) {
+	portStr := strconv.Itoa(port)
+	f, network, err := mptcpSocket(ip)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rawConn, err := f.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+	if dialer.Control != nil {
+		if err := dialer.Control(network, net.JoinHostPort(ip.String(), portStr), rawConn); err != nil {
+			return nil, err
+		}
+	}
+	if local, ok := dialer.LocalAddr.(*net.TCPAddr); ok && local != nil {
+		var bindErr error
+		if err := rawConn.Control(func(fd uintptr) {
+			bindErr = unix.Bind(int(fd), toSockaddr(local.IP, local.Port))
+		}); err != nil {
+			return nil, err
+		}
+		if bindErr != nil {
+			return nil, newError("failed to bind source address ", local).Base(bindErr)
+		}
+	}
+
+	var connectErr error
+	if err := rawConn.Control(func(fd uintptr) {
+		connectErr = unix.Connect(int(fd), toSockaddr(ip, port))
+	}); err != nil {
+		return nil, err
+	}
+	if connectErr != nil && connectErr != unix.EINPROGRESS {
+		return nil, connectErr
+	}
+
+	if connectErr == unix.EINPROGRESS {
+		var deadline time.Time
+		if dialer.Timeout != 0 {
+			deadline = time.Now().Add(dialer.Timeout)
+		}
+		if d, ok := ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+			deadline = d
+		}
+		if !deadline.IsZero() {
+			if err := f.SetWriteDeadline(deadline); err != nil {
+				return nil, err
+			}
+		}
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			select {
+			case <-ctx.Done():
+				f.SetWriteDeadline(time.Unix(1, 0))
+			case <-stop:
+			}
+		}()
+
+		connectErr = nil
+		if err := rawConn.Write(func(fd uintptr) bool {
+			v, err := unix.GetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_ERROR)
+			if err != nil {
+				connectErr = err
+				return true
+			}
+			switch syscall.Errno(v) {
+			case unix.EINPROGRESS, unix.EALREADY, unix.EINTR:
+				return false
+			case 0:
+				_, err := unix.Getpeername(int(fd))
+				return err == nil
+			default:
+				connectErr = syscall.Errno(v)
+				return true
+			}
+		}); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+		if connectErr != nil {
+			return nil, connectErr
+		}
+	}
+
+	conn, err := net.FileConn(f)
+	if err != nil {
+		return nil, err
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok && dialer.KeepAlive >= 0 {
+		keepAlive := dialer.KeepAlive
+		if keepAlive == 0 {
+			keepAlive = 15 * time.Second
+		}
+		tcpConn.SetKeepAlive(true)
+		tcpConn.SetKeepAlivePeriod(keepAlive)
+	}
+	return conn, nil
+}
+
+func listenMPTCP(ctx context.Context, lc *net.ListenConfig, addr *net.TCPAddr) (net.Listener, error) {
+	ip := addr.IP
+	if ip == nil {
+		ip = net.IPv6zero
+	}
+	f, network, err := mptcpSocket(ip)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rawConn, err := f.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+	var setupErr error
+	if err := rawConn.Control(func(fd uintptr) {
+		setupErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEADDR, 1)
+	}); err != nil {
+		return nil, err
+	}
+	if setupErr != nil {
+		return nil, setupErr
+	}
+	if lc.Control != nil {
+		if err := lc.Control(network, addr.String(), rawConn); err != nil {
+			return nil, err
+		}
+	}
+	if err := rawConn.Control(func(fd uintptr) {
+		if setupErr = unix.Bind(int(fd), toSockaddr(ip, addr.Port)); setupErr != nil {
+			return
+		}
+		setupErr = unix.Listen(int(fd), unix.SOMAXCONN)
+	}); err != nil {
+		return nil, err
+	}
+	if setupErr != nil {
+		return nil, setupErr
+	}
+	return net.FileListener(f)
+}
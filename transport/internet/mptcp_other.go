@@ -0,0 +1,17 @@
+//go:build !linux
+// +build !linux
+
+package internet
+
+import (
+	"context"
+	"net"
+)
+
+func dialMPTCP(ctx context.Context, dialer *net.Dialer, address string) (net.Conn, error) {
+	return nil, errMPTCPUnavailable
+}
+
+func listenMPTCP(ctx context.Context, lc *net.ListenConfig, addr *net.TCPAddr) (net.Listener, error) {
+	return nil, errMPTCPUnavailable
+}
@@ -0,0 +1,57 @@
+package internet
+
+import (
+	"context"
+
+	"github.com/v2fly/v2ray-core/v5/common/net"
+	"github.com/v2fly/v2ray-core/v5/features/dns"
+)
+
+type resolverKey int
+
+const dnsClientKey resolverKey = 0
+
+// ContextWithDNSClient returns a new context with the DNS client, which resolves domain destinations for the system
+// dialer.
+func ContextWithDNSClient(ctx context.Context, client dns.Client) context.Context {
+	return context.WithValue(ctx, dnsClientKey, client)
+}
+
+func dnsClientFromContext(ctx context.Context) dns.Client {
+	if client, ok := ctx.Value(dnsClientKey).(dns.Client); ok {
+		return client
+	}
+	return nil
+}
+
+// lookupIP resolves domain under the given domain strategy, with the DNS client in ctx, or the system resolver if
+// there is none.
+func lookupIP(ctx context.Context, domain string, strategy SocketConfig_DomainStrategy) ([]net.IP, error) {
+	client := dnsClientFromContext(ctx)
+	if client == nil {
+		lookupNetwork := "ip"
+		switch strategy {
+		case SocketConfig_USE_IP4:
+			lookupNetwork = "ip4"
+		case SocketConfig_USE_IP6:
+			lookupNetwork = "ip6"
+		}
+		return net.DefaultResolver.LookupIP(ctx, lookupNetwork, domain)
+	}
+
+	if c, ok := client.(dns.ClientWithIPOption); ok {
+		c.SetFakeDNSOption(false) // Skip FakeDNS
+	}
+	lookupFunc := client.LookupIP
+	switch strategy {
+	case SocketConfig_USE_IP4:
+		if lookupIPv4, ok := client.(dns.IPv4Lookup); ok {
+			lookupFunc = lookupIPv4.LookupIPv4
+		}
+	case SocketConfig_USE_IP6:
+		if lookupIPv6, ok := client.(dns.IPv6Lookup); ok {
+			lookupFunc = lookupIPv6.LookupIPv6
+		}
+	}
+	return lookupFunc(domain)
+}
@@ -2,9 +2,13 @@ package internet_test
 
 import (
 	"context"
+	"io"
+	"strings"
 	"syscall"
 	"testing"
 
+	"golang.org/x/sys/unix"
+
 	"github.com/v2fly/v2ray-core/v5/common"
 	"github.com/v2fly/v2ray-core/v5/common/net"
 	"github.com/v2fly/v2ray-core/v5/testing/servers/tcp"
@@ -40,3 +44,82 @@ func TestSockOptMark(t *testing.T) {
 	})
 	common.Must(err)
 }
+
+func TestSockOptTCPCongestion(t *testing.T) {
+	tcpServer := tcp.Server{
+		MsgProcessor: func(b []byte) []byte {
+			return b
+		},
+	}
+	dest, err := tcpServer.Start()
+	common.Must(err)
+	defer tcpServer.Close()
+
+	dialer := DefaultSystemDialer{}
+	conn, err := dialer.Dial(context.Background(), nil, dest, &SocketConfig{
+		TcpCongestion:  "reno",
+		TcpUserTimeout: 5000,
+	})
+	common.Must(err)
+	defer conn.Close()
+
+	rawConn, err := conn.(*net.TCPConn).SyscallConn()
+	common.Must(err)
+	err = rawConn.Control(func(fd uintptr) {
+		cc, err := unix.GetsockoptString(int(fd), unix.IPPROTO_TCP, unix.TCP_CONGESTION)
+		common.Must(err)
+		if cc = strings.TrimRight(cc, "\x00"); cc != "reno" {
+			t.Error("unexpected congestion control ", cc)
+		}
+		timeout, err := unix.GetsockoptInt(int(fd), unix.IPPROTO_TCP, unix.TCP_USER_TIMEOUT)
+		common.Must(err)
+		if timeout != 5000 {
+			t.Error("unexpected user timeout ", timeout)
+		}
+	})
+	common.Must(err)
+}
+
+func TestSockOptMPTCP(t *testing.T) {
+	listener, err := ListenSystem(context.Background(), &net.TCPAddr{IP: net.LocalHostIP.IP()}, &SocketConfig{Mptcp: true})
+	common.Must(err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		b := make([]byte, 4)
+		if _, err := io.ReadFull(conn, b); err != nil {
+			return
+		}
+		conn.Write(b)
+	}()
+
+	dialer := DefaultSystemDialer{}
+	conn, err := dialer.Dial(context.Background(), nil, net.DestinationFromAddr(listener.Addr()), &SocketConfig{Mptcp: true})
+	common.Must(err)
+	defer conn.Close()
+
+	common.Must2(conn.Write([]byte("ping")))
+	b := make([]byte, 4)
+	common.Must2(io.ReadFull(conn, b))
+	if string(b) != "ping" {
+		t.Fatal("unexpected response ", string(b))
+	}
+}
+
+func TestSockOptFreeBind(t *testing.T) {
+	// 192.0.2.0/24 is reserved for documentation and not assigned to any interface.
+	addr := &net.UDPAddr{IP: net.ParseIP("192.0.2.1")}
+	if conn, err := ListenSystemPacket(context.Background(), addr, nil); err == nil {
+		conn.Close()
+		t.Skip("192.0.2.1 is assigned locally")
+	}
+
+	conn, err := ListenSystemPacket(context.Background(), addr, &SocketConfig{FreeBind: true})
+	common.Must(err)
+	conn.Close()
+}
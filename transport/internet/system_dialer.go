@@ -42,6 +42,48 @@ func hasBindAddr(sockopt *SocketConfig) bool {
 }
 
 func (d *DefaultSystemDialer) Dial(ctx context.Context, src net.Address, dest net.Destination, sockopt *SocketConfig) (net.Conn, error) {
+	if sockopt.ResolvesDomain() && dest.Address.Family().IsDomain() {
+		return d.dialDomain(ctx, src, dest, sockopt)
+	}
+	return d.dial(ctx, src, dest, sockopt)
+}
+
+// dialDomain resolves the domain of dest according to sockopt.DomainStrategy, and dials the resolved addresses,
+// racing them if Happy Eyeballs is enabled.
+func (d *DefaultSystemDialer) dialDomain(ctx context.Context, src net.Address, dest net.Destination, sockopt *SocketConfig) (net.Conn, error) {
+	strategy := sockopt.DomainStrategy
+	if src != nil && src != net.AnyIP {
+		// The source address decides the usable family.
+		if src.Family().IsIPv4() {
+			strategy = SocketConfig_USE_IP4
+		} else if src.Family().IsIPv6() {
+			strategy = SocketConfig_USE_IP6
+		}
+	}
+
+	ips, err := lookupIP(ctx, dest.Address.Domain(), strategy)
+	if err != nil {
+		return nil, newError("failed to resolve ", dest.Address.Domain()).Base(err)
+	}
+	ips = SortIPs(ips, strategy)
+	if len(ips) == 0 {
+		return nil, newError("no usable address for ", dest.Address.Domain(), " with domain strategy ", strategy)
+	}
+
+	dialIP := func(ctx context.Context, ip net.IP) (net.Conn, error) {
+		return d.dial(ctx, src, net.Destination{
+			Network: dest.Network,
+			Address: net.IPAddress(ip),
+			Port:    dest.Port,
+		}, sockopt)
+	}
+	if dest.Network == net.Network_TCP && sockopt.HappyEyeballs.GetEnabled() {
+		return DialHappyEyeballs(ctx, ips, sockopt.HappyEyeballs.GetTryDelayValue(), dialIP)
+	}
+	return dialIP(ctx, ips[0])
+}
+
+func (d *DefaultSystemDialer) dial(ctx context.Context, src net.Address, dest net.Destination, sockopt *SocketConfig) (net.Conn, error) {
 	if dest.Network == net.Network_UDP && !hasBindAddr(sockopt) {
 		srcAddr := resolveSrcAddr(net.Network_UDP, src)
 		if srcAddr == nil {
@@ -54,7 +96,16 @@ func (d *DefaultSystemDialer) Dial(ctx context.Context, src net.Address, dest ne
 		if err != nil {
 			return nil, err
 		}
-		destAddr, err := net.ResolveUDPAddr("udp", dest.NetAddr())
+		// A socket bound to a source address only reaches destinations of the same family.
+		resolveNetwork := "udp"
+		if udpAddr := srcAddr.(*net.UDPAddr); !udpAddr.IP.IsUnspecified() {
+			if udpAddr.IP.To4() != nil {
+				resolveNetwork = "udp4"
+			} else {
+				resolveNetwork = "udp6"
+			}
+		}
+		destAddr, err := net.ResolveUDPAddr(resolveNetwork, dest.NetAddr())
 		if err != nil {
 			return nil, err
 		}
@@ -96,6 +147,14 @@ func (d *DefaultSystemDialer) Dial(ctx context.Context, src net.Address, dest ne
 		}
 	}
 
+	if sockopt != nil && sockopt.Mptcp && dest.Network == net.Network_TCP {
+		conn, err := dialMPTCP(ctx, dialer, dest.NetAddr())
+		if err != errMPTCPUnavailable {
+			return conn, err
+		}
+		newError("Multipath TCP is not available, falling back to TCP").AtDebug().WriteToLog(session.ExportIDToError(ctx))
+	}
+
 	return dialer.DialContext(ctx, dest.Network.SystemString(), dest.NetAddr())
 }
 
@@ -78,7 +78,15 @@ func (dl *DefaultListener) Listen(ctx context.Context, addr net.Addr, sockopt *S
 		}
 	}
 
-	l, err = lc.Listen(ctx, network, address)
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok && sockopt != nil && sockopt.Mptcp {
+		l, err = listenMPTCP(ctx, &lc, tcpAddr)
+		if err == errMPTCPUnavailable {
+			newError("Multipath TCP is not available, falling back to TCP").AtDebug().WriteToLog(session.ExportIDToError(ctx))
+			l, err = lc.Listen(ctx, network, address)
+		}
+	} else {
+		l, err = lc.Listen(ctx, network, address)
+	}
 	if sockopt != nil && sockopt.AcceptProxyProtocol {
 		policyFunc := func(upstream net.Addr) (proxyproto.Policy, error) { return proxyproto.REQUIRE, nil }
 		l = &proxyproto.Listener{Listener: l, Policy: policyFunc}
@@ -111,3 +119,22 @@ type SystemListener interface {
 	Listen(ctx context.Context, addr net.Addr, sockopt *SocketConfig) (net.Listener, error)
 	ListenPacket(ctx context.Context, addr net.Addr, sockopt *SocketConfig) (net.PacketConn, error)
 }
+
+type systemListenerKey int
+
+const systemListenerContextKey systemListenerKey = 0
+
+// ContextWithSystemListener returns a new context in which ListenSystem and ListenSystemPacket
+// are served by the given SystemListener instead of the operating system.
+//
+// v2ray:api:beta
+func ContextWithSystemListener(ctx context.Context, listener SystemListener) context.Context {
+	return context.WithValue(ctx, systemListenerContextKey, listener)
+}
+
+func systemListenerFromContext(ctx context.Context) SystemListener {
+	if listener, ok := ctx.Value(systemListenerContextKey).(SystemListener); ok {
+		return listener
+	}
+	return &effectiveListener
+}
@@ -90,12 +90,12 @@ func ListenTCP(ctx context.Context, address net.Address, port net.Port, settings
 //
 // v2ray:api:beta
 func ListenSystem(ctx context.Context, addr net.Addr, sockopt *SocketConfig) (net.Listener, error) {
-	return effectiveListener.Listen(ctx, addr, sockopt)
+	return systemListenerFromContext(ctx).Listen(ctx, addr, sockopt)
 }
 
 // ListenSystemPacket listens on a local address for incoming UDP connections.
 //
 // v2ray:api:beta
 func ListenSystemPacket(ctx context.Context, addr net.Addr, sockopt *SocketConfig) (net.PacketConn, error) {
-	return effectiveListener.ListenPacket(ctx, addr, sockopt)
+	return systemListenerFromContext(ctx).ListenPacket(ctx, addr, sockopt)
 }